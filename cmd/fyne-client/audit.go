@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"image/color"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// auditEntities — объекты журнала; пустая строка — все
+var auditEntities = []string{"", "user", "group", "group_discipline", "discipline", "term", "task",
+	"work", "criteria_group", "criterion", "rubric", "invitation", "directory"}
+
+// auditDate разбирает дату вида 02.01.2006 в RFC3339 по местному времени; пустая
+// строка — без ограничения
+func auditDate(s string, nextDay bool) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", nil
+	}
+	t, err := time.ParseInLocation("02.01.2006", s, time.Local)
+	if err != nil {
+		return "", fmt.Errorf("дата %q должна быть в формате ДД.ММ.ГГГГ", s)
+	}
+	if nextDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t.Format(time.RFC3339), nil
+}
+
+// prettyJSON выравнивает снимок для просмотра
+func prettyJSON(s string) string {
+	if s == "" {
+		return "—"
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(s), "", "  "); err != nil {
+		return s
+	}
+	return buf.String()
+}
+
+func showAuditEntryDialog(w fyne.Window, e *superaccpb.AuditEntry) {
+	snapshot := func(s string) fyne.CanvasObject {
+		text := widget.NewMultiLineEntry()
+		text.SetText(prettyJSON(s))
+		text.Wrapping = fyne.TextWrapOff
+		text.Disable()
+		scroll := container.NewScroll(text)
+		scroll.SetMinSize(fyne.NewSize(380, 360))
+		return scroll
+	}
+	actor := fmt.Sprintf("%d %s (%s)", e.ActorId, e.ActorEmail, e.ActorRole)
+	if e.ImpersonatorId > 0 {
+		actor += fmt.Sprintf(", от имени суперпользователя %d", e.ImpersonatorId)
+	}
+	content := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("Кто", widget.NewLabel(actor)),
+			widget.NewFormItem("Метод", widget.NewLabel(e.Method)),
+			widget.NewFormItem("Объект", widget.NewLabel(fmt.Sprintf("%s %s", e.Entity, strings.Join(e.TargetIds, ", ")))),
+			widget.NewFormItem("Результат", widget.NewLabel(e.StatusCode)),
+		),
+		container.NewGridWithColumns(2,
+			container.NewBorder(widget.NewLabel("До"), nil, nil, nil, snapshot(e.BeforeJson)),
+			container.NewBorder(widget.NewLabel("После"), nil, nil, nil, snapshot(e.AfterJson)),
+		),
+	)
+	dialog.ShowCustom(fmt.Sprintf("Запись %d", e.Id), "Закрыть", content, w)
+}
+
+func CreateAuditLogPage(state *AppState) fyne.CanvasObject {
+	w := state.window
+	headerTextColor := color.White
+	darkBlue := color.NRGBA{R: 20, G: 40, B: 80, A: 255}
+
+	headerTitle := canvas.NewText("Журнал изменений", headerTextColor)
+	headerTitle.TextStyle.Bold = true
+	headerTitle.TextSize = 20
+	headerTitle.Alignment = fyne.TextAlignCenter
+
+	backButton := widget.NewButton("Назад", func() {
+		state.currentPage = "superacc-groups"
+		w.SetContent(createContent(state))
+	})
+	header := container.NewBorder(nil, nil, backButton, nil, container.NewCenter(headerTitle))
+
+	actorEntry := widget.NewEntry()
+	actorEntry.SetPlaceHolder("id пользователя")
+	methodEntry := widget.NewEntry()
+	methodEntry.SetPlaceHolder("например, UpdateUserRole")
+	entitySelect := widget.NewSelect(auditEntities, nil)
+	entitySelect.PlaceHolder = "все"
+	targetEntry := widget.NewEntry()
+	targetEntry.SetPlaceHolder("id или почта")
+	sinceEntry := widget.NewEntry()
+	sinceEntry.SetPlaceHolder("ДД.ММ.ГГГГ")
+	untilEntry := widget.NewEntry()
+	untilEntry.SetPlaceHolder("ДД.ММ.ГГГГ")
+
+	rows := container.NewVBox()
+	var nextBeforeID int64
+	moreButton := widget.NewButton("Загрузить ещё", nil)
+	moreButton.Hide()
+
+	load := func(reset bool) {
+		req := &superaccpb.QueryAuditLogRequest{
+			Method:   methodEntry.Text,
+			Entity:   entitySelect.Selected,
+			TargetId: targetEntry.Text,
+		}
+		if s := strings.TrimSpace(actorEntry.Text); s != "" {
+			id, err := strconv.Atoi(s)
+			if err != nil {
+				dialog.ShowInformation("Ошибка", "id пользователя должен быть числом", w)
+				return
+			}
+			req.ActorId = int32(id)
+		}
+		var err error
+		if req.Since, err = auditDate(sinceEntry.Text, false); err == nil {
+			req.Until, err = auditDate(untilEntry.Text, true)
+		}
+		if err != nil {
+			dialog.ShowInformation("Ошибка", err.Error(), w)
+			return
+		}
+		if !reset {
+			req.BeforeId = nextBeforeID
+		}
+
+		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+		if err != nil {
+			log.Printf("Failed to connect to superaccservice: %v", err)
+			return
+		}
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		resp, err := superaccpb.NewSuperAccServiceClient(conn).QueryAuditLog(ctx, req)
+		if err == nil && !resp.Success {
+			err = errors.New(resp.Message)
+		}
+		if err != nil {
+			log.Printf("Failed to query audit log: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+
+		if reset {
+			rows.RemoveAll()
+			if len(resp.Entries) == 0 {
+				rows.Add(container.NewCenter(widget.NewLabel("Записей нет")))
+			}
+		}
+		for _, e := range resp.Entries {
+			e := e
+			createdAt := e.CreatedAt
+			if t, err := time.Parse(time.RFC3339, e.CreatedAt); err == nil {
+				createdAt = t.Local().Format("02.01.2006 15:04:05")
+			}
+			method := e.Method[strings.LastIndex(e.Method, "/")+1:]
+			result := "успешно"
+			if !e.Succeeded {
+				result = "отказ: " + e.StatusCode
+			}
+			who := e.ActorEmail
+			if who == "" {
+				who = fmt.Sprintf("пользователь %d", e.ActorId)
+			}
+			info := widget.NewLabel(fmt.Sprintf("%s  %s — %s %s [%s], %s",
+				createdAt, who, method, e.Entity, strings.Join(e.TargetIds, ", "), result))
+			info.Wrapping = fyne.TextWrapWord
+			detailsButton := widget.NewButton("Подробнее", func() {
+				showAuditEntryDialog(w, e)
+			})
+			rows.Add(container.NewBorder(nil, nil, nil, detailsButton, info))
+		}
+		nextBeforeID = resp.NextBeforeId
+		if nextBeforeID > 0 {
+			moreButton.Show()
+		} else {
+			moreButton.Hide()
+		}
+		rows.Refresh()
+	}
+	moreButton.OnTapped = func() { load(false) }
+	searchButton := widget.NewButton("Найти", func() { load(true) })
+
+	filters := container.NewVBox(
+		container.NewGridWithColumns(3,
+			widget.NewForm(widget.NewFormItem("Кто", actorEntry)),
+			widget.NewForm(widget.NewFormItem("Метод", methodEntry)),
+			widget.NewForm(widget.NewFormItem("Объект", entitySelect)),
+		),
+		container.NewGridWithColumns(3,
+			widget.NewForm(widget.NewFormItem("id объекта", targetEntry)),
+			widget.NewForm(widget.NewFormItem("С", sinceEntry)),
+			widget.NewForm(widget.NewFormItem("По", untilEntry)),
+		),
+		searchButton,
+	)
+
+	load(true)
+
+	scroll := container.NewVScroll(container.NewVBox(rows, moreButton))
+	scroll.SetMinSize(fyne.NewSize(0, 450))
+
+	content := container.NewStack(
+		canvas.NewRectangle(color.White),
+		container.NewPadded(container.NewBorder(filters, nil, nil, nil, scroll)),
+	)
+
+	return container.NewStack(
+		canvas.NewRectangle(darkBlue),
+		container.NewBorder(container.NewPadded(header), nil, nil, nil, content),
+	)
+}
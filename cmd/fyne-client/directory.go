@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// directoryFormat выбирает формат справочника по расширению файла
+func directoryFormat(name string) string {
+	if strings.HasSuffix(strings.ToLower(name), ".json") {
+		return "json"
+	}
+	return "csv"
+}
+
+// exportDirectory сохраняет выгрузку справочника в файл по мере получения частей
+func exportDirectory(state *AppState, format string, out io.Writer) error {
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	stream, err := superaccpb.NewSuperAccServiceClient(conn).ExportDirectory(ctx, &superaccpb.ExportDirectoryRequest{Format: format})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := out.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// importDirectory отправляет файл справочника в ImportDirectory частями
+func importDirectory(state *AppState, data []byte, options *superaccpb.ImportDirectoryOptions) (*superaccpb.ImportDirectoryResponse, error) {
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	stream, err := superaccpb.NewSuperAccServiceClient(conn).ImportDirectory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = stream.Send(&superaccpb.ImportDirectoryRequest{
+		Payload: &superaccpb.ImportDirectoryRequest_Options{Options: options},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(data); start += importChunkSize {
+		end := min(start+importChunkSize, len(data))
+		err := stream.Send(&superaccpb.ImportDirectoryRequest{
+			Payload: &superaccpb.ImportDirectoryRequest_Chunk{Chunk: data[start:end]},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// directoryReport показывает, сколько записей создано или обновлено, и найденные проблемы
+func directoryReport(resp *superaccpb.ImportDirectoryResponse) fyne.CanvasObject {
+	rows := container.NewVBox(
+		widget.NewLabel(resp.Message),
+		widget.NewLabel(fmt.Sprintf("Пользователи: создано %d, обновлено %d", resp.UsersCreated, resp.UsersUpdated)),
+		widget.NewLabel(fmt.Sprintf("Группы: создано %d, обновлено %d", resp.GroupsCreated, resp.GroupsUpdated)),
+		widget.NewLabel(fmt.Sprintf("Участники групп: добавлено %d", resp.MembershipsCreated)),
+		widget.NewLabel(fmt.Sprintf("Семестры: создано %d, обновлено %d", resp.TermsCreated, resp.TermsUpdated)),
+		widget.NewLabel(fmt.Sprintf("Дисциплины: создано %d, обновлено %d", resp.DisciplinesCreated, resp.DisciplinesUpdated)),
+		widget.NewLabel(fmt.Sprintf("Назначения дисциплин: создано %d, обновлено %d", resp.AssignmentsCreated, resp.AssignmentsUpdated)),
+	)
+	if len(resp.Problems) > 0 {
+		rows.Add(widget.NewLabelWithStyle("Проблемы", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+		for _, problem := range resp.Problems {
+			label := widget.NewLabel(problem)
+			label.Wrapping = fyne.TextWrapWord
+			rows.Add(label)
+		}
+	}
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(600, 400))
+	return scroll
+}
+
+// showDirectoryDialog — выгрузка справочника в CSV/JSON и загрузка такой выгрузки
+// с пробным прогоном перед записью
+func showDirectoryDialog(state *AppState) {
+	w := state.window
+
+	exportButton := widget.NewButton("Выгрузить в файл", func() {
+		save := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if writer == nil {
+				return
+			}
+			defer writer.Close()
+			if err := exportDirectory(state, directoryFormat(writer.URI().Name()), writer); err != nil {
+				log.Printf("Failed to export directory: %v", err)
+				dialog.ShowError(err, w)
+				return
+			}
+			dialog.ShowInformation("Готово", "Справочник выгружен", w)
+		}, w)
+		save.SetFileName(fmt.Sprintf("directory-%s.csv", time.Now().Format("2006-01-02")))
+		save.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".json"}))
+		save.Show()
+	})
+
+	updateExisting := widget.NewCheck("Обновлять существующие записи", nil)
+	sendInvitations := widget.NewCheck("Отправить приглашения новым пользователям", nil)
+
+	importButton := widget.NewButton("Загрузить из файла", func() {
+		open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+			if reader == nil {
+				return
+			}
+			defer reader.Close()
+			data, err := io.ReadAll(reader)
+			if err != nil {
+				log.Printf("Failed to read directory file: %v", err)
+				dialog.ShowError(err, w)
+				return
+			}
+			options := &superaccpb.ImportDirectoryOptions{
+				Format:          directoryFormat(reader.URI().Name()),
+				DryRun:          true,
+				UpdateExisting:  updateExisting.Checked,
+				SendInvitations: sendInvitations.Checked,
+			}
+
+			resp, err := importDirectory(state, data, options)
+			if err != nil {
+				log.Printf("Failed to import directory (dry run): %v", err)
+				dialog.ShowError(err, w)
+				return
+			}
+			if !resp.Success {
+				dialog.ShowCustom("Проверка справочника", "Закрыть", directoryReport(resp), w)
+				return
+			}
+			dialog.ShowCustomConfirm("Проверка справочника", "Записать", "Отмена", directoryReport(resp), func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				options.DryRun = false
+				resp, err := importDirectory(state, data, options)
+				if err != nil {
+					log.Printf("Failed to import directory: %v", err)
+					dialog.ShowError(err, w)
+					return
+				}
+				dialog.ShowCustom("Загрузка справочника", "Закрыть", directoryReport(resp), w)
+			}, w)
+		}, w)
+		open.SetFilter(storage.NewExtensionFileFilter([]string{".csv", ".json"}))
+		open.Show()
+	})
+
+	content := container.NewVBox(
+		widget.NewLabel("Пользователи, группы, состав групп, семестры, дисциплины и их назначения.\nФормат файла определяется расширением: .csv или .json."),
+		exportButton,
+		widget.NewSeparator(),
+		updateExisting,
+		sendInvitations,
+		importButton,
+	)
+	dialog.ShowCustom("Справочник", "Закрыть", content, w)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"log"
+	"strconv"
+	"time"
+
+	workpb "rubr/proto/work"
+)
+
+// showCloneDisciplineDialog копирует дисциплину лектора с заданиями и критериями в другой
+// семестр; сдвиг дедлайнов по умолчанию — разница между началами семестров
+func showCloneDisciplineDialog(state *AppState, lectorID int32) {
+	w := state.window
+	conn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		log.Printf("Failed to connect to workservice: %v", err)
+		dialog.ShowError(err, w)
+		return
+	}
+	client := workpb.NewWorkServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	termsResp, err := client.GetTerms(ctx, &workpb.GetTermsRequest{})
+	if err == nil && termsResp.Error != "" {
+		err = errors.New(termsResp.Error)
+	}
+	if err != nil {
+		conn.Close()
+		log.Printf("Failed to get terms: %v", err)
+		dialog.ShowError(err, w)
+		return
+	}
+	terms := termsResp.Terms
+
+	var sourceOptions, targetOptions []string
+	var targetTerms []*workpb.GetTermsResponse_Term
+	for _, t := range terms {
+		label := fmt.Sprintf("%s (%s)", t.Name, termStateNames[t.State])
+		sourceOptions = append(sourceOptions, label)
+		if t.State != "archived" {
+			targetOptions = append(targetOptions, label)
+			targetTerms = append(targetTerms, t)
+		}
+	}
+	if len(targetTerms) == 0 {
+		conn.Close()
+		dialog.ShowInformation("Ошибка", "Нет семестров, в которые можно копировать", w)
+		return
+	}
+
+	var disciplines []*workpb.GetDisciplinesResponse_Discipline
+	disciplineSelect := widget.NewSelect(nil, nil)
+	offsetEntry := widget.NewEntry()
+	offsetEntry.SetText("0")
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Как у исходной")
+	copyAssignments := widget.NewCheck("Перенести назначения групп", nil)
+
+	sourceSelect := widget.NewSelect(sourceOptions, nil)
+	targetSelect := widget.NewSelect(targetOptions, nil)
+	updateOffset := func() {
+		si, ti := sourceSelect.SelectedIndex(), targetSelect.SelectedIndex()
+		if si < 0 || ti < 0 {
+			return
+		}
+		from, err1 := time.Parse("2006-01-02", terms[si].StartsOn)
+		to, err2 := time.Parse("2006-01-02", targetTerms[ti].StartsOn)
+		if err1 == nil && err2 == nil {
+			offsetEntry.SetText(strconv.Itoa(int(to.Sub(from).Hours() / 24)))
+		}
+	}
+	sourceSelect.OnChanged = func(string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		resp, err := client.GetDisciplines(ctx, &workpb.GetDisciplinesRequest{LectorId: lectorID, TermId: terms[sourceSelect.SelectedIndex()].Id})
+		if err != nil {
+			log.Printf("Failed to get disciplines: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		// Копировать дисциплину может только её владелец
+		disciplines = nil
+		var names []string
+		for _, d := range resp.Disciplines {
+			if d.Role == "owner" {
+				disciplines = append(disciplines, d)
+				names = append(names, d.Name)
+			}
+		}
+		disciplineSelect.Options = names
+		disciplineSelect.ClearSelected()
+		disciplineSelect.Refresh()
+		updateOffset()
+	}
+	targetSelect.OnChanged = func(string) { updateOffset() }
+
+	form := dialog.NewForm("Копировать дисциплину", "Копировать", "Отмена", []*widget.FormItem{
+		widget.NewFormItem("Из семестра", sourceSelect),
+		widget.NewFormItem("Дисциплина", disciplineSelect),
+		widget.NewFormItem("В семестр", targetSelect),
+		widget.NewFormItem("Название копии", nameEntry),
+		widget.NewFormItem("Сдвиг дедлайнов, дней", offsetEntry),
+		widget.NewFormItem("", copyAssignments),
+	}, func(confirmed bool) {
+		if !confirmed {
+			return
+		}
+		if disciplineSelect.SelectedIndex() < 0 || targetSelect.SelectedIndex() < 0 {
+			dialog.ShowInformation("Ошибка", "Выберите дисциплину и семестр", w)
+			return
+		}
+		offset, err := strconv.Atoi(offsetEntry.Text)
+		if err != nil {
+			dialog.ShowInformation("Ошибка", "Сдвиг дедлайнов должен быть целым числом дней", w)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		resp, err := client.CloneDiscipline(ctx, &workpb.CloneDisciplineRequest{
+			DisciplineId:         disciplines[disciplineSelect.SelectedIndex()].Id,
+			TargetTermId:         targetTerms[targetSelect.SelectedIndex()].Id,
+			Name:                 nameEntry.Text,
+			DeadlineOffsetDays:   int32(offset),
+			CopyGroupAssignments: copyAssignments.Checked,
+		})
+		if err == nil && resp.Error != "" {
+			err = errors.New(resp.Error)
+		}
+		if err != nil {
+			log.Printf("Failed to clone discipline: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		dialog.ShowInformation("Готово", fmt.Sprintf("Скопировано заданий: %d, групп критериев: %d, критериев: %d",
+			len(resp.TaskIds), len(resp.CriteriaGroupIds), len(resp.CriteriaIds)), w)
+	}, w)
+	form.SetOnClosed(func() { conn.Close() })
+	form.Show()
+}
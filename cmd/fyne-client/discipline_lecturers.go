@@ -0,0 +1,191 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"log"
+	"time"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// lecturerRoles — роли лектора в дисциплине и их подписи
+var lecturerRoles = []string{"owner", "co_lecturer", "read_only"}
+
+var lecturerRoleNames = map[string]string{
+	"owner":       "Владелец",
+	"co_lecturer": "Соавтор",
+	"read_only":   "Только чтение",
+}
+
+// showDisciplineLecturersDialog — лекторы дисциплины выбранного семестра: смена роли,
+// удаление и добавление лектора
+func showDisciplineLecturersDialog(state *AppState) {
+	w := state.window
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		log.Printf("Failed to connect to superaccservice: %v", err)
+		dialog.ShowInformation("Ошибка", "Не удалось подключиться к серверу", w)
+		return
+	}
+	client := superaccpb.NewSuperAccServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	users, err := listAllUserPages(ctx, client, &superaccpb.ListAllUsersRequest{Roles: []string{"lecturer"}})
+	if err != nil {
+		conn.Close()
+		log.Printf("Failed to list lecturers: %v", err)
+		dialog.ShowError(err, w)
+		return
+	}
+	var userOptions []string
+	var userIDs []int32
+	for _, u := range users {
+		userOptions = append(userOptions, fmt.Sprintf("%s (%s)", u.Fio, u.Email))
+		userIDs = append(userIDs, u.Id)
+	}
+	var roleOptions []string
+	for _, r := range lecturerRoles {
+		roleOptions = append(roleOptions, lecturerRoleNames[r])
+	}
+
+	manage := func(req *superaccpb.ManageDisciplineLecturerRequest) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		resp, err := client.ManageDisciplineLecturer(ctx, req)
+		if err == nil && !resp.Success {
+			err = errors.New(resp.Message)
+		}
+		if err != nil {
+			log.Printf("Failed to %s lecturer %d: %v", req.Action, req.UserId, err)
+			dialog.ShowError(err, w)
+		}
+		return err
+	}
+
+	var disciplines []*superaccpb.Discipline
+	disciplineSelect := widget.NewSelect(nil, nil)
+	rows := container.NewVBox()
+	var reload func()
+	reload = func() {
+		rows.RemoveAll()
+		i := disciplineSelect.SelectedIndex()
+		if i < 0 {
+			rows.Refresh()
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		resp, err := client.ListDisciplineLecturers(ctx, &superaccpb.ListDisciplineLecturersRequest{DisciplineId: disciplines[i].Id})
+		if err == nil && !resp.Success {
+			err = errors.New(resp.Message)
+		}
+		if err != nil {
+			log.Printf("Failed to list lecturers of discipline %d: %v", disciplines[i].Id, err)
+			dialog.ShowError(err, w)
+			return
+		}
+		if len(resp.Lecturers) == 0 {
+			rows.Add(widget.NewLabel("Лекторы не назначены"))
+		}
+		for _, l := range resp.Lecturers {
+			l := l
+			disciplineID := disciplines[i].Id
+			roleSelect := widget.NewSelect(roleOptions, nil)
+			roleSelect.SetSelected(lecturerRoleNames[l.Role])
+			roleSelect.OnChanged = func(string) {
+				role := lecturerRoles[roleSelect.SelectedIndex()]
+				if role == l.Role {
+					return
+				}
+				manage(&superaccpb.ManageDisciplineLecturerRequest{DisciplineId: disciplineID, Action: "set", UserId: l.UserId, Role: role})
+				reload()
+			}
+			removeButton := widget.NewButton("Убрать", func() {
+				manage(&superaccpb.ManageDisciplineLecturerRequest{DisciplineId: disciplineID, Action: "remove", UserId: l.UserId})
+				reload()
+			})
+			rows.Add(container.NewBorder(nil, nil,
+				widget.NewLabel(fmt.Sprintf("%s (%s)", l.Fio, l.Email)), removeButton,
+				roleSelect))
+		}
+		rows.Refresh()
+	}
+	disciplineSelect.OnChanged = func(string) { reload() }
+
+	terms, err := listTerms(ctx, client)
+	if err != nil {
+		conn.Close()
+		log.Printf("Failed to list terms: %v", err)
+		dialog.ShowError(err, w)
+		return
+	}
+	termSelector, selectedTerm := termSelect(terms)
+	loadDisciplines := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		resp, err := client.ListDisciplines(ctx, &superaccpb.ListDisciplinesRequest{TermId: selectedTerm()})
+		if err != nil {
+			log.Printf("Failed to list disciplines: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		disciplines = resp.Disciplines
+		var names []string
+		for _, d := range disciplines {
+			names = append(names, d.Name)
+		}
+		disciplineSelect.Options = names
+		disciplineSelect.ClearSelected()
+		disciplineSelect.Refresh()
+	}
+	termSelector.OnChanged = func(string) { loadDisciplines() }
+	loadDisciplines()
+
+	userSelect := widget.NewSelect(userOptions, nil)
+	roleSelect := widget.NewSelect(roleOptions, nil)
+	roleSelect.SetSelectedIndex(1)
+	addButton := widget.NewButton("Назначить", func() {
+		if disciplineSelect.SelectedIndex() < 0 || userSelect.SelectedIndex() < 0 {
+			dialog.ShowInformation("Ошибка", "Выберите дисциплину и лектора", w)
+			return
+		}
+		err := manage(&superaccpb.ManageDisciplineLecturerRequest{
+			DisciplineId: disciplines[disciplineSelect.SelectedIndex()].Id,
+			Action:       "set",
+			UserId:       userIDs[userSelect.SelectedIndex()],
+			Role:         lecturerRoles[roleSelect.SelectedIndex()],
+		})
+		if err == nil {
+			userSelect.ClearSelected()
+		}
+		reload()
+	})
+
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(600, 220))
+	content := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("Семестр", termSelector),
+			widget.NewFormItem("Дисциплина", disciplineSelect),
+		),
+		widget.NewLabel("Владелец у дисциплины один; новый владелец становится вместо прежнего, прежний — соавтором."),
+		scroll,
+		widget.NewSeparator(),
+		widget.NewForm(
+			widget.NewFormItem("Лектор", userSelect),
+			widget.NewFormItem("Роль", roleSelect),
+		),
+		addButton,
+	)
+	d := dialog.NewCustom("Лекторы дисциплин", "Закрыть", content, w)
+	d.SetOnClosed(func() { conn.Close() })
+	d.Show()
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"log"
+	"time"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// staffRoles — роли в составе преподавателей группы и их подписи
+var staffRoles = []string{"seminarist", "assistant"}
+
+var staffRoleNames = map[string]string{
+	"seminarist": "Семинарист",
+	"assistant":  "Ассистент",
+}
+
+// showDisciplineStaffDialog — семинаристы и ассистенты группы по её дисциплинам
+// текущего семестра: список с удалением и добавление нового преподавателя
+func showDisciplineStaffDialog(state *AppState, groupID int32, groupName string, attachedDisciplines []string) {
+	w := state.window
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		log.Printf("Failed to connect to superaccservice: %v", err)
+		dialog.ShowInformation("Ошибка", "Не удалось подключиться к серверу", w)
+		return
+	}
+	client := superaccpb.NewSuperAccServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	disciplinesResp, err := client.ListDisciplines(ctx, &superaccpb.ListDisciplinesRequest{})
+	if err != nil {
+		conn.Close()
+		log.Printf("Failed to list disciplines: %v", err)
+		dialog.ShowError(err, w)
+		return
+	}
+	var disciplineOptions []string
+	var disciplineIDs []int32
+	for _, d := range disciplinesResp.Disciplines {
+		if contains(attachedDisciplines, d.Name) {
+			disciplineOptions = append(disciplineOptions, d.Name)
+			disciplineIDs = append(disciplineIDs, d.Id)
+		}
+	}
+	if len(disciplineIDs) == 0 {
+		conn.Close()
+		dialog.ShowInformation("Информация", "К группе не прикреплено дисциплин текущего семестра", w)
+		return
+	}
+	users, err := listAllUserPages(ctx, client, &superaccpb.ListAllUsersRequest{Roles: staffRoles})
+	if err != nil {
+		conn.Close()
+		log.Printf("Failed to list users: %v", err)
+		dialog.ShowError(err, w)
+		return
+	}
+	var userOptions []string
+	var userIDs []int32
+	for _, u := range users {
+		userOptions = append(userOptions, fmt.Sprintf("%s (%s)", u.Fio, u.Email))
+		userIDs = append(userIDs, u.Id)
+	}
+
+	manage := func(req *superaccpb.ManageDisciplineRequest) error {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		resp, err := client.ManageDiscipline(ctx, req)
+		if err == nil && !resp.Success {
+			err = errors.New(resp.Message)
+		}
+		return err
+	}
+
+	rows := container.NewVBox()
+	var reload func()
+	reload = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		resp, err := client.ListDisciplineStaff(ctx, &superaccpb.ListDisciplineStaffRequest{GroupId: groupID})
+		if err == nil && !resp.Success {
+			err = errors.New(resp.Message)
+		}
+		if err != nil {
+			log.Printf("Failed to list staff of group %d: %v", groupID, err)
+			dialog.ShowError(err, w)
+			return
+		}
+		rows.RemoveAll()
+		if len(resp.Staff) == 0 {
+			rows.Add(widget.NewLabel("Преподаватели не назначены"))
+		}
+		for _, m := range resp.Staff {
+			m := m
+			removeButton := widget.NewButton("Убрать", func() {
+				err := manage(&superaccpb.ManageDisciplineRequest{
+					DisciplineId: m.DisciplineId,
+					GroupId:      groupID,
+					Action:       "remove",
+					UserId:       m.UserId,
+					Role:         m.Role,
+				})
+				if err != nil {
+					log.Printf("Failed to remove %s %d: %v", m.Role, m.UserId, err)
+					dialog.ShowError(err, w)
+				}
+				reload()
+			})
+			rows.Add(container.NewBorder(nil, nil,
+				widget.NewLabel(fmt.Sprintf("%s — %s: %s (%s)", m.DisciplineName, staffRoleNames[m.Role], m.Fio, m.Email)), nil,
+				removeButton))
+		}
+		rows.Refresh()
+	}
+	reload()
+
+	disciplineSelect := widget.NewSelect(disciplineOptions, nil)
+	disciplineSelect.SetSelectedIndex(0)
+	var roleOptions []string
+	for _, r := range staffRoles {
+		roleOptions = append(roleOptions, staffRoleNames[r])
+	}
+	roleSelect := widget.NewSelect(roleOptions, nil)
+	roleSelect.SetSelectedIndex(0)
+	userSelect := widget.NewSelect(userOptions, nil)
+	addButton := widget.NewButton("Добавить", func() {
+		if userSelect.SelectedIndex() < 0 {
+			dialog.ShowInformation("Ошибка", "Выберите преподавателя", w)
+			return
+		}
+		err := manage(&superaccpb.ManageDisciplineRequest{
+			DisciplineId: disciplineIDs[disciplineSelect.SelectedIndex()],
+			GroupId:      groupID,
+			Action:       "add",
+			UserId:       userIDs[userSelect.SelectedIndex()],
+			Role:         staffRoles[roleSelect.SelectedIndex()],
+		})
+		if err != nil {
+			log.Printf("Failed to add staff to group %d: %v", groupID, err)
+			dialog.ShowError(err, w)
+			return
+		}
+		userSelect.ClearSelected()
+		reload()
+	})
+
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(600, 250))
+	content := container.NewVBox(
+		widget.NewLabel("Работы студентов распределяются между семинаристами группы по дисциплине."),
+		scroll,
+		widget.NewSeparator(),
+		widget.NewForm(
+			widget.NewFormItem("Дисциплина", disciplineSelect),
+			widget.NewFormItem("Роль", roleSelect),
+			widget.NewFormItem("Преподаватель", userSelect),
+		),
+		addButton,
+	)
+	d := dialog.NewCustom("Преподаватели группы "+groupName, "Закрыть", content, w)
+	d.SetOnClosed(func() { conn.Close() })
+	d.Show()
+}
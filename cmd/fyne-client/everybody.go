@@ -12,11 +12,8 @@ import (
 	"google.golang.org/grpc"
 	"image/color"
 	"log"
-	"math/rand"
-	"strconv"
-	"time"
+	"strings"
 
-	notifypb "rubr/proto/notification"
 	userpb "rubr/proto/user"
 )
 
@@ -37,12 +34,19 @@ func CreateGreetingPage(state *AppState) fyne.CanvasObject {
 	})
 	registerButton.Importance = widget.MediumImportance
 
+	invitationButton := widget.NewButton("Принять приглашение", func() {
+		state.currentPage = "accept_invitation"
+		state.window.SetContent(createContent(state))
+	})
+	invitationButton.Importance = widget.LowImportance
+
 	// Централизуем всё содержимое: логотип + кнопки
 	centeredContent := container.NewVBox(
 		logo,
 		widget.NewLabel(""), // Можно использовать spacer при желании
 		loginButton,
 		registerButton,
+		invitationButton,
 	)
 
 	leftContainer := container.NewCenter(centeredContent)
@@ -70,7 +74,7 @@ func CreateAuthorizationPage(state *AppState) fyne.CanvasObject {
 	passwordEntry.SetPlaceHolder("Введите пароль")
 
 	enterButton := widget.NewButton("Войти в аккаунт", func() {
-		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure())
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Failed to connect to userservice: %v", err)
 			return
@@ -86,28 +90,30 @@ func CreateAuthorizationPage(state *AppState) fyne.CanvasObject {
 			log.Printf("Login failed: %v", err)
 			return
 		}
+		if resp.Error == "Email is not verified" {
+			state.pendingEmail = loginEntry.Text
+			state.currentPage = "email_verification"
+			state.window.SetContent(createContent(state))
+			return
+		}
 		if resp.Error != "" {
 			log.Println("Login error:", resp.Error)
 			return
 		}
 
-		state.userID = resp.UserId
-		state.role = resp.Role
-		switch state.role {
-		case "lecturer":
-			state.currentPage = "lector_works"
-		case "superaccount":
-			state.currentPage = "superacc-groups"
-		case "assistant":
-			state.currentPage = "assistant_works"
-		case "student":
-			state.currentPage = "student_grades"
-		case "seminarist":
-			state.currentPage = "seminarist_works"
-		default:
-			state.currentPage = "greeting"
+		// Второй шаг: промежуточный токен годится только для TOTP
+		if resp.MfaRequired {
+			state.token = resp.MfaToken
+			if resp.TotpEnrollmentRequired {
+				state.currentPage = "totp_enrollment"
+			} else {
+				state.currentPage = "totp_login"
+			}
+			state.window.SetContent(createContent(state))
+			return
 		}
-		state.window.SetContent(createContent(state))
+
+		finishLogin(state, resp)
 	})
 	enterButton.Importance = widget.HighImportance
 
@@ -150,6 +156,214 @@ func CreateAuthorizationPage(state *AppState) fyne.CanvasObject {
 	return container.New(layout.NewGridLayout(2), leftContent, rightContainer)
 }
 
+// finishLogin запоминает выданные токены и открывает стартовую страницу роли
+func finishLogin(state *AppState, resp *userpb.LoginResponse) {
+	state.userID = resp.UserId
+	state.role = resp.Role
+	state.token = resp.Token
+	state.refreshToken = resp.RefreshToken
+	state.expiresAt = resp.ExpiresAt
+	state.currentPage = homePage(state.role)
+	state.window.SetContent(createContent(state))
+}
+
+// homePage — стартовая страница для роли
+func homePage(role string) string {
+	switch role {
+	case "lecturer":
+		return "lector_works"
+	case "superaccount":
+		return "superacc-groups"
+	case "assistant":
+		return "assistant_works"
+	case "student":
+		return "student_grades"
+	case "seminarist":
+		return "seminarist_works"
+	default:
+		return "greeting"
+	}
+}
+
+// CreateTotpLoginPage — второй шаг входа: код из приложения-аутентификатора или резервный код
+func CreateTotpLoginPage(state *AppState) fyne.CanvasObject {
+	logo := canvas.NewImageFromResource(resourceHselogoSvg)
+	logo.FillMode = canvas.ImageFillOriginal
+	logo.SetMinSize(fyne.NewSize(100, 100))
+
+	codeEntry := widget.NewEntry()
+	codeEntry.SetPlaceHolder("Код из приложения или резервный код")
+
+	confirmButton := widget.NewButton("Войти", func() {
+		if codeEntry.Text == "" {
+			dialog.ShowInformation("Ошибка", "Введите код", state.window)
+			return
+		}
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+		if err != nil {
+			log.Printf("Failed to connect to userservice: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		client := userpb.NewUserServiceClient(conn)
+		resp, err := client.VerifyTotp(context.Background(), &userpb.VerifyTotpRequest{
+			Code: strings.TrimSpace(codeEntry.Text),
+		})
+		if err != nil {
+			log.Printf("TOTP verification failed: %v", err)
+			dialog.ShowError(err, state.window)
+			return
+		}
+		if resp.Error != "" {
+			dialog.ShowInformation("Ошибка", resp.Error, state.window)
+			return
+		}
+		finishLogin(state, resp)
+	})
+	confirmButton.Importance = widget.HighImportance
+
+	backButton := widget.NewButton("← Назад", func() {
+		state.token = ""
+		state.currentPage = "authorization"
+		state.window.SetContent(createContent(state))
+	})
+	backFull := container.NewHBox(backButton)
+
+	form := container.NewVBox(
+		logo,
+		layout.NewSpacer(),
+		widget.NewLabel("Введите код двухфакторной аутентификации"),
+		codeEntry,
+		confirmButton,
+		layout.NewSpacer(),
+	)
+
+	leftContent := container.NewBorder(
+		nil, backFull, nil, nil,
+		container.NewCenter(form),
+	)
+
+	rightBackground := canvas.NewRectangle(color.RGBA{23, 44, 101, 255})
+	rightText := canvas.NewText("Подтвердите вход", color.White)
+	rightText.TextSize = 32
+	rightText.TextStyle = fyne.TextStyle{Bold: true}
+	rightContent := container.NewCenter(rightText)
+	rightContainer := container.NewStack(rightBackground, rightContent)
+
+	return container.New(layout.NewGridLayout(2), leftContent, rightContainer)
+}
+
+// CreateTotpEnrollmentPage подключает TOTP, когда он обязателен для роли, и завершает вход
+func CreateTotpEnrollmentPage(state *AppState) fyne.CanvasObject {
+	logo := canvas.NewImageFromResource(resourceHselogoSvg)
+	logo.FillMode = canvas.ImageFillOriginal
+	logo.SetMinSize(fyne.NewSize(100, 100))
+
+	// Секрет и ссылку можно скопировать в приложение-аутентификатор
+	secretEntry := widget.NewEntry()
+	uriEntry := widget.NewEntry()
+
+	conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		log.Printf("Failed to connect to userservice: %v", err)
+	} else {
+		defer conn.Close()
+		client := userpb.NewUserServiceClient(conn)
+		resp, err := client.BeginTotpEnrollment(context.Background(), &userpb.BeginTotpEnrollmentRequest{})
+		if err != nil {
+			log.Printf("Failed to begin TOTP enrollment: %v", err)
+		} else if resp.Error != "" {
+			log.Printf("TOTP enrollment error: %s", resp.Error)
+		} else {
+			secretEntry.SetText(resp.Secret)
+			uriEntry.SetText(resp.ProvisioningUri)
+		}
+	}
+
+	codeEntry := widget.NewEntry()
+	codeEntry.SetPlaceHolder("Код из приложения")
+
+	confirmButton := widget.NewButton("Подключить и войти", func() {
+		if codeEntry.Text == "" {
+			dialog.ShowInformation("Ошибка", "Введите код", state.window)
+			return
+		}
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+		if err != nil {
+			log.Printf("Failed to connect to userservice: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		client := userpb.NewUserServiceClient(conn)
+		resp, err := client.ConfirmTotpEnrollment(context.Background(), &userpb.ConfirmTotpEnrollmentRequest{
+			Code: strings.TrimSpace(codeEntry.Text),
+		})
+		if err != nil {
+			log.Printf("TOTP enrollment failed: %v", err)
+			dialog.ShowError(err, state.window)
+			return
+		}
+		if resp.Error != "" {
+			dialog.ShowInformation("Ошибка", resp.Error, state.window)
+			return
+		}
+
+		codesEntry := widget.NewMultiLineEntry()
+		codesEntry.SetText(strings.Join(resp.RecoveryCodes, "\n"))
+		codesEntry.SetMinRowsVisible(len(resp.RecoveryCodes))
+		codesDialog := dialog.NewCustom("Резервные коды", "Я сохранил коды",
+			container.NewVBox(
+				widget.NewLabel("Сохраните резервные коды: каждый из них можно использовать вместо кода из приложения один раз. Больше они показаны не будут."),
+				codesEntry,
+			), state.window)
+		codesDialog.SetOnClosed(func() {
+			if resp.Login == nil || resp.Login.Error != "" {
+				state.token = ""
+				state.currentPage = "authorization"
+				state.window.SetContent(createContent(state))
+				return
+			}
+			finishLogin(state, resp.Login)
+		})
+		codesDialog.Show()
+	})
+	confirmButton.Importance = widget.HighImportance
+
+	backButton := widget.NewButton("← Назад", func() {
+		state.token = ""
+		state.currentPage = "authorization"
+		state.window.SetContent(createContent(state))
+	})
+	backFull := container.NewHBox(backButton)
+
+	form := container.NewVBox(
+		logo,
+		widget.NewLabel("Для вашей роли обязательна двухфакторная аутентификация.\nДобавьте ключ в приложение-аутентификатор и введите код из него."),
+		widget.NewLabel("Ключ:"),
+		secretEntry,
+		widget.NewLabel("Ссылка otpauth:"),
+		uriEntry,
+		codeEntry,
+		confirmButton,
+	)
+
+	leftContent := container.NewBorder(
+		nil, backFull, nil, nil,
+		container.NewCenter(form),
+	)
+
+	rightBackground := canvas.NewRectangle(color.RGBA{23, 44, 101, 255})
+	rightText := canvas.NewText("Двухфакторная аутентификация", color.White)
+	rightText.TextSize = 32
+	rightText.TextStyle = fyne.TextStyle{Bold: true}
+	rightContent := container.NewCenter(rightText)
+	rightContainer := container.NewStack(rightBackground, rightContent)
+
+	return container.New(layout.NewGridLayout(2), leftContent, rightContainer)
+}
+
 func CreateRegistrationPage(state *AppState) fyne.CanvasObject {
 	logo := canvas.NewImageFromResource(resourceHselogoSvg)
 	logo.FillMode = canvas.ImageFillOriginal
@@ -171,7 +385,7 @@ func CreateRegistrationPage(state *AppState) fyne.CanvasObject {
 	passwordEntry.SetPlaceHolder("Введите пароль")
 
 	enterButton := widget.NewButton("Зарегистрироваться", func() {
-		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure())
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Failed to connect to userservice: %v", err)
 			return
@@ -195,41 +409,199 @@ func CreateRegistrationPage(state *AppState) fyne.CanvasObject {
 			return
 		}
 
-		connNotificate, err := grpc.Dial("89.169.39.161:50056", grpc.WithInsecure())
+		log.Printf("Registration successful, UserID: %s", resp.UserId)
+		state.pendingEmail = emailEntry.Text
+		state.currentPage = "email_verification"
+		state.window.SetContent(createContent(state))
+	})
+	enterButton.Importance = widget.HighImportance
+
+	backButton := widget.NewButton("← Назад", func() {
+		state.currentPage = "greeting"
+		state.window.SetContent(createContent(state))
+	})
+	backFull := container.NewHBox(backButton)
+
+	form := container.NewVBox(
+		logo,
+		layout.NewSpacer(),
+		nameEntry,
+		surnameEntry,
+		patronymicEntry,
+		emailEntry,
+		passwordEntry,
+		enterButton,
+		layout.NewSpacer(),
+	)
+
+	leftContent := container.NewBorder(
+		nil, backFull, nil, nil,
+		container.NewCenter(form),
+	)
+
+	rightBackground := canvas.NewRectangle(color.RGBA{23, 44, 101, 255})
+
+	rightText := canvas.NewText("Зарегистрируйтесь", color.White)
+	rightText.TextSize = 32
+	rightText.TextStyle = fyne.TextStyle{Bold: true}
+
+	rightContent := container.NewCenter(rightText)
+	rightContainer := container.NewStack(rightBackground, rightContent)
+
+	return container.New(layout.NewGridLayout(2), leftContent, rightContainer)
+}
+
+// CreateEmailVerificationPage принимает код из письма, отправленного при регистрации
+func CreateEmailVerificationPage(state *AppState) fyne.CanvasObject {
+	logo := canvas.NewImageFromResource(resourceHselogoSvg)
+	logo.FillMode = canvas.ImageFillOriginal
+	logo.SetMinSize(fyne.NewSize(100, 100))
+
+	infoLabel := widget.NewLabel(fmt.Sprintf("Код подтверждения отправлен на %s", state.pendingEmail))
+
+	codeEntry := widget.NewEntry()
+	codeEntry.SetPlaceHolder("Введите код из письма")
+
+	confirmButton := widget.NewButton("Подтвердить", func() {
+		if codeEntry.Text == "" {
+			dialog.ShowInformation("Ошибка", "Введите код", state.window)
+			return
+		}
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure())
 		if err != nil {
 			log.Printf("Failed to connect to userservice: %v", err)
 			return
 		}
 		defer conn.Close()
 
-		userIDint64, err := strconv.ParseInt(resp.UserId, 10, 32)
+		client := userpb.NewUserServiceClient(conn)
+		resp, err := client.VerifyEmail(context.Background(), &userpb.VerifyEmailRequest{
+			Token: strings.TrimSpace(codeEntry.Text),
+		})
 		if err != nil {
-			log.Printf("Некорректный ID пользователя: %v", err)
+			log.Printf("Email verification failed: %v", err)
+			dialog.ShowError(err, state.window)
 			return
 		}
-		userID := int32(userIDint64)
-
-		clientNotificate := notifypb.NewNotificationServiceClient(connNotificate)
-		respNotificate, err := clientNotificate.SendRegistrationNotification(context.Background(), &notifypb.NotificationRequest{
-			UserId:    userID,
-			Email:     emailEntry.Text,
-			Message:   "Created new account",
-			CreatedAt: time.Now().GoString(),
+		if resp.Error != "" {
+			dialog.ShowInformation("Ошибка", resp.Error, state.window)
+			return
+		}
+		dialog.ShowInformation("Успех", "Почта подтверждена, теперь можно войти", state.window)
+		state.pendingEmail = ""
+		state.currentPage = "authorization"
+		state.window.SetContent(createContent(state))
+	})
+	confirmButton.Importance = widget.HighImportance
+
+	resendButton := widget.NewButton("Отправить код повторно", func() {
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure())
+		if err != nil {
+			log.Printf("Failed to connect to userservice: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		client := userpb.NewUserServiceClient(conn)
+		resp, err := client.ResendVerification(context.Background(), &userpb.ResendVerificationRequest{
+			Email: state.pendingEmail,
 		})
 		if err != nil {
-			log.Printf("Notification failed: %v", err)
+			log.Printf("Failed to resend verification: %v", err)
+			dialog.ShowError(err, state.window)
 			return
 		}
-		if respNotificate.Error != "" {
-			log.Println("Notification error:", respNotificate.Error)
+		if resp.Error != "" {
+			dialog.ShowInformation("Ошибка", resp.Error, state.window)
 			return
 		}
+		dialog.ShowInformation("Готово", "Новый код отправлен на почту", state.window)
+	})
+	resendButton.Importance = widget.MediumImportance
 
-		log.Printf("Registration successful, UserID: %s", resp.UserId)
+	backButton := widget.NewButton("← Назад", func() {
+		state.pendingEmail = ""
 		state.currentPage = "greeting"
 		state.window.SetContent(createContent(state))
 	})
-	enterButton.Importance = widget.HighImportance
+	backFull := container.NewHBox(backButton)
+
+	form := container.NewVBox(
+		logo,
+		layout.NewSpacer(),
+		infoLabel,
+		codeEntry,
+		confirmButton,
+		resendButton,
+		layout.NewSpacer(),
+	)
+
+	leftContent := container.NewBorder(
+		nil, backFull, nil, nil,
+		container.NewCenter(form),
+	)
+
+	rightBackground := canvas.NewRectangle(color.RGBA{23, 44, 101, 255})
+	rightText := canvas.NewText("Подтвердите почту", color.White)
+	rightText.TextSize = 32
+	rightText.TextStyle = fyne.TextStyle{Bold: true}
+	rightContent := container.NewCenter(rightText)
+	rightContainer := container.NewStack(rightBackground, rightContent)
+
+	return container.New(layout.NewGridLayout(2), leftContent, rightContainer)
+}
+
+// CreateAcceptInvitationPage — первый вход пользователя, созданного суперакком:
+// код из письма-приглашения и свой пароль
+func CreateAcceptInvitationPage(state *AppState) fyne.CanvasObject {
+	logo := canvas.NewImageFromResource(resourceHselogoSvg)
+	logo.FillMode = canvas.ImageFillOriginal
+	logo.SetMinSize(fyne.NewSize(100, 100))
+
+	codeEntry := widget.NewEntry()
+	codeEntry.SetPlaceHolder("Код из письма-приглашения")
+
+	passwordEntry := widget.NewPasswordEntry()
+	passwordEntry.SetPlaceHolder("Придумайте пароль")
+
+	repeatEntry := widget.NewPasswordEntry()
+	repeatEntry.SetPlaceHolder("Повторите пароль")
+
+	acceptButton := widget.NewButton("Принять приглашение", func() {
+		if codeEntry.Text == "" || passwordEntry.Text == "" {
+			dialog.ShowInformation("Ошибка", "Заполните все поля", state.window)
+			return
+		}
+		if passwordEntry.Text != repeatEntry.Text {
+			dialog.ShowInformation("Ошибка", "Пароли не совпадают", state.window)
+			return
+		}
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure())
+		if err != nil {
+			log.Printf("Failed to connect to userservice: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		client := userpb.NewUserServiceClient(conn)
+		resp, err := client.AcceptInvitation(context.Background(), &userpb.AcceptInvitationRequest{
+			Token:    strings.TrimSpace(codeEntry.Text),
+			Password: passwordEntry.Text,
+		})
+		if err != nil {
+			log.Printf("Failed to accept invitation: %v", err)
+			dialog.ShowError(err, state.window)
+			return
+		}
+		if resp.Error != "" {
+			dialog.ShowInformation("Ошибка", resp.Error, state.window)
+			return
+		}
+		dialog.ShowInformation("Успех", "Пароль задан, теперь можно войти", state.window)
+		state.currentPage = "authorization"
+		state.window.SetContent(createContent(state))
+	})
+	acceptButton.Importance = widget.HighImportance
 
 	backButton := widget.NewButton("← Назад", func() {
 		state.currentPage = "greeting"
@@ -240,12 +612,10 @@ func CreateRegistrationPage(state *AppState) fyne.CanvasObject {
 	form := container.NewVBox(
 		logo,
 		layout.NewSpacer(),
-		nameEntry,
-		surnameEntry,
-		patronymicEntry,
-		emailEntry,
+		codeEntry,
 		passwordEntry,
-		enterButton,
+		repeatEntry,
+		acceptButton,
 		layout.NewSpacer(),
 	)
 
@@ -255,11 +625,9 @@ func CreateRegistrationPage(state *AppState) fyne.CanvasObject {
 	)
 
 	rightBackground := canvas.NewRectangle(color.RGBA{23, 44, 101, 255})
-
-	rightText := canvas.NewText("Зарегистрируйтесь", color.White)
+	rightText := canvas.NewText("Добро пожаловать", color.White)
 	rightText.TextSize = 32
 	rightText.TextStyle = fyne.TextStyle{Bold: true}
-
 	rightContent := container.NewCenter(rightText)
 	rightContainer := container.NewStack(rightBackground, rightContent)
 
@@ -274,11 +642,6 @@ func CreatePasswordResetPage(state *AppState) fyne.CanvasObject {
 	emailEntry := widget.NewEntry()
 	emailEntry.SetPlaceHolder("Введите ваш email")
 
-	var tempPasswordEntry *widget.Entry
-	var newPasswordEntry *widget.Entry
-	var confirmButton *widget.Button
-	var currentForm fyne.CanvasObject
-
 	// Правая часть окна (константная часть)
 	rightBackground := canvas.NewRectangle(color.RGBA{23, 44, 101, 255})
 	rightText := canvas.NewText("Сброс пароля", color.White)
@@ -287,59 +650,55 @@ func CreatePasswordResetPage(state *AppState) fyne.CanvasObject {
 	rightContent := container.NewCenter(rightText)
 	rightContainer := container.NewStack(rightBackground, rightContent)
 
-	// Изначальный контейнер для первого этапа (ввод email)
+	backButton := widget.NewButton("← Назад", func() {
+		state.currentPage = "authorization"
+		state.window.SetContent(createContent(state))
+	})
+	backFull := container.NewHBox(backButton)
+
+	// Первый этап: сервер высылает на почту одноразовый код
 	initialForm := container.NewVBox(
 		logo,
 		layout.NewSpacer(),
 		emailEntry,
 		widget.NewButton("Далее", func() {
-			conn, err := grpc.Dial("89.169.39.161:50056", grpc.WithInsecure())
-			if err != nil {
-				log.Printf("Failed to connect to notificationservice: %v", err)
+			if emailEntry.Text == "" {
+				dialog.ShowInformation("Ошибка", "Введите email", state.window)
 				return
 			}
-			defer conn.Close()
-
-			client := notifypb.NewNotificationServiceClient(conn)
-			// Генерируем случайный 4-значный пароль
-			b := make([]byte, 3)
-			_, err = rand.Read(b)
+			conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure())
 			if err != nil {
-				log.Printf("Failed to generate random password: %v", err)
+				log.Printf("Failed to connect to userservice: %v", err)
 				return
 			}
-			tempPassword := fmt.Sprintf("%04d", int(b[0])%10000)
-			createdAt := time.Now().Format(time.RFC3339)
+			defer conn.Close()
 
-			_, err = client.SendPasswordResetNotification(context.Background(), &notifypb.NotificationRequest{
-				Email:     emailEntry.Text,
-				Message:   fmt.Sprintf("Ваш временный пароль: %s\nВведите его в приложении для создания нового пароля.", tempPassword),
-				CreatedAt: createdAt,
+			client := userpb.NewUserServiceClient(conn)
+			resp, err := client.RequestPasswordReset(context.Background(), &userpb.RequestPasswordResetRequest{
+				Email: emailEntry.Text,
 			})
 			if err != nil {
-				log.Printf("Password reset notification failed: %v", err)
+				log.Printf("Password reset request failed: %v", err)
+				dialog.ShowError(err, state.window)
+				return
+			}
+			if resp.Error != "" {
+				dialog.ShowInformation("Ошибка", resp.Error, state.window)
 				return
 			}
 
-			// Инициализируем поля для второго этапа
-			tempPasswordEntry = widget.NewPasswordEntry()
-			tempPasswordEntry.SetPlaceHolder("Введите временный пароль")
-			newPasswordEntry = widget.NewPasswordEntry()
+			// Второй этап: код из письма и новый пароль
+			codeEntry := widget.NewEntry()
+			codeEntry.SetPlaceHolder("Введите код из письма")
+			newPasswordEntry := widget.NewPasswordEntry()
 			newPasswordEntry.SetPlaceHolder("Введите новый пароль")
 
-			confirmButton = widget.NewButton("Подтвердить", func() {
-				if tempPasswordEntry.Text == "" || newPasswordEntry.Text == "" {
+			confirmButton := widget.NewButton("Подтвердить", func() {
+				if codeEntry.Text == "" || newPasswordEntry.Text == "" {
 					dialog.ShowInformation("Ошибка", "Заполните все поля", state.window)
 					return
 				}
 
-				// Проверка временного пароля (эмуляция на клиенте)
-				if tempPasswordEntry.Text != tempPassword {
-					dialog.ShowInformation("Ошибка", "Неверный временный пароль", state.window)
-					return
-				}
-
-				// Обновляем пароль
 				userConn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure())
 				if err != nil {
 					log.Printf("Failed to connect to userservice: %v", err)
@@ -348,12 +707,17 @@ func CreatePasswordResetPage(state *AppState) fyne.CanvasObject {
 				defer userConn.Close()
 
 				userClient := userpb.NewUserServiceClient(userConn)
-				_, err = userClient.UpdatePassword(context.Background(), &userpb.UpdatePasswordRequest{
-					Email:    emailEntry.Text,
-					Password: newPasswordEntry.Text,
+				resp, err := userClient.ConfirmPasswordReset(context.Background(), &userpb.ConfirmPasswordResetRequest{
+					Token:       strings.TrimSpace(codeEntry.Text),
+					NewPassword: newPasswordEntry.Text,
 				})
 				if err != nil {
-					log.Printf("Failed to update password: %v", err)
+					log.Printf("Failed to reset password: %v", err)
+					dialog.ShowError(err, state.window)
+					return
+				}
+				if resp.Error != "" {
+					dialog.ShowInformation("Ошибка", resp.Error, state.window)
 					return
 				}
 				dialog.ShowInformation("Успех", "Пароль успешно обновлён", state.window)
@@ -362,38 +726,25 @@ func CreatePasswordResetPage(state *AppState) fyne.CanvasObject {
 			})
 			confirmButton.Importance = widget.HighImportance
 
-			// Перестраиваем форму для второго этапа
 			secondForm := container.NewVBox(
 				logo,
 				layout.NewSpacer(),
-				tempPasswordEntry,
+				widget.NewLabel("Если адрес зарегистрирован, на него отправлен код"),
+				codeEntry,
 				newPasswordEntry,
 				confirmButton,
 				layout.NewSpacer(),
 			)
-			currentForm = secondForm
-			leftContent := container.NewBorder(nil, nil, nil, nil, container.NewCenter(secondForm))
+			leftContent := container.NewBorder(nil, backFull, nil, nil, container.NewCenter(secondForm))
 			state.window.SetContent(container.New(layout.NewGridLayout(2), leftContent, rightContainer))
 		}),
 		layout.NewSpacer(),
 	)
 
-	backButton := widget.NewButton("← Назад", func() {
-		state.currentPage = "authorization"
-		state.window.SetContent(createContent(state))
-	})
-	backFull := container.NewHBox(backButton)
-
-	// Изначальный leftContent
 	leftContent := container.NewBorder(
 		nil, backFull, nil, nil,
 		container.NewCenter(initialForm),
 	)
-	currentForm = initialForm
-
-	if currentForm == initialForm {
-
-	}
 
 	return container.New(layout.NewGridLayout(2), leftContent, rightContainer)
 }
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"log"
+	"strconv"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// impersonation хранит данные суперпользователя на время работы от чужого имени
+type impersonation struct {
+	id        int32
+	fio       string
+	expiresAt int64
+
+	userID       string
+	role         string
+	token        string
+	refreshToken string
+	tokenExpires int64
+}
+
+// impersonationWindow показывает баннер имперсонации над любым содержимым окна,
+// поэтому страницам, которые сами вызывают SetContent, ничего делать не нужно
+type impersonationWindow struct {
+	fyne.Window
+	state *AppState
+}
+
+func (w *impersonationWindow) SetContent(content fyne.CanvasObject) {
+	w.Window.SetContent(withImpersonationBanner(w.state, content))
+}
+
+func withImpersonationBanner(state *AppState, content fyne.CanvasObject) fyne.CanvasObject {
+	imp := state.impersonation
+	if imp == nil {
+		return content
+	}
+	text := canvas.NewText(fmt.Sprintf("Вы работаете от имени: %s (%s) до %s",
+		imp.fio, state.role, time.Unix(imp.expiresAt, 0).Format("15:04")), color.Black)
+	text.TextStyle.Bold = true
+	endButton := widget.NewButton("Завершить имперсонацию", func() {
+		endImpersonation(state)
+	})
+	endButton.Importance = widget.DangerImportance
+	banner := container.NewStack(
+		canvas.NewRectangle(color.NRGBA{R: 255, G: 193, B: 7, A: 255}),
+		container.NewPadded(container.NewHBox(text, layout.NewSpacer(), endButton)),
+	)
+	return container.NewBorder(banner, nil, nil, nil, content)
+}
+
+// startImpersonation получает токен от имени пользователя и открывает его стартовую страницу
+func startImpersonation(state *AppState, userID int32, reason string) {
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		log.Printf("Failed to connect to superaccservice: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := superaccpb.NewSuperAccServiceClient(conn).Impersonate(ctx, &superaccpb.ImpersonateRequest{
+		UserId: userID,
+		Reason: reason,
+	})
+	if err != nil {
+		log.Printf("Failed to impersonate user %d: %v", userID, err)
+		dialog.ShowError(err, state.window)
+		return
+	}
+	if !resp.Success {
+		dialog.ShowInformation("Ошибка", resp.Message, state.window)
+		return
+	}
+
+	refreshMu.Lock()
+	state.impersonation = &impersonation{
+		id:           resp.ImpersonationId,
+		fio:          resp.Fio,
+		expiresAt:    resp.ExpiresAt,
+		userID:       state.userID,
+		role:         state.role,
+		token:        state.token,
+		refreshToken: state.refreshToken,
+		tokenExpires: state.expiresAt,
+	}
+	state.userID = strconv.Itoa(int(resp.UserId))
+	state.role = resp.Role
+	state.token = resp.Token
+	state.refreshToken = "" // токен имперсонации не обновляется
+	state.expiresAt = resp.ExpiresAt
+	refreshMu.Unlock()
+
+	state.currentPage = homePage(state.role)
+	state.window.SetContent(createContent(state))
+}
+
+// endImpersonation возвращает токены суперпользователя и завершает имперсонацию на сервере
+func endImpersonation(state *AppState) {
+	imp := state.impersonation
+	if imp == nil {
+		return
+	}
+	refreshMu.Lock()
+	state.impersonation = nil
+	state.userID = imp.userID
+	state.role = imp.role
+	state.token = imp.token
+	state.refreshToken = imp.refreshToken
+	state.expiresAt = imp.tokenExpires
+	refreshMu.Unlock()
+
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		log.Printf("Failed to connect to superaccservice: %v", err)
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		resp, err := superaccpb.NewSuperAccServiceClient(conn).EndImpersonation(ctx, &superaccpb.EndImpersonationRequest{
+			ImpersonationId: imp.id,
+		})
+		if err != nil {
+			log.Printf("Failed to end impersonation %d: %v", imp.id, err)
+		} else if !resp.Success {
+			log.Printf("End impersonation failed: %s", resp.Message)
+		}
+		cancel()
+		conn.Close()
+	}
+
+	state.currentPage = "superacc-all-users"
+	state.window.SetContent(createContent(state))
+}
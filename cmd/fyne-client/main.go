@@ -1,18 +1,110 @@
 package main
 
 import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	userpb "rubr/proto/user"
 )
 
 type AppState struct {
-	currentPage string
-	userID      string
-	role        string
-	window      fyne.Window
+	currentPage  string
+	userID       string
+	role         string
+	token        string
+	refreshToken string
+	expiresAt    int64
+	pendingEmail string // почта, ожидающая подтверждения
+	window       fyne.Window
+	// impersonation заполнено, пока суперпользователь работает от имени другого пользователя
+	impersonation *impersonation
+}
+
+// tokenAuth прикрепляет JWT текущего пользователя к каждому gRPC-вызову
+type tokenAuth struct {
+	state *AppState
+}
+
+// refreshMu не даёт нескольким вызовам одновременно обновлять токен
+var refreshMu sync.Mutex
+
+func (t tokenAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+	if t.state.token == "" {
+		return nil, nil
+	}
+	if t.state.refreshToken != "" && time.Until(time.Unix(t.state.expiresAt, 0)) < time.Minute {
+		refreshSession(ctx, t.state)
+	}
+	return map[string]string{"authorization": "Bearer " + t.state.token}, nil
+}
+
+func (t tokenAuth) RequireTransportSecurity() bool {
+	return false
+}
+
+func withAuth(state *AppState) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(tokenAuth{state: state})
+}
+
+// refreshSession обменивает refresh-токен на новую пару токенов
+func refreshSession(ctx context.Context, state *AppState) {
+	conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure())
+	if err != nil {
+		log.Printf("Failed to connect for token refresh: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	resp, err := userpb.NewUserServiceClient(conn).RefreshToken(ctx, &userpb.RefreshTokenRequest{RefreshToken: state.refreshToken})
+	if err != nil {
+		log.Printf("Failed to refresh token: %v", err)
+		return
+	}
+	if resp.Error != "" {
+		log.Printf("Failed to refresh token: %s", resp.Error)
+		state.refreshToken = ""
+		return
+	}
+	state.token = resp.Token
+	state.refreshToken = resp.RefreshToken
+	state.expiresAt = resp.ExpiresAt
+}
+
+// logout завершает сессию на сервере, забывает токены и возвращает на приветственную страницу
+func logout(state *AppState) {
+	// Под имперсонацией «выход» возвращает к собственному аккаунту суперпользователя
+	if state.impersonation != nil {
+		endImpersonation(state)
+		return
+	}
+	if state.token != "" {
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+		if err == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			if _, err := userpb.NewUserServiceClient(conn).Logout(ctx, &userpb.LogoutRequest{}); err != nil {
+				log.Printf("Failed to log out: %v", err)
+			}
+			cancel()
+			conn.Close()
+		}
+	}
+	state.userID = ""
+	state.role = ""
+	state.token = ""
+	state.refreshToken = ""
+	state.expiresAt = 0
+	state.currentPage = "greeting"
+	state.window.SetContent(createContent(state))
 }
 
 func main() {
@@ -24,10 +116,10 @@ func main() {
 		currentPage: "greeting",
 		userID:      "",
 		role:        "",
-		window:      w,
 	}
+	state.window = &impersonationWindow{Window: w, state: state}
 
-	w.SetContent(createContent(state))
+	state.window.SetContent(createContent(state))
 	w.Resize(fyne.NewSize(1280, 720))
 	w.ShowAndRun()
 }
@@ -43,6 +135,18 @@ func createContent(state *AppState) fyne.CanvasObject {
 		return CreateRegistrationPage(state)
 	case "password_reset":
 		return CreatePasswordResetPage(state)
+	case "email_verification":
+		return CreateEmailVerificationPage(state)
+	case "totp_login":
+		return CreateTotpLoginPage(state)
+	case "totp_enrollment":
+		return CreateTotpEnrollmentPage(state)
+	case "accept_invitation":
+		return CreateAcceptInvitationPage(state)
+	case "profile":
+		return CreateProfilePage(state)
+	case "personal_tokens":
+		return CreatePersonalTokensPage(state)
 	//superacc
 	case "superacc-groups":
 		return СreateGroupListPage(state)
@@ -50,6 +154,10 @@ func createContent(state *AppState) fyne.CanvasObject {
 		return СreateGroupUsersPage(state, GroupName)
 	case "superacc-all-users":
 		return СreateUsersListPage(state)
+	case "superacc-invitations":
+		return CreateInvitationsPage(state)
+	case "superacc-audit":
+		return CreateAuditLogPage(state)
 	// lector
 	case "lector_works":
 		return CreateLectorWorksPage(state)
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+
+	userpb "rubr/proto/user"
+)
+
+// formatTokenTime показывает время из RFC3339 в привычном виде
+func formatTokenTime(value string) string {
+	if value == "" {
+		return "—"
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	return t.Local().Format("02.01.2006 15:04")
+}
+
+// CreatePersonalTokensPage — токены для скриптов: выпуск, список и отзыв
+func CreatePersonalTokensPage(state *AppState) fyne.CanvasObject {
+	w := state.window
+
+	headerTextColor := color.White
+	logoText := canvas.NewText("ВШЭ", headerTextColor)
+	logoText.TextStyle.Bold = true
+	logoText.TextSize = 24
+	logoText.Alignment = fyne.TextAlignCenter
+	leftHeaderObject := container.NewMax(logoText)
+
+	headerTitle := canvas.NewText("Токены API", headerTextColor)
+	headerTitle.TextStyle.Bold = true
+	headerTitle.TextSize = 20
+	headerTitle.Alignment = fyne.TextAlignCenter
+
+	headerContent := container.New(layout.NewBorderLayout(nil, nil, leftHeaderObject, nil),
+		leftHeaderObject,
+		container.NewCenter(headerTitle),
+	)
+	headerWithBackground := container.NewMax(canvas.NewRectangle(color.NRGBA{R: 23, G: 44, B: 101, A: 255}), headerContent)
+
+	backButton := widget.NewButton("Назад", func() {
+		state.currentPage = "profile"
+		w.SetContent(createContent(state))
+	})
+	backButtonContainer := container.NewHBox(layout.NewSpacer(), backButton)
+
+	reload := func() {
+		state.currentPage = "personal_tokens"
+		w.SetContent(createContent(state))
+	}
+
+	conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		log.Printf("Failed to connect to userservice: %v", err)
+		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису пользователей"), backButtonContainer)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := userpb.NewUserServiceClient(conn).ListPersonalTokens(ctx, &userpb.ListPersonalTokensRequest{})
+	if err != nil {
+		log.Printf("Failed to list personal tokens: %v", err)
+		return container.NewVBox(widget.NewLabel("Ошибка загрузки токенов"), backButtonContainer)
+	}
+	if resp.Error != "" {
+		return container.NewVBox(widget.NewLabel(resp.Error), backButtonContainer)
+	}
+
+	tokensList := container.NewVBox()
+	if len(resp.Tokens) == 0 {
+		tokensList.Add(widget.NewLabel("Токенов пока нет"))
+	}
+	for _, token := range resp.Tokens {
+		token := token
+		info := widget.NewLabel(fmt.Sprintf("%s\nОбласти: %s\nСоздан: %s, действует до: %s, использован: %s",
+			token.Name, strings.Join(token.Scopes, ", "),
+			formatTokenTime(token.CreatedAt), formatTokenTime(token.ExpiresAt), formatTokenTime(token.LastUsedAt)))
+		info.Wrapping = fyne.TextWrapWord
+
+		revokeButton := widget.NewButton("Отозвать", func() {
+			dialog.ShowConfirm("Отзыв токена", fmt.Sprintf("Отозвать токен «%s»? Скрипты с ним перестанут работать.", token.Name),
+				func(ok bool) {
+					if !ok {
+						return
+					}
+					conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+					if err != nil {
+						log.Printf("Failed to connect to userservice: %v", err)
+						return
+					}
+					defer conn.Close()
+					resp, err := userpb.NewUserServiceClient(conn).RevokePersonalToken(context.Background(), &userpb.RevokePersonalTokenRequest{TokenId: token.Id})
+					if err != nil {
+						log.Printf("Failed to revoke personal token: %v", err)
+						dialog.ShowError(err, w)
+						return
+					}
+					if resp.Error != "" {
+						dialog.ShowInformation("Ошибка", resp.Error, w)
+						return
+					}
+					reload()
+				}, w)
+		})
+		if token.Revoked {
+			revokeButton.SetText("Отозван")
+			revokeButton.Disable()
+		}
+		tokensList.Add(container.NewBorder(nil, nil, nil, revokeButton, info))
+		tokensList.Add(widget.NewSeparator())
+	}
+
+	// Выпуск нового токена
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Например, выгрузка оценок")
+	daysEntry := widget.NewEntry()
+	daysEntry.SetText("90")
+	scopesCheck := widget.NewCheckGroup(resp.AvailableScopes, nil)
+
+	createButton := widget.NewButton("Выпустить токен", func() {
+		days, err := strconv.Atoi(strings.TrimSpace(daysEntry.Text))
+		if err != nil {
+			dialog.ShowInformation("Ошибка", "Срок действия должен быть числом дней", w)
+			return
+		}
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+		if err != nil {
+			log.Printf("Failed to connect to userservice: %v", err)
+			return
+		}
+		defer conn.Close()
+		resp, err := userpb.NewUserServiceClient(conn).CreatePersonalToken(context.Background(), &userpb.CreatePersonalTokenRequest{
+			Name:          nameEntry.Text,
+			Scopes:        scopesCheck.Selected,
+			ExpiresInDays: int32(days),
+		})
+		if err != nil {
+			log.Printf("Failed to create personal token: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		if resp.Error != "" {
+			dialog.ShowInformation("Ошибка", resp.Error, w)
+			return
+		}
+
+		tokenEntry := widget.NewEntry()
+		tokenEntry.SetText(resp.Token)
+		tokenDialog := dialog.NewCustom("Новый токен", "Я сохранил токен",
+			container.NewVBox(
+				widget.NewLabel("Скопируйте токен: больше он показан не будет.\nПередавайте его в заголовке authorization: Bearer <токен>."),
+				tokenEntry,
+				widget.NewButton("Скопировать", func() {
+					w.Clipboard().SetContent(resp.Token)
+				}),
+			), w)
+		tokenDialog.SetOnClosed(reload)
+		tokenDialog.Show()
+	})
+	createButton.Importance = widget.HighImportance
+
+	createForm := widget.NewForm(
+		widget.NewFormItem("Название", nameEntry),
+		widget.NewFormItem("Срок, дней", daysEntry),
+		widget.NewFormItem("Области", scopesCheck),
+	)
+
+	content := container.NewVBox(
+		backButtonContainer,
+		widget.NewLabelWithStyle("Мои токены", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		tokensList,
+		widget.NewLabelWithStyle("Новый токен", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabel("Права токена — только выбранные области и только в пределах вашей роли."),
+		createForm,
+		container.NewHBox(layout.NewSpacer(), createButton),
+	)
+
+	return container.NewStack(
+		canvas.NewRectangle(color.White),
+		container.NewBorder(
+			headerWithBackground,
+			nil,
+			nil,
+			nil,
+			container.NewVScroll(container.NewPadded(content)),
+		),
+	)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"image/color"
+	"log"
+	"time"
+
+	userpb "rubr/proto/user"
+)
+
+// roleTitles — подписи ролей для страницы профиля
+var roleTitles = map[string]string{
+	"student":      "Студент",
+	"assistant":    "Ассистент",
+	"seminarist":   "Семинарист",
+	"lecturer":     "Лектор",
+	"superaccount": "Администратор",
+}
+
+// profileButton открывает страницу профиля; добавляется на стартовую страницу каждой роли
+func profileButton(state *AppState) *widget.Button {
+	return widget.NewButton("Профиль", func() {
+		state.currentPage = "profile"
+		state.window.SetContent(createContent(state))
+	})
+}
+
+// CreateProfilePage — профиль текущего пользователя: ФИО и смена пароля
+func CreateProfilePage(state *AppState) fyne.CanvasObject {
+	w := state.window
+
+	headerTextColor := color.White
+	logoText := canvas.NewText("ВШЭ", headerTextColor)
+	logoText.TextStyle.Bold = true
+	logoText.TextSize = 24
+	logoText.Alignment = fyne.TextAlignCenter
+	leftHeaderObject := container.NewMax(logoText)
+
+	headerTitle := canvas.NewText("Профиль", headerTextColor)
+	headerTitle.TextStyle.Bold = true
+	headerTitle.TextSize = 20
+	headerTitle.Alignment = fyne.TextAlignCenter
+
+	headerContent := container.New(layout.NewBorderLayout(nil, nil, leftHeaderObject, nil),
+		leftHeaderObject,
+		container.NewCenter(headerTitle),
+	)
+	headerWithBackground := container.NewMax(canvas.NewRectangle(color.NRGBA{R: 23, G: 44, B: 101, A: 255}), headerContent)
+
+	backButton := widget.NewButton("Назад", func() {
+		state.currentPage = homePage(state.role)
+		w.SetContent(createContent(state))
+	})
+	backButtonContainer := container.NewHBox(layout.NewSpacer(), backButton)
+
+	tokensButton := widget.NewButton("Токены API", func() {
+		state.currentPage = "personal_tokens"
+		w.SetContent(createContent(state))
+	})
+
+	conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		log.Printf("Failed to connect to userservice: %v", err)
+		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису пользователей"), backButtonContainer)
+	}
+	defer conn.Close()
+	client := userpb.NewUserServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := client.GetMyProfile(ctx, &userpb.GetMyProfileRequest{})
+	if err != nil {
+		log.Printf("Failed to get profile: %v", err)
+		return container.NewVBox(widget.NewLabel("Ошибка загрузки профиля"), backButtonContainer)
+	}
+	if resp.Error != "" {
+		log.Printf("GetMyProfile error: %s", resp.Error)
+		return container.NewVBox(widget.NewLabel(resp.Error), backButtonContainer)
+	}
+
+	emailLabel := widget.NewLabel(resp.User.Email)
+	roleTitle, ok := roleTitles[resp.User.Role]
+	if !ok {
+		roleTitle = resp.User.Role
+	}
+	roleLabel := widget.NewLabel(roleTitle)
+	totpText := "не подключена"
+	if resp.TotpEnabled {
+		totpText = "подключена"
+	}
+	totpLabel := widget.NewLabel(totpText)
+
+	surnameEntry := widget.NewEntry()
+	surnameEntry.SetText(resp.User.Surname)
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(resp.User.Name)
+	patronymicEntry := widget.NewEntry()
+	patronymicEntry.SetText(resp.User.Patronymic)
+
+	saveButton := widget.NewButton("Сохранить", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+		if err != nil {
+			log.Printf("Failed to connect to userservice: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		defer conn.Close()
+
+		resp, err := userpb.NewUserServiceClient(conn).UpdateMyProfile(ctx, &userpb.UpdateMyProfileRequest{
+			Name:       nameEntry.Text,
+			Surname:    surnameEntry.Text,
+			Patronymic: patronymicEntry.Text,
+		})
+		if err != nil {
+			log.Printf("Failed to update profile: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		if resp.Error != "" {
+			dialog.ShowInformation("Ошибка", resp.Error, w)
+			return
+		}
+		surnameEntry.SetText(resp.User.Surname)
+		nameEntry.SetText(resp.User.Name)
+		patronymicEntry.SetText(resp.User.Patronymic)
+		dialog.ShowInformation("Готово", "Профиль сохранён", w)
+	})
+	saveButton.Importance = widget.HighImportance
+
+	profileForm := widget.NewForm(
+		widget.NewFormItem("Почта", emailLabel),
+		widget.NewFormItem("Роль", roleLabel),
+		widget.NewFormItem("Двухфакторная аутентификация", totpLabel),
+		widget.NewFormItem("Фамилия", surnameEntry),
+		widget.NewFormItem("Имя", nameEntry),
+		widget.NewFormItem("Отчество", patronymicEntry),
+	)
+
+	currentPasswordEntry := widget.NewPasswordEntry()
+	newPasswordEntry := widget.NewPasswordEntry()
+	repeatPasswordEntry := widget.NewPasswordEntry()
+
+	changePasswordButton := widget.NewButton("Сменить пароль", func() {
+		if currentPasswordEntry.Text == "" || newPasswordEntry.Text == "" {
+			dialog.ShowInformation("Ошибка", "Введите текущий и новый пароль", w)
+			return
+		}
+		if newPasswordEntry.Text != repeatPasswordEntry.Text {
+			dialog.ShowInformation("Ошибка", "Пароли не совпадают", w)
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		conn, err := grpc.Dial("89.169.39.161:50051", grpc.WithInsecure(), withAuth(state))
+		if err != nil {
+			log.Printf("Failed to connect to userservice: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		defer conn.Close()
+
+		resp, err := userpb.NewUserServiceClient(conn).ChangePassword(ctx, &userpb.ChangePasswordRequest{
+			CurrentPassword: currentPasswordEntry.Text,
+			NewPassword:     newPasswordEntry.Text,
+		})
+		if err != nil {
+			log.Printf("Failed to change password: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		if resp.Error != "" {
+			dialog.ShowInformation("Ошибка", resp.Error, w)
+			return
+		}
+		currentPasswordEntry.SetText("")
+		newPasswordEntry.SetText("")
+		repeatPasswordEntry.SetText("")
+		dialog.ShowInformation("Готово", "Пароль изменён. Остальные сессии завершены.", w)
+	})
+
+	passwordForm := widget.NewForm(
+		widget.NewFormItem("Текущий пароль", currentPasswordEntry),
+		widget.NewFormItem("Новый пароль", newPasswordEntry),
+		widget.NewFormItem("Повторите пароль", repeatPasswordEntry),
+	)
+
+	content := container.NewVBox(
+		container.NewHBox(tokensButton, layout.NewSpacer(), backButton),
+		widget.NewLabelWithStyle("Личные данные", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		profileForm,
+		container.NewHBox(layout.NewSpacer(), saveButton),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("Смена пароля", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		passwordForm,
+		container.NewHBox(layout.NewSpacer(), changePasswordButton),
+	)
+
+	return container.NewStack(
+		canvas.NewRectangle(color.White),
+		container.NewBorder(
+			headerWithBackground,
+			nil,
+			nil,
+			nil,
+			container.NewVScroll(container.NewPadded(content)),
+		),
+	)
+}
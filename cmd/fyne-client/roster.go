@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// parseRoster разбирает CSV списка деканата: в каждой строке группа и почта студента,
+// первая строка может быть заголовком group,email. Группа без студентов задаётся строкой
+// с пустой почтой
+func parseRoster(data []byte) ([]*superaccpb.RosterGroup, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) > 0 && strings.EqualFold(records[0][0], "group") && strings.EqualFold(records[0][1], "email") {
+		records = records[1:]
+	}
+	var groups []*superaccpb.RosterGroup
+	byName := make(map[string]*superaccpb.RosterGroup)
+	for _, record := range records {
+		name := strings.TrimSpace(record[0])
+		g, ok := byName[name]
+		if !ok {
+			g = &superaccpb.RosterGroup{GroupName: name}
+			byName[name] = g
+			groups = append(groups, g)
+		}
+		if email := strings.TrimSpace(record[1]); email != "" {
+			g.Emails = append(g.Emails, email)
+		}
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("файл пуст")
+	}
+	return groups, nil
+}
+
+func reconcileRoster(state *AppState, groups []*superaccpb.RosterGroup, dryRun bool) (*superaccpb.ReconcileRosterResponse, error) {
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return superaccpb.NewSuperAccServiceClient(conn).ReconcileRoster(ctx, &superaccpb.ReconcileRosterRequest{Groups: groups, DryRun: dryRun})
+}
+
+// rosterReport показывает проблемы списка или изменения состава групп
+func rosterReport(resp *superaccpb.ReconcileRosterResponse) fyne.CanvasObject {
+	rows := container.NewVBox(widget.NewLabel(resp.Message))
+	for _, problem := range resp.Problems {
+		label := widget.NewLabel("Ошибка: " + problem)
+		label.Wrapping = fyne.TextWrapWord
+		rows.Add(label)
+	}
+	for _, c := range resp.Changes {
+		var text string
+		switch c.Action {
+		case "add":
+			text = fmt.Sprintf("%s: добавить в %s", c.Email, c.ToGroup)
+		case "move":
+			text = fmt.Sprintf("%s: перевести из %s в %s", c.Email, c.FromGroup, c.ToGroup)
+		case "remove":
+			text = fmt.Sprintf("%s: убрать из %s", c.Email, c.FromGroup)
+		}
+		rows.Add(widget.NewLabel(text))
+	}
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(600, 400))
+	return scroll
+}
+
+// showReconcileRosterDialog выбирает CSV (group,email) со списком деканата, показывает
+// разницу с текущим составом перечисленных групп и после подтверждения применяет её
+func showReconcileRosterDialog(state *AppState) {
+	w := state.window
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			log.Printf("Failed to read roster file: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		groups, err := parseRoster(data)
+		if err != nil {
+			log.Printf("Failed to parse roster file: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+
+		resp, err := reconcileRoster(state, groups, true)
+		if err != nil {
+			log.Printf("Failed to reconcile roster (dry run): %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		if !resp.Success || len(resp.Changes) == 0 {
+			dialog.ShowCustom("Сверка состава групп", "Закрыть", rosterReport(resp), w)
+			return
+		}
+		dialog.ShowCustomConfirm("Сверка состава групп", fmt.Sprintf("Применить %d", len(resp.Changes)), "Отмена",
+			rosterReport(resp), func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				resp, err := reconcileRoster(state, groups, false)
+				if err != nil {
+					log.Printf("Failed to reconcile roster: %v", err)
+					dialog.ShowError(err, w)
+					return
+				}
+				dialog.ShowCustom("Сверка состава групп", "Закрыть", rosterReport(resp), w)
+			}, w)
+	}, w)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+	open.Show()
+}
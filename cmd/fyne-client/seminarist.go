@@ -48,7 +48,7 @@ func CreateSeminaristWorksPage(state *AppState) fyne.CanvasObject {
 	separatorColor := color.NRGBA{R: 200, G: 200, B: 200, A: 255}
 
 	// Подключение к сервисам
-	workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+	workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Failed to connect to workservice: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к серверу"))
@@ -56,7 +56,7 @@ func CreateSeminaristWorksPage(state *AppState) fyne.CanvasObject {
 	defer workConn.Close()
 	workClient := workpb.NewWorkServiceClient(workConn)
 
-	workAssignmentConn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure())
+	workAssignmentConn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Failed to connect to workassignmentservice: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису назначений"))
@@ -64,7 +64,7 @@ func CreateSeminaristWorksPage(state *AppState) fyne.CanvasObject {
 	defer workAssignmentConn.Close()
 	workAssignmentClient := workassignmentpb.NewWorkAssignmentServiceClient(workAssignmentConn)
 
-	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Failed to connect to gradingservice: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису оценок"))
@@ -72,7 +72,7 @@ func CreateSeminaristWorksPage(state *AppState) fyne.CanvasObject {
 	defer gradingConn.Close()
 	gradingClient := gradingpb.NewGradingServiceClient(gradingConn)
 
-	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure())
+	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Failed to connect to rubricservice: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису рубрик"))
@@ -234,10 +234,9 @@ func CreateSeminaristWorksPage(state *AppState) fyne.CanvasObject {
 	// Кнопка выхода
 	backButton := widget.NewButton("Выйти из аккаунта", func() {
 		log.Println("Кнопка 'Выйти из аккаунта' нажата. Возврат на экран авторизации.")
-		state.currentPage = "greeting"
-		state.window.SetContent(createContent(state))
+		logout(state)
 	})
-	backButtonRow := container.New(layout.NewMaxLayout(), backButton)
+	backButtonRow := container.New(layout.NewGridLayout(2), profileButton(state), backButton)
 
 	// Создание вкладок
 	studentWorksContent := createWorksTable(studentWorks, separatorColor, state)
@@ -319,7 +318,7 @@ func createTasksTable(tasks []Task, separatorColor color.Color, state *AppState)
 				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
 
-				conn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+				conn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 				if err != nil {
 					log.Printf("Не удалось подключиться к сервису: %v", err)
 					return
@@ -377,7 +376,7 @@ func CreateTaskDetailsPage(state *AppState, taskID int32) fyne.CanvasObject {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	conn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+	conn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к сервису: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -474,7 +473,7 @@ func CreateAssistantAssignmentPage(state *AppState, taskID int32) fyne.CanvasObj
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	conn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+	conn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к сервису: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -511,7 +510,10 @@ func CreateAssistantAssignmentPage(state *AppState, taskID int32) fyne.CanvasObj
 	log.Printf("Loaded task details in %v", time.Since(startTime))
 
 	// Получение ассистентов
-	assistantsResp, err := client.GetAssistantsByDiscipline(ctx, &workpb.GetAssistantsByDisciplineRequest{DisciplineId: taskDetailsResp.DisciplineId})
+	assistantsResp, err := client.GetAssistantsByDiscipline(ctx, &workpb.GetAssistantsByDisciplineRequest{
+		DisciplineId: taskDetailsResp.DisciplineId,
+		GroupId:      taskDetailsResp.GroupId,
+	})
 	if err != nil {
 		log.Printf("Не удалось получить ассистентов: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка загрузки ассистентов"))
@@ -664,7 +666,7 @@ func CreateAssistantAssignmentPage(state *AppState, taskID int32) fyne.CanvasObj
 			defer assignCancel()
 
 			// Создаём новое gRPC-соединение для AssignAssistantsToWorks
-			assignConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+			assignConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 			if err != nil {
 				log.Printf("Не удалось подключиться к сервису для назначения ассистентов: %v", err)
 				popupContent := container.NewVBox(
@@ -727,7 +729,7 @@ func CreateSeminaristBlockingCriteriaGradingPage(state *AppState, workID int32,
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure())
+	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Failed to connect to rubricservice: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -735,7 +737,7 @@ func CreateSeminaristBlockingCriteriaGradingPage(state *AppState, workID int32,
 	defer rubricConn.Close()
 	rubricClient := rubricpb.NewRubricServiceClient(rubricConn)
 
-	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Failed to connect to gradingservice: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -861,7 +863,7 @@ func CreateSeminaristBlockingCriteriaGradingPage(state *AppState, workID int32,
 	//ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	//defer cancel()
 
-	conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure())
+	conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к сервису: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -903,7 +905,7 @@ func CreateSeminaristBlockingCriteriaGradingPage(state *AppState, workID int32,
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure())
+		conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Не удалось подключиться к сервису: %v", err)
 			dialog.ShowError(fmt.Errorf("Ошибка подключения к сервису: %v", err), w)
@@ -1003,7 +1005,7 @@ func CreateSeminaristBlockingCriteriaGradingPage(state *AppState, workID int32,
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 
-		gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+		gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Failed to connect to gradingservice: %v", err)
 			dialog.ShowError(err, w)
@@ -1086,7 +1088,7 @@ func CreateSeminaristMainCriteriaGradingPage(state *AppState, workID int32, task
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure())
+	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к RubricService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -1094,7 +1096,7 @@ func CreateSeminaristMainCriteriaGradingPage(state *AppState, workID int32, task
 	defer rubricConn.Close()
 	rubricClient := rubricpb.NewRubricServiceClient(rubricConn)
 
-	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к GradingService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -1102,7 +1104,7 @@ func CreateSeminaristMainCriteriaGradingPage(state *AppState, workID int32, task
 	defer gradingConn.Close()
 	gradingClient := gradingpb.NewGradingServiceClient(gradingConn)
 
-	workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+	workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к WorkService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -1359,7 +1361,7 @@ func CreateSeminaristMainCriteriaGradingPage(state *AppState, workID int32, task
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+		gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Не удалось подключиться к GradingService: %v", err)
 			dialog.ShowError(err, w)
@@ -1421,7 +1423,7 @@ func CreateSeminaristMainCriteriaGradingPage(state *AppState, workID int32, task
 		}
 
 		// Обновление статуса работы и очистка assistant_id
-		workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+		workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Не удалось подключиться к WorkService: %v", err)
 			dialog.ShowError(err, w)
@@ -1431,15 +1433,20 @@ func CreateSeminaristMainCriteriaGradingPage(state *AppState, workID int32, task
 		workClient := workpb.NewWorkServiceClient(workConn)
 
 		start := time.Now()
-		_, err = workClient.UpdateWork(ctx, &workpb.UpdateWorkRequest{
+		updateResp, err := workClient.UpdateWork(ctx, &workpb.UpdateWorkRequest{
 			WorkId: workID,
-			Status: "graded by seminarist",
+			Status: workpb.WorkStatus_WORK_STATUS_GRADED_BY_SEMINARIST,
 		})
 		if err != nil {
 			log.Printf("Не удалось обновить статус работы %d: %v (время выполнения: %v)", workID, err, time.Since(start))
 			dialog.ShowError(err, w)
 			return
 		}
+		if updateResp.Error != "" {
+			log.Printf("Ошибка UpdateWork для работы %d: %s (время выполнения: %v)", workID, updateResp.Error, time.Since(start))
+			dialog.ShowInformation("Ошибка", updateResp.Error, w)
+			return
+		}
 
 		log.Printf("Статус работы %d обновлен на 'graded by seminarist', assistant_id очищен (время выполнения: %v)", workID, time.Since(start))
 		dialog.ShowInformation("Успех", "Оценка завершена", w)
@@ -1545,7 +1552,7 @@ func CreateTaskStudentWorksPage(state *AppState, taskID int32) fyne.CanvasObject
 	defer cancel()
 
 	// Подключение к сервисам
-	workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+	workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к WorkService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -1553,7 +1560,7 @@ func CreateTaskStudentWorksPage(state *AppState, taskID int32) fyne.CanvasObject
 	defer workConn.Close()
 	workClient := workpb.NewWorkServiceClient(workConn)
 
-	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к GradingService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -1561,7 +1568,7 @@ func CreateTaskStudentWorksPage(state *AppState, taskID int32) fyne.CanvasObject
 	defer gradingConn.Close()
 	gradingClient := gradingpb.NewGradingServiceClient(gradingConn)
 
-	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure())
+	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к RubricService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -1684,7 +1691,7 @@ func CreateTaskStudentWorksPage(state *AppState, taskID int32) fyne.CanvasObject
 				downloadCtx, downloadCancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer downloadCancel()
 
-				workAssignmentConn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure())
+				workAssignmentConn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure(), withAuth(state))
 				if err != nil {
 					log.Printf("Не удалось подключиться к WorkAssignmentService: %v", err)
 					dialog.ShowInformation("Ошибка", "Не удалось подключиться к сервису", state.window)
@@ -1783,7 +1790,7 @@ func CreateTaskReportPage(state *AppState, taskID int32) fyne.CanvasObject {
 	defer cancel()
 
 	// Подключение к сервисам
-	workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+	workConn, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к WorkService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -1791,7 +1798,7 @@ func CreateTaskReportPage(state *AppState, taskID int32) fyne.CanvasObject {
 	defer workConn.Close()
 	workClient := workpb.NewWorkServiceClient(workConn)
 
-	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+	gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к GradingService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -1799,7 +1806,7 @@ func CreateTaskReportPage(state *AppState, taskID int32) fyne.CanvasObject {
 	defer gradingConn.Close()
 	gradingClient := gradingpb.NewGradingServiceClient(gradingConn)
 
-	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure())
+	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к RubricService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -59,18 +59,17 @@ func СreateStudentGradesPage(state *AppState) fyne.CanvasObject {
 			"Выйти из приложения?",
 			func(ok bool) {
 				if ok {
-					state.currentPage = "greeting"
-					myWindow.SetContent(createContent(state))
+					logout(state)
 					return
 				}
 			},
 			myWindow,
 		)
 	})
-	backButtonRow := container.New(layout.NewMaxLayout(), backButton)
+	backButtonRow := container.New(layout.NewGridLayout(2), profileButton(state), backButton)
 
 	// Подключение к WorkService
-	connWork, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+	connWork, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к WorkService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к серверу работ"))
@@ -79,7 +78,7 @@ func СreateStudentGradesPage(state *AppState) fyne.CanvasObject {
 	workClient := pbWork.NewWorkServiceClient(connWork)
 
 	// Подключение к GradingService
-	connGrade, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+	connGrade, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к GradingService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к серверу оценок"))
@@ -115,7 +114,7 @@ func СreateStudentGradesPage(state *AppState) fyne.CanvasObject {
 		}
 		log.Printf("%s: %v", discipline.Name, worksResp.Works)
 
-		rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure())
+		rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Не удалось подключиться к сервису рубрик: %v", err)
 			return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису рубрик"))
@@ -275,7 +274,7 @@ func СreateStudentWorksPage(state *AppState) fyne.CanvasObject {
 	var tableContent []fyne.CanvasObject
 
 	// Подключение к WorkService (порт 50053) для получения списка работ
-	connWork, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure())
+	connWork, err := grpc.Dial("89.169.39.161:50053", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к WorkService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к серверу работ"))
@@ -344,7 +343,7 @@ func CreateStudentWorkDetailsPage(state *AppState) fyne.CanvasObject {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure())
+	conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к сервису: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -362,7 +361,7 @@ func CreateStudentWorkDetailsPage(state *AppState) fyne.CanvasObject {
 		return container.NewVBox(widget.NewLabel(resp.Error))
 	}
 
-	connExist, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure())
+	connExist, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к сервису: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -467,7 +466,7 @@ func CreateStudentWorkDetailsPage(state *AppState) fyne.CanvasObject {
 
 				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 				defer cancel()
-				conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure())
+				conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure(), withAuth(state))
 				if err != nil {
 					log.Printf("Не удалось подключиться к сервису: %v", err)
 					dialog.ShowError(fmt.Errorf("Ошибка подключения к сервису: %v", err), w)
@@ -540,7 +539,7 @@ func CreateStudentWorkDetailsPage(state *AppState) fyne.CanvasObject {
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 			defer cancel()
 
-			conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure())
+			conn, err := grpc.Dial("89.169.39.161:50054", grpc.WithInsecure(), withAuth(state))
 			if err != nil {
 				log.Printf("Не удалось подключиться к сервису: %v", err)
 				dialog.ShowError(fmt.Errorf("Ошибка подключения к сервису: %v", err), w)
@@ -688,7 +687,7 @@ func CreateStudentBlockingCriteriaPage(state *AppState) fyne.CanvasObject {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure())
+	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Failed to connect to rubricservice: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -699,7 +698,7 @@ func CreateStudentBlockingCriteriaPage(state *AppState) fyne.CanvasObject {
 	marksMap := make(map[int32]gradingpb.CriterionMark)
 
 	if workID != 0 {
-		gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+		gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Failed to connect to gradingservice: %v", err)
 			return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -875,7 +874,7 @@ func CreateStudentMainCriteriaPage(state *AppState) fyne.CanvasObject {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure())
+	rubricConn, err := grpc.Dial("89.169.39.161:50055", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Не удалось подключиться к RubricService: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -885,7 +884,7 @@ func CreateStudentMainCriteriaPage(state *AppState) fyne.CanvasObject {
 
 	marksMap := make(map[int32]gradingpb.CriterionMark)
 	if workID != 0 {
-		gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure())
+		gradingConn, err := grpc.Dial("89.169.39.161:50057", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Не удалось подключиться к GradingService: %v", err)
 			return container.NewVBox(widget.NewLabel("Ошибка подключения к сервису"))
@@ -24,6 +24,12 @@ import (
 
 var GroupName string
 
+// userRole переводит роль из списка выбора в UserRole; неизвестная роль даёт
+// USER_ROLE_UNSPECIFIED, и сервер её отклонит
+func userRole(role string) superaccpb.UserRole {
+	return superaccpb.UserRole(superaccpb.UserRole_value["USER_ROLE_"+strings.ToUpper(role)])
+}
+
 type GroupEntry struct {
 	NameEntry        *widget.Entry
 	DescriptionEntry *widget.Entry
@@ -58,15 +64,14 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 			"Выйти из приложения?",
 			func(ok bool) {
 				if ok {
-					state.currentPage = "greeting"
-					w.SetContent(createContent(state))
+					logout(state)
 					return
 				}
 			},
 			w,
 		)
 	})
-	backButtonContainer := container.NewHBox(layout.NewSpacer(), backButton)
+	backButtonContainer := container.NewHBox(layout.NewSpacer(), profileButton(state), backButton)
 
 	groupInfoListContainer := container.NewVBox()
 
@@ -92,7 +97,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 		descriptionEntryContainer.Resize(fyne.NewSize(250, 60))
 
 		// Получаем прикреплённые дисциплины через gRPC
-		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Не удалось подключиться к superaccservice: %v", err)
 			return
@@ -126,7 +131,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 		}
 
 		deleteDisciplineButton := widget.NewButton("Удалить дисциплину", func() {
-			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 			if err != nil {
 				log.Printf("Не удалось подключиться к superaccservice: %v", err)
 				return
@@ -181,7 +186,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 							}
 						}
 						if len(selectedIDs) > 0 {
-							connFinal, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+							connFinal, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 							if err != nil {
 								log.Printf("Не удалось подключиться к superaccservice: %v", err)
 								return
@@ -202,7 +207,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 							} else {
 								log.Printf("Дисциплины успешно откреплены от группы %s", group.Name)
 								// Обновляем список дисциплин
-								connUpdate, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+								connUpdate, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 								if err == nil {
 									defer connUpdate.Close()
 									clientUpdate := superaccpb.NewSuperAccServiceClient(connUpdate)
@@ -227,7 +232,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 		})
 
 		attachDisciplineButton := widget.NewButton("Прикрепить дисциплину", func() {
-			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 			if err != nil {
 				log.Printf("Не удалось подключиться к superaccservice: %v", err)
 				return
@@ -279,14 +284,16 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 			checkGroup := container.NewVBox(checkItems...)
 
 			if seminaristID == 0 || assistantID == 0 {
-				respUsers, err := client.ListAllUsers(context.Background(), &superaccpb.ListAllUsersRequest{})
+				staff, err := listAllUserPages(context.Background(), client, &superaccpb.ListAllUsersRequest{
+					Roles: []string{"seminarist", "assistant"},
+				})
 				if err != nil {
 					log.Printf("Не удалось получить список пользователей: %v", err)
 					return
 				}
 				var userOptions []string
 				var userIDs []int32
-				for _, u := range respUsers.Users {
+				for _, u := range staff {
 					if u.Status == "seminarist" || u.Status == "assistant" {
 						userOptions = append(userOptions, fmt.Sprintf("%s (%s)", u.Fio, u.Email))
 						userIDs = append(userIDs, u.Id)
@@ -317,7 +324,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 							}
 
 							// Прикрепляем выбранных семинариста и ассистента к группе
-							connInner, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+							connInner, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 							if err != nil {
 								log.Printf("Не удалось подключиться к superaccservice: %v", err)
 								return
@@ -329,7 +336,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 								GroupId: group.Id,
 								Action:  "add",
 								UserId:  selectedSeminaristID,
-								Role:    "seminarist",
+								Role:    superaccpb.UserRole_USER_ROLE_SEMINARIST,
 							})
 							if err != nil {
 								log.Printf("Не удалось добавить семинариста: %v", err)
@@ -340,7 +347,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 									GroupId: group.Id,
 									Action:  "add",
 									UserId:  selectedAssistantID,
-									Role:    "assistant",
+									Role:    superaccpb.UserRole_USER_ROLE_ASSISTANT,
 								})
 								if err != nil {
 									log.Printf("Не удалось добавить ассистента: %v", err)
@@ -364,7 +371,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 											}
 										}
 										if len(selectedIDs) > 0 {
-											connFinal, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+											connFinal, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 											if err != nil {
 												log.Printf("Не удалось подключиться к superaccservice: %v", err)
 												return
@@ -388,7 +395,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 											} else {
 												log.Printf("Дисциплины успешно прикреплены к группе %s", group.Name)
 												// Обновляем список дисциплин
-												connUpdate, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+												connUpdate, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 												if err == nil {
 													defer connUpdate.Close()
 													clientUpdate := superaccpb.NewSuperAccServiceClient(connUpdate)
@@ -431,7 +438,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 								}
 							}
 							if len(selectedIDs) > 0 {
-								connFinal, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+								connFinal, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 								if err != nil {
 									log.Printf("Не удалось подключиться к superaccservice: %v", err)
 									return
@@ -455,7 +462,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 								} else {
 									log.Printf("Дисциплины успешно прикреплены к группе %s", group.Name)
 									// Обновляем список дисциплин
-									connUpdate, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+									connUpdate, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 									if err == nil {
 										defer connUpdate.Close()
 										clientUpdate := superaccpb.NewSuperAccServiceClient(connUpdate)
@@ -480,6 +487,10 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 			}
 		})
 
+		staffButton := widget.NewButton("Преподаватели", func() {
+			showDisciplineStaffDialog(state, group.Id, group.Name, attachedDisciplines)
+		})
+
 		nextButton := widget.NewButton("Подробнее", func() {
 			log.Printf("Кнопка 'Подробнее' нажата для группы ID: %d", group.Id)
 			GroupName = group.Name
@@ -493,7 +504,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 				fmt.Sprintf("Удалить группу '%s'?", group.Name),
 				func(confirmed bool) {
 					if confirmed {
-						conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+						conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 						if err != nil {
 							log.Printf("Не удалось подключиться к superaccservice: %v", err)
 							return
@@ -528,12 +539,13 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 			)
 		})
 
-		groupRow := container.New(layout.NewGridLayoutWithColumns(7),
+		groupRow := container.New(layout.NewGridLayoutWithColumns(8),
 			container.NewPadded(container.NewPadded(nameEntryContainer)),
 			container.NewPadded(container.NewPadded(descriptionEntryContainer)),
 			container.NewPadded(container.NewPadded(commentEntryContainer)),
 			container.NewPadded(container.NewPadded(deleteDisciplineButton)),
 			container.NewPadded(container.NewPadded(attachDisciplineButton)),
+			container.NewPadded(container.NewPadded(staffButton)),
 			container.NewPadded(container.NewPadded(nextButton)),
 			container.NewPadded(container.NewPadded(deleteButton)),
 		)
@@ -549,7 +561,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 		})
 	}
 
-	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Failed to connect to superaccservice: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к серверу"))
@@ -592,7 +604,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 			},
 			func(confirmed bool) {
 				if confirmed && nameEntry.Text != "" {
-					conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+					conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 					if err != nil {
 						log.Printf("Failed to connect to superaccservice: %v", err)
 						return
@@ -640,7 +652,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 	})
 
 	createDisciplineButton := widget.NewButton("Создать дисциплину", func() {
-		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Failed to connect to superaccservice: %v", err)
 			dialog.ShowInformation("Ошибка", "Не удалось подключиться к серверу", w)
@@ -655,7 +667,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
-		respUsers, err := client.ListAllUsers(ctx, &superaccpb.ListAllUsersRequest{})
+		lecturers, err := listAllUserPages(ctx, client, &superaccpb.ListAllUsersRequest{Roles: []string{"lecturer"}})
 		if err != nil {
 			log.Printf("Failed to list users: %v", err)
 			dialog.ShowInformation("Ошибка", "Не удалось загрузить список пользователей", w)
@@ -663,7 +675,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 		}
 		var lecturerOptions []string
 		var lecturerIDs []int32
-		for _, u := range respUsers.Users {
+		for _, u := range lecturers {
 			if u.Status == "lecturer" {
 				lecturerOptions = append(lecturerOptions, fmt.Sprintf("%s (%s)", u.Fio, u.Email))
 				lecturerIDs = append(lecturerIDs, u.Id)
@@ -672,6 +684,14 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 		lecturerSelect := widget.NewSelect(append([]string{"None"}, lecturerOptions...), nil)
 		lecturerSelect.SetSelectedIndex(0)
 
+		terms, err := listTerms(ctx, client)
+		if err != nil {
+			log.Printf("Failed to list terms: %v", err)
+			dialog.ShowInformation("Ошибка", "Не удалось загрузить список семестров", w)
+			return
+		}
+		termSelect, selectedTerm := termSelect(terms)
+
 		dialog.ShowForm(
 			"Создать дисциплину",
 			"OK",
@@ -679,6 +699,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 			[]*widget.FormItem{
 				widget.NewFormItem("Название", nameEntry),
 				widget.NewFormItem("Лектор", lecturerSelect),
+				widget.NewFormItem("Семестр", termSelect),
 			},
 			func(confirmed bool) {
 				if confirmed && nameEntry.Text != "" {
@@ -689,8 +710,13 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 						dialog.ShowInformation("Ошибка", "Не выбран лектор", w)
 						return
 					}
+					termID := selectedTerm()
+					if termID == 0 {
+						dialog.ShowInformation("Ошибка", "Не выбран семестр", w)
+						return
+					}
 
-					conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), grpc.WithBlock(), grpc.WithTimeout(15*time.Second))
+					conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state), grpc.WithBlock(), grpc.WithTimeout(15*time.Second))
 					if err != nil {
 						log.Printf("Failed to connect to superaccservice: %v", err)
 						dialog.ShowInformation("Ошибка", "Не удалось подключиться к серверу", w)
@@ -707,6 +733,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 						Action:   "create",
 						Name:     nameEntry.Text,
 						LectorId: lectorID,
+						TermId:   termID,
 					})
 					if err != nil {
 						log.Printf("Не удалось создать дисциплину: %v", err)
@@ -731,7 +758,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 		var conn *grpc.ClientConn
 
 		// Получаем список дисциплин внутри диалога
-		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Failed to connect to superaccservice: %v", err)
 			dialog.ShowInformation("Ошибка", "Не удалось подключиться к серверу", w)
@@ -787,7 +814,7 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 					}
 					if len(selectedIDs) > 0 {
 						// Создаем новое соединение для удаления
-						conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+						conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 						if err != nil {
 							log.Printf("Failed to connect to superaccservice: %v", err)
 							dialog.ShowInformation("Ошибка", "Не удалось подключиться к серверу", w)
@@ -822,11 +849,43 @@ func СreateGroupListPage(state *AppState) fyne.CanvasObject {
 		)
 	})
 
+	invitationsButton := widget.NewButton("Приглашения", func() {
+		state.currentPage = "superacc-invitations"
+		w.SetContent(createContent(state))
+	})
+
+	directoryButton := widget.NewButton("Справочник", func() {
+		showDirectoryDialog(state)
+	})
+
+	termsButton := widget.NewButton("Семестры", func() {
+		showTermsDialog(state)
+	})
+
+	lecturersButton := widget.NewButton("Лекторы", func() {
+		showDisciplineLecturersDialog(state)
+	})
+
+	rosterButton := widget.NewButton("Сверка состава", func() {
+		showReconcileRosterDialog(state)
+	})
+
+	auditButton := widget.NewButton("Журнал", func() {
+		state.currentPage = "superacc-audit"
+		w.SetContent(createContent(state))
+	})
+
 	bottomButtons := container.New(layout.NewHBoxLayout(),
 		addButton,
 		layout.NewSpacer(),
 		deleteDisciplineButton,
 		createDisciplineButton,
+		lecturersButton,
+		invitationsButton,
+		termsButton,
+		directoryButton,
+		rosterButton,
+		auditButton,
 		nextButton,
 	)
 
@@ -928,7 +987,7 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 			usersData[idx].Status = selected
 			fmt.Printf("Статус пользователя %s изменен на: %s\n", usersData[idx].FIOEmail, selected)
 
-			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 			if err != nil {
 				log.Printf("Failed to connect to superaccservice: %v", err)
 				return
@@ -936,27 +995,15 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 			defer conn.Close()
 
 			client := superaccpb.NewSuperAccServiceClient(conn)
-			resp, err := client.ListAllUsers(context.Background(), &superaccpb.ListAllUsersRequest{})
+			userID, err := findUserID(context.Background(), client, extractEmail(user.FIOEmail))
 			if err != nil {
-				log.Printf("Failed to list users: %v", err)
-				return
-			}
-			var userID int32
-			email := extractEmail(user.FIOEmail)
-			for _, u := range resp.Users {
-				if u.Email == email {
-					userID = u.Id
-					break
-				}
-			}
-			if userID == 0 {
-				log.Printf("User with email %s not found", email)
+				log.Printf("Failed to find user: %v", err)
 				return
 			}
 
 			updateResp, err := client.UpdateUserRole(context.Background(), &superaccpb.UpdateRoleRequest{
 				UserId: userID,
-				Role:   selected,
+				Role:   userRole(selected),
 			})
 			if err != nil {
 				log.Printf("Failed to update role: %v", err)
@@ -964,6 +1011,7 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 			}
 			if !updateResp.Success {
 				log.Printf("Update role failed: %s", updateResp.Message)
+				dialog.ShowInformation("Ошибка", updateResp.Message, w)
 			} else {
 				log.Printf("Role updated successfully for user %s", user.FIOEmail)
 			}
@@ -976,7 +1024,7 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 				fmt.Sprintf("Удалить пользователя '%s' из группы?", user.FIOEmail),
 				func(confirmed bool) {
 					if confirmed {
-						conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+						conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 						if err != nil {
 							log.Printf("Failed to connect to superaccservice: %v", err)
 							return
@@ -986,20 +1034,9 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 						client := superaccpb.NewSuperAccServiceClient(conn)
 						email := extractEmail(user.FIOEmail)
 
-						respUsers, err := client.ListAllUsers(context.Background(), &superaccpb.ListAllUsersRequest{})
+						userID, err := findUserID(context.Background(), client, email)
 						if err != nil {
-							log.Printf("Failed to list users: %v", err)
-							return
-						}
-						var userID int32
-						for _, u := range respUsers.Users {
-							if u.Email == email {
-								userID = u.Id
-								break
-							}
-						}
-						if userID == 0 {
-							log.Printf("User with email %s not found", email)
+							log.Printf("Failed to find user: %v", err)
 							return
 						}
 
@@ -1024,7 +1061,7 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 							GroupId: groupID,
 							Action:  "remove",
 							UserId:  userID,
-							Role:    user.Status,
+							Role:    userRole(user.Status),
 						})
 						if err != nil {
 							log.Printf("Failed to remove user from group: %v", err)
@@ -1034,10 +1071,10 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 							log.Printf("Remove user from group failed: %s", resp.Message)
 						} else {
 							log.Printf("User %s removed from group %s", user.FIOEmail, groupName)
-							respUsersByGroup, err := client.ListUsersByGroup(context.Background(), &superaccpb.ListUsersByGroupRequest{GroupName: groupName})
+							groupUsers, err := listGroupUsers(context.Background(), client, groupName)
 							if err == nil {
-								usersData = make([]UserEntry, len(respUsersByGroup.Users))
-								for i, user := range respUsersByGroup.Users {
+								usersData = make([]UserEntry, len(groupUsers))
+								for i, user := range groupUsers {
 									usersData[i] = UserEntry{FIOEmail: user.Fio + ", " + user.Email, Status: user.Status}
 								}
 								updateUsersListUI()
@@ -1086,7 +1123,7 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 		usersListContainer.Refresh()
 	}
 
-	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 	if err != nil {
 		log.Printf("Failed to connect to superaccservice: %v", err)
 		return container.NewVBox(widget.NewLabel("Ошибка подключения к серверу"))
@@ -1094,18 +1131,14 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 	defer conn.Close()
 
 	client := superaccpb.NewSuperAccServiceClient(conn)
-	resp, err := client.ListUsersByGroup(context.Background(), &superaccpb.ListUsersByGroupRequest{GroupName: groupName})
+	groupUsers, err := listGroupUsers(context.Background(), client, groupName)
 	if err != nil {
 		log.Printf("Failed to list users: %v", err)
-		return container.NewVBox(widget.NewLabel("Ошибка загрузки пользователей"))
-	}
-	if !resp.Success {
-		log.Printf("ListUsersByGroup failed: %s", resp.Message)
-		return container.NewVBox(widget.NewLabel(fmt.Sprintf("Ошибка: %s", resp.Message)))
+		return container.NewVBox(widget.NewLabel(fmt.Sprintf("Ошибка загрузки пользователей: %v", err)))
 	}
 
-	usersData = make([]UserEntry, len(resp.Users))
-	for i, user := range resp.Users {
+	usersData = make([]UserEntry, len(groupUsers))
+	for i, user := range groupUsers {
 		usersData[i] = UserEntry{FIOEmail: user.Fio + ", " + user.Email, Status: user.Status}
 	}
 
@@ -1123,7 +1156,7 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 			query := strings.ToLower(searchEntry.Text)
 			filteredUsers = nil
 
-			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 			if err != nil {
 				log.Printf("Failed to connect to superaccservice: %v", err)
 				return
@@ -1131,18 +1164,19 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 			defer conn.Close()
 
 			client := superaccpb.NewSuperAccServiceClient(conn)
-			allUsersResp, err := client.ListAllUsers(context.Background(), &superaccpb.ListAllUsersRequest{})
+			// Поиск идёт на сервере; в диалоге достаточно первой страницы совпадений
+			allUsersResp, err := client.ListAllUsers(context.Background(), &superaccpb.ListAllUsersRequest{Search: query})
 			if err != nil {
 				log.Printf("Failed to list all users: %v", err)
 				return
 			}
+			if !allUsersResp.Success {
+				log.Printf("ListAllUsers failed: %s", allUsersResp.Message)
+				return
+			}
 
 			for _, user := range allUsersResp.Users {
-				if strings.Contains(strings.ToLower(user.Fio), query) || strings.Contains(strings.ToLower(user.Email), query) {
-					if user.Group == groupName || user.Group == "" {
-						filteredUsers = append(filteredUsers, UserEntry{FIOEmail: user.Fio + ", " + user.Email, Status: user.Status})
-					}
-				}
+				filteredUsers = append(filteredUsers, UserEntry{FIOEmail: user.Fio + ", " + user.Email, Status: user.Status})
 			}
 		}
 
@@ -1158,7 +1192,7 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 
 		userList.OnSelected = func(id widget.ListItemID) {
 			selectedUser := filteredUsers[id]
-			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 			if err != nil {
 				log.Printf("Failed to connect to superaccservice: %v", err)
 				return
@@ -1168,20 +1202,9 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 			client := superaccpb.NewSuperAccServiceClient(conn)
 			email := extractEmail(selectedUser.FIOEmail)
 
-			respUsers, err := client.ListAllUsers(context.Background(), &superaccpb.ListAllUsersRequest{})
+			userID, err := findUserID(context.Background(), client, email)
 			if err != nil {
-				log.Printf("Failed to list users: %v", err)
-				return
-			}
-			var userID int32
-			for _, user := range respUsers.Users {
-				if user.Email == email {
-					userID = user.Id
-					break
-				}
-			}
-			if userID == 0 {
-				log.Printf("User with email %s not found", email)
+				log.Printf("Failed to find user: %v", err)
 				return
 			}
 
@@ -1206,7 +1229,7 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 				GroupId: groupID,
 				Action:  "add",
 				UserId:  userID,
-				Role:    selectedUser.Status,
+				Role:    userRole(selectedUser.Status),
 			})
 			if err != nil {
 				log.Printf("Failed to add user to group: %v", err)
@@ -1219,13 +1242,13 @@ func СreateGroupUsersPage(state *AppState, groupName string) fyne.CanvasObject
 				return
 			}
 
-			respUsersByGroup, err := client.ListUsersByGroup(context.Background(), &superaccpb.ListUsersByGroupRequest{GroupName: groupName})
+			groupUsers, err := listGroupUsers(context.Background(), client, groupName)
 			if err != nil {
 				log.Printf("Failed to refresh users: %v", err)
 				return
 			}
-			usersData = make([]UserEntry, len(respUsersByGroup.Users))
-			for i, user := range respUsersByGroup.Users {
+			usersData = make([]UserEntry, len(groupUsers))
+			for i, user := range groupUsers {
 				usersData[i] = UserEntry{FIOEmail: user.Fio + ", " + user.Email, Status: user.Status}
 			}
 			updateUsersListUI()
@@ -1280,6 +1303,7 @@ type User struct {
 	Email  string
 	Group  string
 	Status string
+	Active bool
 }
 
 func СreateUsersListPage(state *AppState) fyne.CanvasObject {
@@ -1356,7 +1380,11 @@ func СreateUsersListPage(state *AppState) fyne.CanvasObject {
 	columnHeadersContainer := container.New(layout.NewHBoxLayout(), columnHeaders, layout.NewSpacer())
 
 	createUserTableRow := func(user *User, idx int) *fyne.Container {
-		fioEmailCombinedLabel := widget.NewLabel(fmt.Sprintf("%s, %s", user.FIO, user.Email))
+		fioEmailText := fmt.Sprintf("%s, %s", user.FIO, user.Email)
+		if !user.Active {
+			fioEmailText += " (деактивирован)"
+		}
+		fioEmailCombinedLabel := widget.NewLabel(fioEmailText)
 		fioEmailCombinedLabel.Wrapping = fyne.TextWrapWord
 
 		groupLabel := widget.NewLabel(user.Group)
@@ -1367,7 +1395,7 @@ func СreateUsersListPage(state *AppState) fyne.CanvasObject {
 			user.Status = selectedStatus
 			fmt.Printf("Статус пользователя %s (%s) изменен на: %s\n", user.FIO, user.Email, selectedStatus)
 
-			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 			if err != nil {
 				log.Printf("Failed to connect to superaccservice: %v", err)
 				return
@@ -1377,7 +1405,7 @@ func СreateUsersListPage(state *AppState) fyne.CanvasObject {
 			client := superaccpb.NewSuperAccServiceClient(conn)
 			resp, err := client.UpdateUserRole(context.Background(), &superaccpb.UpdateRoleRequest{
 				UserId: int32(user.ID),
-				Role:   selectedStatus,
+				Role:   userRole(selectedStatus),
 			})
 			if err != nil {
 				log.Printf("Failed to update role: %v", err)
@@ -1385,19 +1413,25 @@ func СreateUsersListPage(state *AppState) fyne.CanvasObject {
 			}
 			if !resp.Success {
 				log.Printf("Update role failed: %s", resp.Message)
+				dialog.ShowInformation("Ошибка", resp.Message, w)
 			} else {
 				log.Printf("Role updated successfully for user %s", user.FIO)
 			}
 		})
 		statusSelect.SetSelected(user.Status)
 
-		deleteButton := widget.NewButton("Удалить", func() {
-			dialog.ShowConfirm(
-				"Подтверждение удаления",
-				fmt.Sprintf("Удалить пользователя '%s (%s)'?", user.FIO, user.Email),
-				func(confirmed bool) {
-					if confirmed {
-						conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+		// Деактивация сохраняет работы и оценки; окончательно удалить можно только деактивированного
+		var deleteButton *widget.Button
+		if user.Active {
+			deleteButton = widget.NewButton("Деактивировать", func() {
+				dialog.ShowConfirm(
+					"Подтверждение деактивации",
+					fmt.Sprintf("Деактивировать пользователя '%s (%s)'? Вход будет запрещён, работы и оценки сохранятся.", user.FIO, user.Email),
+					func(confirmed bool) {
+						if !confirmed {
+							return
+						}
+						conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 						if err != nil {
 							log.Printf("Failed to connect to superaccservice: %v", err)
 							return
@@ -1405,29 +1439,158 @@ func СreateUsersListPage(state *AppState) fyne.CanvasObject {
 						defer conn.Close()
 
 						client := superaccpb.NewSuperAccServiceClient(conn)
-						resp, err := client.RemoveUser(context.Background(), &superaccpb.RemoveUserRequest{
-							Email: user.Email,
+						resp, err := client.DeactivateUser(context.Background(), &superaccpb.DeactivateUserRequest{
+							UserId: int32(user.ID),
 						})
 						if err != nil {
-							log.Printf("Failed to remove user: %v", err)
+							log.Printf("Failed to deactivate user: %v", err)
 							return
 						}
 						if !resp.Success {
-							log.Printf("Remove user failed: %s", resp.Message)
-						} else {
-							log.Printf("User %s (%s) successfully removed", user.FIO, user.Email)
-							updateUsersTableUI(searchEntry.Text)
+							dialog.ShowInformation("Ошибка", resp.Message, w)
+							return
 						}
+						log.Printf("User %s (%s) deactivated", user.FIO, user.Email)
+						updateUsersTableUI(searchEntry.Text)
+					},
+					w,
+				)
+			})
+		} else {
+			deleteButton = widget.NewButton("Активировать", func() {
+				conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+				if err != nil {
+					log.Printf("Failed to connect to superaccservice: %v", err)
+					return
+				}
+				defer conn.Close()
+
+				client := superaccpb.NewSuperAccServiceClient(conn)
+				resp, err := client.ReactivateUser(context.Background(), &superaccpb.ReactivateUserRequest{
+					UserId: int32(user.ID),
+				})
+				if err != nil {
+					log.Printf("Failed to reactivate user: %v", err)
+					return
+				}
+				if !resp.Success {
+					dialog.ShowInformation("Ошибка", resp.Message, w)
+					return
+				}
+				log.Printf("User %s (%s) reactivated", user.FIO, user.Email)
+				updateUsersTableUI(searchEntry.Text)
+			})
+		}
+
+		purgeButton := widget.NewButton("Удалить навсегда", func() {
+			reasonEntry := widget.NewMultiLineEntry()
+			reasonEntry.SetPlaceHolder("Причина удаления")
+			confirmEmailEntry := widget.NewEntry()
+			confirmEmailEntry.SetPlaceHolder(user.Email)
+			dialog.ShowForm(
+				"Окончательное удаление",
+				"Удалить",
+				"Отмена",
+				[]*widget.FormItem{
+					widget.NewFormItem("", widget.NewLabel("Пользователь, его работы и оценки будут удалены без возможности восстановления.")),
+					widget.NewFormItem("Причина", reasonEntry),
+					widget.NewFormItem("Почта для подтверждения", confirmEmailEntry),
+				},
+				func(confirmed bool) {
+					if !confirmed {
+						return
+					}
+					conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+					if err != nil {
+						log.Printf("Failed to connect to superaccservice: %v", err)
+						return
+					}
+					defer conn.Close()
+
+					client := superaccpb.NewSuperAccServiceClient(conn)
+					resp, err := client.PurgeUser(context.Background(), &superaccpb.PurgeUserRequest{
+						UserId:       int32(user.ID),
+						Reason:       reasonEntry.Text,
+						ConfirmEmail: confirmEmailEntry.Text,
+					})
+					if err != nil {
+						log.Printf("Failed to purge user: %v", err)
+						return
+					}
+					if !resp.Success {
+						dialog.ShowInformation("Ошибка", resp.Message, w)
+						return
+					}
+					log.Printf("User %s (%s) purged", user.FIO, user.Email)
+					updateUsersTableUI(searchEntry.Text)
+				},
+				w,
+			)
+		})
+		if user.Active {
+			purgeButton.Disable()
+		}
+
+		impersonateButton := widget.NewButton("Войти как", func() {
+			reasonEntry := widget.NewEntry()
+			reasonEntry.SetPlaceHolder("Например, номер обращения")
+			dialog.ShowForm(
+				"Вход от имени пользователя",
+				"Войти",
+				"Отмена",
+				[]*widget.FormItem{
+					widget.NewFormItem("", widget.NewLabel(fmt.Sprintf("Все действия будут записаны от имени %s и от вашего.", user.Email))),
+					widget.NewFormItem("Причина", reasonEntry),
+				},
+				func(confirmed bool) {
+					if confirmed {
+						startImpersonation(state, int32(user.ID), reasonEntry.Text)
 					}
 				},
 				w,
 			)
 		})
+		if !user.Active || user.Status == "superaccount" {
+			impersonateButton.Disable()
+		}
+
+		unlockButton := widget.NewButton("Разблокировать вход", func() {
+			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+			if err != nil {
+				log.Printf("Failed to connect to superaccservice: %v", err)
+				return
+			}
+			defer conn.Close()
+
+			client := superaccpb.NewSuperAccServiceClient(conn)
+			resp, err := client.UnlockUser(context.Background(), &superaccpb.UnlockUserRequest{
+				UserId: int32(user.ID),
+			})
+			if err != nil {
+				log.Printf("Failed to unlock user: %v", err)
+				return
+			}
+			if !resp.Success {
+				dialog.ShowInformation("Ошибка", resp.Message, w)
+				return
+			}
+			dialog.ShowInformation("Готово", fmt.Sprintf("Вход для %s разблокирован", user.Email), w)
+		})
+
+		mergeButton := widget.NewButton("Объединить", func() {
+			showMergeUsersDialog(state, int32(user.ID), user.Email, func() {
+				updateUsersTableUI(searchEntry.Text)
+			})
+		})
 
 		cellFIOEmail := container.NewPadded(container.NewMax(fioEmailCombinedLabel))
 		cellGroup := container.NewPadded(container.NewMax(groupLabel))
 		cellStatus := container.NewPadded(container.NewMax(statusSelect))
 		cellDelete := container.NewPadded(container.NewMax(deleteButton))
+		cellUnlock := container.NewPadded(container.NewMax(unlockButton))
+		cellPurge := container.NewPadded(container.NewMax(purgeButton))
+		cellImpersonate := container.NewPadded(container.NewMax(impersonateButton))
+		cellMerge := container.NewPadded(container.NewMax(mergeButton))
 
 		verticalCellDivider := canvas.NewRectangle(mediumGrayDivider)
 		verticalCellDivider.SetMinSize(fyne.NewSize(1, 0))
@@ -1439,71 +1602,155 @@ func СreateUsersListPage(state *AppState) fyne.CanvasObject {
 			verticalCellDivider,
 			cellStatus,
 			cellDelete,
+			cellUnlock,
+			cellPurge,
+			cellImpersonate,
+			cellMerge,
 		)
 		return rowContainer
 	}
 
-	updateUsersTableUI = func(searchText string) {
-		tableRowsContainer.RemoveAll()
+	// Фильтры и сортировка выполняются на сервере; страницы подгружаются при прокрутке
+	roleValues := []string{"", "student", "assistant", "seminarist", "lecturer", "superaccount"}
+	roleOptions := []string{"Все роли"}
+	for _, role := range roleValues[1:] {
+		roleOptions = append(roleOptions, roleTitles[role])
+	}
+	roleFilter := widget.NewSelect(roleOptions, nil)
+	roleFilter.SetSelectedIndex(0)
+
+	groupOptions := []string{"Все группы"}
+	groupIDs := []int32{0}
+	disciplineOptions := []string{"Все дисциплины"}
+	disciplineIDs := []int32{0}
+	if conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state)); err != nil {
+		log.Printf("Failed to connect to superaccservice: %v", err)
+	} else {
+		client := superaccpb.NewSuperAccServiceClient(conn)
+		if resp, err := client.ListGroups(context.Background(), &superaccpb.ListGroupsRequest{}); err != nil {
+			log.Printf("Failed to list groups: %v", err)
+		} else {
+			for _, g := range resp.Groups {
+				groupOptions = append(groupOptions, g.Name)
+				groupIDs = append(groupIDs, g.Id)
+			}
+		}
+		if resp, err := client.ListDisciplines(context.Background(), &superaccpb.ListDisciplinesRequest{}); err != nil {
+			log.Printf("Failed to list disciplines: %v", err)
+		} else {
+			for _, d := range resp.Disciplines {
+				disciplineOptions = append(disciplineOptions, d.Name)
+				disciplineIDs = append(disciplineIDs, d.Id)
+			}
+		}
+		conn.Close()
+	}
+	groupFilter := widget.NewSelect(groupOptions, nil)
+	groupFilter.SetSelectedIndex(0)
+	disciplineFilter := widget.NewSelect(disciplineOptions, nil)
+	disciplineFilter.SetSelectedIndex(0)
+
+	sortValues := []string{"fio", "email", "role"}
+	sortSelect := widget.NewSelect([]string{"по ФИО", "по почте", "по роли"}, nil)
+	sortSelect.SetSelectedIndex(0)
+	descendingCheck := widget.NewCheck("по убыванию", nil)
+	countLabel := widget.NewLabel("")
+
+	var nextCursor string
+	var loading bool
+	loadUsersPage := func(reset bool) {
+		if loading {
+			return
+		}
+		loading = true
+		defer func() { loading = false }()
 
-		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure())
+		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
 		if err != nil {
 			log.Printf("Failed to connect to superaccservice: %v", err)
 			return
 		}
 		defer conn.Close()
 
+		req := &superaccpb.ListAllUsersRequest{
+			Search:       searchEntry.Text,
+			GroupId:      groupIDs[max(groupFilter.SelectedIndex(), 0)],
+			DisciplineId: disciplineIDs[max(disciplineFilter.SelectedIndex(), 0)],
+			Sort:         sortValues[max(sortSelect.SelectedIndex(), 0)],
+			Descending:   descendingCheck.Checked,
+		}
+		if role := roleValues[max(roleFilter.SelectedIndex(), 0)]; role != "" {
+			req.Roles = []string{role}
+		}
+		if !reset {
+			req.Cursor = nextCursor
+		}
 		client := superaccpb.NewSuperAccServiceClient(conn)
-		resp, err := client.ListAllUsers(context.Background(), &superaccpb.ListAllUsersRequest{})
+		resp, err := client.ListAllUsers(context.Background(), req)
 		if err != nil {
 			log.Printf("Failed to list all users: %v", err)
 			return
 		}
+		if !resp.Success {
+			log.Printf("ListAllUsers failed: %s", resp.Message)
+			return
+		}
 
-		currentDisplayedUsers = []*User{}
-		if searchText == "" {
-			currentDisplayedUsers = make([]*User, len(resp.Users))
-			for i, user := range resp.Users {
-				currentDisplayedUsers[i] = &User{
-					ID:     int(user.Id),
-					FIO:    user.Fio,
-					Email:  user.Email,
-					Group:  user.Group,
-					Status: user.Status,
-				}
-			}
-		} else {
-			lowerSearchText := strings.ToLower(searchText)
-			for _, user := range resp.Users {
-				if strings.Contains(strings.ToLower(user.Fio), lowerSearchText) ||
-					strings.Contains(strings.ToLower(user.Email), lowerSearchText) {
-					currentDisplayedUsers = append(currentDisplayedUsers, &User{
-						ID:     int(user.Id),
-						FIO:    user.Fio,
-						Email:  user.Email,
-						Group:  user.Group,
-						Status: user.Status,
-					})
-				}
+		if reset {
+			tableRowsContainer.RemoveAll()
+			currentDisplayedUsers = nil
+		}
+		for _, user := range resp.Users {
+			u := &User{
+				ID:     int(user.Id),
+				FIO:    user.Fio,
+				Email:  user.Email,
+				Group:  user.Group,
+				Status: user.Status,
+				Active: user.Active,
 			}
+			tableRowsContainer.Add(createUserTableRow(u, len(currentDisplayedUsers)))
+			tableRowsContainer.Add(canvas.NewRectangle(lightGrayDivider))
+			currentDisplayedUsers = append(currentDisplayedUsers, u)
 		}
+		nextCursor = resp.NextCursor
+		countLabel.SetText(fmt.Sprintf("Показано %d из %d", len(currentDisplayedUsers), resp.TotalCount))
 
 		if len(currentDisplayedUsers) == 0 {
 			tableRowsContainer.Add(container.NewCenter(widget.NewLabel("Нет пользователей для отображения по заданным критериям.")))
-		} else {
-			for i, user := range currentDisplayedUsers {
-				tableRowsContainer.Add(createUserTableRow(user, i))
-				tableRowsContainer.Add(canvas.NewRectangle(lightGrayDivider))
-			}
 		}
 		tableRowsContainer.Refresh()
-		scrollableTable.ScrollToTop()
+		if reset {
+			scrollableTable.ScrollToTop()
+		}
+	}
+
+	updateUsersTableUI = func(string) {
+		loadUsersPage(true)
+	}
+	roleFilter.OnChanged = func(string) { updateUsersTableUI(searchEntry.Text) }
+	groupFilter.OnChanged = func(string) { updateUsersTableUI(searchEntry.Text) }
+	disciplineFilter.OnChanged = func(string) { updateUsersTableUI(searchEntry.Text) }
+	sortSelect.OnChanged = func(string) { updateUsersTableUI(searchEntry.Text) }
+	descendingCheck.OnChanged = func(bool) { updateUsersTableUI(searchEntry.Text) }
+
+	// Следующая страница подгружается, когда до конца таблицы остаётся меньше экрана
+	scrollableTable.OnScrolled = func(offset fyne.Position) {
+		if nextCursor == "" || loading {
+			return
+		}
+		if offset.Y+2*scrollableTable.Size().Height >= tableRowsContainer.MinSize().Height {
+			loadUsersPage(false)
+		}
 	}
 
 	updateUsersTableUI("")
 
+	filtersBox := container.NewHBox(roleFilter, groupFilter, disciplineFilter, sortSelect, descendingCheck, layout.NewSpacer(), countLabel)
+
 	centralContentPanel := container.NewVBox(
 		container.NewPadded(searchBox),
+		container.NewPadded(filtersBox),
 		columnHeadersContainer,
 		scrollableTable,
 	)
@@ -1519,3 +1766,177 @@ func СreateUsersListPage(state *AppState) fyne.CanvasObject {
 		centralContentWithBackground,
 	)
 }
+
+//*************************
+//* Page with invitations *
+//*************************
+
+var invitationStatusNames = map[string]string{
+	"pending":  "ожидает",
+	"accepted": "принято",
+	"expired":  "истекло",
+	"revoked":  "отозвано",
+}
+
+func CreateInvitationsPage(state *AppState) fyne.CanvasObject {
+	w := state.window
+	headerTextColor := color.White
+	darkBlue := color.NRGBA{R: 20, G: 40, B: 80, A: 255}
+
+	headerTitle := canvas.NewText("Приглашения", headerTextColor)
+	headerTitle.TextStyle.Bold = true
+	headerTitle.TextSize = 20
+	headerTitle.Alignment = fyne.TextAlignCenter
+
+	backButton := widget.NewButton("Назад", func() {
+		state.currentPage = "superacc-groups"
+		w.SetContent(createContent(state))
+	})
+	header := container.NewBorder(nil, nil, backButton, nil, container.NewCenter(headerTitle))
+
+	rows := container.NewVBox()
+	pendingOnly := widget.NewCheck("Только ожидающие", nil)
+
+	var reload func()
+	call := func(f func(client superaccpb.SuperAccServiceClient) (string, bool, error)) {
+		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+		if err != nil {
+			log.Printf("Failed to connect to superaccservice: %v", err)
+			return
+		}
+		defer conn.Close()
+		message, ok, err := f(superaccpb.NewSuperAccServiceClient(conn))
+		if err != nil {
+			log.Printf("Superacc call failed: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		if !ok {
+			dialog.ShowInformation("Ошибка", message, w)
+			return
+		}
+		dialog.ShowInformation("Готово", message, w)
+		reload()
+	}
+
+	reload = func() {
+		rows.RemoveAll()
+		conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+		if err != nil {
+			log.Printf("Failed to connect to superaccservice: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		client := superaccpb.NewSuperAccServiceClient(conn)
+		resp, err := client.ListInvitations(context.Background(), &superaccpb.ListInvitationsRequest{PendingOnly: pendingOnly.Checked})
+		if err != nil {
+			log.Printf("Failed to list invitations: %v", err)
+			return
+		}
+		if !resp.Success {
+			log.Printf("ListInvitations failed: %s", resp.Message)
+			return
+		}
+		if len(resp.Invitations) == 0 {
+			rows.Add(container.NewCenter(widget.NewLabel("Приглашений нет")))
+		}
+		for _, inv := range resp.Invitations {
+			inv := inv
+			expiresAt := inv.ExpiresAt
+			if t, err := time.Parse(time.RFC3339, inv.ExpiresAt); err == nil {
+				expiresAt = t.Local().Format("02.01.2006 15:04")
+			}
+			info := widget.NewLabel(fmt.Sprintf("%s, %s (%s) — %s, до %s",
+				inv.Fio, inv.Email, inv.Role, invitationStatusNames[inv.Status], expiresAt))
+			info.Wrapping = fyne.TextWrapWord
+
+			resendButton := widget.NewButton("Отправить заново", func() {
+				call(func(client superaccpb.SuperAccServiceClient) (string, bool, error) {
+					resp, err := client.ResendInvitation(context.Background(), &superaccpb.ResendInvitationRequest{UserId: inv.UserId})
+					if err != nil {
+						return "", false, err
+					}
+					return resp.Message, resp.Success, nil
+				})
+			})
+			revokeButton := widget.NewButton("Отозвать", func() {
+				dialog.ShowConfirm("Подтверждение", fmt.Sprintf("Отозвать приглашение для %s?", inv.Email), func(ok bool) {
+					if !ok {
+						return
+					}
+					call(func(client superaccpb.SuperAccServiceClient) (string, bool, error) {
+						resp, err := client.RevokeInvitation(context.Background(), &superaccpb.RevokeInvitationRequest{InvitationId: inv.Id})
+						if err != nil {
+							return "", false, err
+						}
+						return resp.Message, resp.Success, nil
+					})
+				}, w)
+			})
+			if inv.Status == "accepted" {
+				resendButton.Disable()
+			}
+			if inv.Status != "pending" {
+				revokeButton.Disable()
+			}
+			rows.Add(container.NewBorder(nil, nil, nil, container.NewHBox(resendButton, revokeButton), info))
+		}
+		rows.Refresh()
+	}
+	pendingOnly.OnChanged = func(bool) { reload() }
+
+	inviteButton := widget.NewButton("Пригласить пользователя", func() {
+		fioEntry := widget.NewEntry()
+		fioEntry.SetPlaceHolder("Фамилия Имя Отчество")
+		emailEntry := widget.NewEntry()
+		groupEntry := widget.NewEntry()
+		groupEntry.SetPlaceHolder("необязательно")
+		roleSelect := widget.NewSelect([]string{"student", "assistant", "seminarist", "lecturer", "superaccount"}, nil)
+		roleSelect.SetSelected("student")
+
+		dialog.ShowForm("Пригласить пользователя", "Пригласить", "Отмена",
+			[]*widget.FormItem{
+				widget.NewFormItem("ФИО", fioEntry),
+				widget.NewFormItem("Почта", emailEntry),
+				widget.NewFormItem("Группа", groupEntry),
+				widget.NewFormItem("Роль", roleSelect),
+			},
+			func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				call(func(client superaccpb.SuperAccServiceClient) (string, bool, error) {
+					resp, err := client.AddUser(context.Background(), &superaccpb.AddUserRequest{
+						Fio:    fioEntry.Text,
+						Email:  emailEntry.Text,
+						Group:  groupEntry.Text,
+						Status: userRole(roleSelect.Selected),
+					})
+					if err != nil {
+						return "", false, err
+					}
+					return resp.Message, resp.Success, nil
+				})
+			}, w)
+	})
+
+	importButton := widget.NewButton("Импорт из CSV", func() {
+		showImportUsersDialog(state, reload)
+	})
+
+	reload()
+
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(0, 450))
+
+	content := container.NewStack(
+		canvas.NewRectangle(color.White),
+		container.NewPadded(container.NewBorder(pendingOnly, container.NewGridWithColumns(2, inviteButton, importButton), nil, nil, scroll)),
+	)
+
+	return container.NewStack(
+		canvas.NewRectangle(darkBlue),
+		container.NewBorder(container.NewPadded(header), nil, nil, nil, content),
+	)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"log"
+	"time"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// termStates — состояния семестра в порядке жизненного цикла и их подписи
+var termStates = []string{"upcoming", "active", "archived"}
+
+var termStateNames = map[string]string{
+	"upcoming": "Будущий",
+	"active":   "Текущий",
+	"archived": "Архив",
+}
+
+func listTerms(ctx context.Context, client superaccpb.SuperAccServiceClient) ([]*superaccpb.Term, error) {
+	resp, err := client.ListTerms(ctx, &superaccpb.ListTermsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, errors.New(resp.Message)
+	}
+	return resp.Terms, nil
+}
+
+// termSelect — выпадающий список семестров, в которые можно добавлять дисциплины;
+// по умолчанию выбран текущий. Возвращает id выбранного семестра
+func termSelect(terms []*superaccpb.Term) (*widget.Select, func() int32) {
+	var options []string
+	var ids []int32
+	selected := -1
+	for _, t := range terms {
+		if t.State == "archived" {
+			continue
+		}
+		if t.State == "active" {
+			selected = len(options)
+		}
+		options = append(options, fmt.Sprintf("%s (%s)", t.Name, termStateNames[t.State]))
+		ids = append(ids, t.Id)
+	}
+	sel := widget.NewSelect(options, nil)
+	if selected >= 0 {
+		sel.SetSelectedIndex(selected)
+	}
+	return sel, func() int32 {
+		if i := sel.SelectedIndex(); i >= 0 {
+			return ids[i]
+		}
+		return 0
+	}
+}
+
+// showTermsDialog — список семестров со сменой состояния и создание нового семестра
+func showTermsDialog(state *AppState) {
+	w := state.window
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		log.Printf("Failed to connect to superaccservice: %v", err)
+		dialog.ShowInformation("Ошибка", "Не удалось подключиться к серверу", w)
+		return
+	}
+	client := superaccpb.NewSuperAccServiceClient(conn)
+
+	rows := container.NewVBox()
+	var reload func()
+	reload = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		terms, err := listTerms(ctx, client)
+		if err != nil {
+			log.Printf("Failed to list terms: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		rows.RemoveAll()
+		if len(terms) == 0 {
+			rows.Add(widget.NewLabel("Семестров пока нет"))
+		}
+		var stateOptions []string
+		for _, s := range termStates {
+			stateOptions = append(stateOptions, termStateNames[s])
+		}
+		for _, t := range terms {
+			t := t
+			stateSelect := widget.NewSelect(stateOptions, nil)
+			stateSelect.SetSelected(termStateNames[t.State])
+			stateSelect.OnChanged = func(string) {
+				newState := termStates[stateSelect.SelectedIndex()]
+				if newState == t.State {
+					return
+				}
+				ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+				defer cancel()
+				resp, err := client.SetTermState(ctx, &superaccpb.SetTermStateRequest{TermId: t.Id, State: newState})
+				if err == nil && !resp.Success {
+					err = errors.New(resp.Message)
+				}
+				if err != nil {
+					log.Printf("Failed to set state of term %d: %v", t.Id, err)
+					dialog.ShowError(err, w)
+				}
+				reload()
+			}
+			rows.Add(container.NewBorder(nil, nil,
+				widget.NewLabel(fmt.Sprintf("%s: %s — %s", t.Name, t.StartsOn, t.EndsOn)), nil,
+				stateSelect))
+		}
+		rows.Refresh()
+	}
+	reload()
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Осень 2026")
+	startsEntry := widget.NewEntry()
+	startsEntry.SetPlaceHolder("ГГГГ-ММ-ДД")
+	endsEntry := widget.NewEntry()
+	endsEntry.SetPlaceHolder("ГГГГ-ММ-ДД")
+	createButton := widget.NewButton("Создать семестр", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		resp, err := client.CreateTerm(ctx, &superaccpb.CreateTermRequest{
+			Name:     nameEntry.Text,
+			StartsOn: startsEntry.Text,
+			EndsOn:   endsEntry.Text,
+		})
+		if err == nil && !resp.Success {
+			err = errors.New(resp.Message)
+		}
+		if err != nil {
+			log.Printf("Failed to create term: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		nameEntry.SetText("")
+		startsEntry.SetText("")
+		endsEntry.SetText("")
+		reload()
+	})
+
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(500, 250))
+	content := container.NewVBox(
+		widget.NewLabel("Текущим может быть только один семестр; архивный доступен только для чтения."),
+		scroll,
+		widget.NewSeparator(),
+		widget.NewForm(
+			widget.NewFormItem("Название", nameEntry),
+			widget.NewFormItem("Начало", startsEntry),
+			widget.NewFormItem("Конец", endsEntry),
+		),
+		createButton,
+	)
+	d := dialog.NewCustom("Семестры", "Закрыть", content, w)
+	d.SetOnClosed(func() { conn.Close() })
+	d.Show()
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"io"
+	"log"
+	"time"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// importChunkSize — размер части CSV в одном сообщении потока
+const importChunkSize = 32 * 1024
+
+// importStatusNames — подписи статусов строк отчёта импорта
+var importStatusNames = map[string]string{
+	"created":           "будет создан",
+	"already_exists":    "уже существует",
+	"duplicate_in_file": "повтор в файле",
+	"invalid_group":     "нет такой группы",
+	"invalid_role":      "неизвестная роль",
+	"invalid_email":     "неверная почта",
+	"malformed_fio":     "неверное ФИО",
+	"malformed_row":     "неверная строка",
+	"rolled_back":       "не создан, импорт отменён",
+}
+
+// importUsers отправляет CSV в ImportUsers частями
+func importUsers(state *AppState, data []byte, dryRun bool) (*superaccpb.ImportUsersResponse, error) {
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	stream, err := superaccpb.NewSuperAccServiceClient(conn).ImportUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = stream.Send(&superaccpb.ImportUsersRequest{
+		Payload: &superaccpb.ImportUsersRequest_Options{Options: &superaccpb.ImportUsersOptions{DryRun: dryRun}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(data); start += importChunkSize {
+		end := min(start+importChunkSize, len(data))
+		err := stream.Send(&superaccpb.ImportUsersRequest{
+			Payload: &superaccpb.ImportUsersRequest_CsvChunk{CsvChunk: data[start:end]},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// importReport показывает отчёт по строкам импорта
+func importReport(resp *superaccpb.ImportUsersResponse) fyne.CanvasObject {
+	rows := container.NewVBox(widget.NewLabel(resp.Message))
+	for _, result := range resp.Results {
+		status, ok := importStatusNames[result.Status]
+		if !ok {
+			status = result.Status
+		}
+		if result.Status == "created" && resp.Committed {
+			status = "создан"
+		}
+		text := fmt.Sprintf("Строка %d, %s: %s", result.Line, result.Email, status)
+		if result.Message != "" && (result.Status != "created" || resp.Committed) {
+			text += " (" + result.Message + ")"
+		}
+		label := widget.NewLabel(text)
+		label.Wrapping = fyne.TextWrapWord
+		rows.Add(label)
+	}
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(600, 400))
+	return scroll
+}
+
+// showImportUsersDialog выбирает CSV (fio,email,group,role), показывает результат пробного
+// прогона и после подтверждения импортирует корректные строки
+func showImportUsersDialog(state *AppState, onDone func()) {
+	w := state.window
+	open := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		defer reader.Close()
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			log.Printf("Failed to read CSV file: %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+
+		resp, err := importUsers(state, data, true)
+		if err != nil {
+			log.Printf("Failed to import users (dry run): %v", err)
+			dialog.ShowError(err, w)
+			return
+		}
+		if !resp.Success || resp.Created == 0 {
+			dialog.ShowCustom("Проверка импорта", "Закрыть", importReport(resp), w)
+			return
+		}
+		dialog.ShowCustomConfirm("Проверка импорта", fmt.Sprintf("Создать %d", resp.Created), "Отмена",
+			importReport(resp), func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				resp, err := importUsers(state, data, false)
+				if err != nil {
+					log.Printf("Failed to import users: %v", err)
+					dialog.ShowError(err, w)
+					return
+				}
+				dialog.ShowCustom("Импорт пользователей", "Закрыть", importReport(resp), w)
+				if resp.Committed {
+					onDone()
+				}
+			}, w)
+	}, w)
+	open.SetFilter(storage.NewExtensionFileFilter([]string{".csv"}))
+	open.Show()
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// listAllUserPages проходит по всем страницам ListAllUsers; для выпадающих списков,
+// где фильтр по роли оставляет немного пользователей
+func listAllUserPages(ctx context.Context, client superaccpb.SuperAccServiceClient, req *superaccpb.ListAllUsersRequest) ([]*superaccpb.User, error) {
+	var users []*superaccpb.User
+	for {
+		resp, err := client.ListAllUsers(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, errors.New(resp.Message)
+		}
+		users = append(users, resp.Users...)
+		if resp.NextCursor == "" {
+			return users, nil
+		}
+		req.Cursor = resp.NextCursor
+	}
+}
+
+// listGroupUsers возвращает всех участников группы, проходя по страницам ListUsersByGroup
+func listGroupUsers(ctx context.Context, client superaccpb.SuperAccServiceClient, groupName string) ([]*superaccpb.User, error) {
+	req := &superaccpb.ListUsersByGroupRequest{GroupName: groupName, PageSize: 200}
+	var users []*superaccpb.User
+	for {
+		resp, err := client.ListUsersByGroup(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, errors.New(resp.Message)
+		}
+		users = append(users, resp.Users...)
+		if resp.NextCursor == "" {
+			return users, nil
+		}
+		req.Cursor = resp.NextCursor
+	}
+}
+
+// findUserID ищет id пользователя по точной почте
+func findUserID(ctx context.Context, client superaccpb.SuperAccServiceClient, email string) (int32, error) {
+	users, err := listAllUserPages(ctx, client, &superaccpb.ListAllUsersRequest{Search: email, PageSize: 200})
+	if err != nil {
+		return 0, err
+	}
+	for _, u := range users {
+		if strings.EqualFold(u.Email, email) {
+			return u.Id, nil
+		}
+	}
+	return 0, fmt.Errorf("user with email %s not found", email)
+}
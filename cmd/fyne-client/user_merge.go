@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"google.golang.org/grpc"
+	"log"
+	"strings"
+	"time"
+
+	superaccpb "rubr/proto/superacc"
+)
+
+// mergePolicies — политики разрешения конфликтов работ и их подписи
+var mergePolicies = []superaccpb.MergeConflictPolicy{
+	superaccpb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_TARGET,
+	superaccpb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_SOURCE,
+	superaccpb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_LATEST,
+}
+
+var mergePolicyNames = []string{
+	"Оставить работу основной записи",
+	"Оставить работу объединяемой записи",
+	"Оставить более позднюю сдачу",
+}
+
+func mergeUsers(state *AppState, req *superaccpb.MergeUsersRequest) (*superaccpb.MergeUsersResponse, error) {
+	conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	return superaccpb.NewSuperAccServiceClient(conn).MergeUsers(ctx, req)
+}
+
+// mergeReport показывает, что переедет и какие работы будут отброшены
+func mergeReport(resp *superaccpb.MergeUsersResponse) fyne.CanvasObject {
+	rows := container.NewVBox(widget.NewLabel(resp.Message))
+	if resp.Success {
+		rows.Add(widget.NewLabel(fmt.Sprintf(
+			"Работ: %d, оценок: %d, групп: %d, уведомлений: %d, назначений преподавателем: %d, отброшено работ: %d",
+			resp.WorksMoved, resp.MarksMoved, resp.GroupsMoved, resp.NotificationsMoved, resp.StaffAssignmentsMoved, resp.WorksDiscarded)))
+	}
+	for _, c := range resp.Conflicts {
+		text := fmt.Sprintf("Задание «%s»: работы есть у обеих записей", c.TaskTitle)
+		if len(c.DiscardedWorkIds) > 0 {
+			text += fmt.Sprintf(", будут удалены работы %v", c.DiscardedWorkIds)
+		}
+		label := widget.NewLabel(text)
+		label.Wrapping = fyne.TextWrapWord
+		rows.Add(label)
+	}
+	scroll := container.NewVScroll(rows)
+	scroll.SetMinSize(fyne.NewSize(600, 300))
+	return scroll
+}
+
+// showMergeUsersDialog переносит историю пользователя source в учётную запись с указанной
+// почтой: сначала показывает пробный прогон, после подтверждения объединяет и
+// деактивирует source
+func showMergeUsersDialog(state *AppState, sourceID int32, sourceEmail string, onDone func()) {
+	w := state.window
+	targetEntry := widget.NewEntry()
+	targetEntry.SetPlaceHolder("почта основной учётной записи")
+	policySelect := widget.NewSelect(mergePolicyNames, nil)
+	policySelect.SetSelectedIndex(0)
+
+	dialog.ShowForm("Объединение учётных записей", "Проверить", "Отмена",
+		[]*widget.FormItem{
+			widget.NewFormItem("", widget.NewLabel(fmt.Sprintf("Работы, оценки, группы и уведомления %s перейдут в основную запись, а %s будет деактивирован.", sourceEmail, sourceEmail))),
+			widget.NewFormItem("Основная запись", targetEntry),
+			widget.NewFormItem("Если работы по заданию есть у обеих", policySelect),
+		},
+		func(confirmed bool) {
+			if !confirmed {
+				return
+			}
+			conn, err := grpc.Dial("89.169.39.161:50052", grpc.WithInsecure(), withAuth(state))
+			if err != nil {
+				log.Printf("Failed to connect to superaccservice: %v", err)
+				dialog.ShowError(err, w)
+				return
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			targetID, err := findUserID(ctx, superaccpb.NewSuperAccServiceClient(conn), strings.TrimSpace(targetEntry.Text))
+			cancel()
+			conn.Close()
+			if err != nil {
+				dialog.ShowError(err, w)
+				return
+			}
+
+			req := &superaccpb.MergeUsersRequest{
+				SourceUserId:   sourceID,
+				TargetUserId:   targetID,
+				ConflictPolicy: mergePolicies[policySelect.SelectedIndex()],
+				DryRun:         true,
+			}
+			resp, err := mergeUsers(state, req)
+			if err != nil {
+				log.Printf("Failed to merge users (dry run): %v", err)
+				dialog.ShowError(err, w)
+				return
+			}
+			if !resp.Success {
+				dialog.ShowCustom("Объединение учётных записей", "Закрыть", mergeReport(resp), w)
+				return
+			}
+			dialog.ShowCustomConfirm("Объединение учётных записей", "Объединить", "Отмена", mergeReport(resp), func(confirmed bool) {
+				if !confirmed {
+					return
+				}
+				req.DryRun = false
+				resp, err := mergeUsers(state, req)
+				if err != nil {
+					log.Printf("Failed to merge user %d into %d: %v", sourceID, targetID, err)
+					dialog.ShowError(err, w)
+					return
+				}
+				dialog.ShowCustom("Объединение учётных записей", "Закрыть", mergeReport(resp), w)
+				if resp.Applied {
+					onDone()
+				}
+			}, w)
+		}, w)
+}
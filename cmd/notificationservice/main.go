@@ -8,6 +8,7 @@ import (
 	"log"
 	"net"
 	"os"
+	"rubr/internal/auth"
 	"rubr/internal/notificationservice"
 	pb "rubr/proto/notification"
 	"strconv"
@@ -40,7 +41,17 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
+	keys, err := auth.KeySetFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	tokens := auth.NewTokenManager(keys, auth.AccessTokenTTL)
+	authenticator := auth.NewAuthenticator(tokens, db)
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(authenticator.UnaryInterceptor()),
+		grpc.StreamInterceptor(authenticator.StreamInterceptor()),
+	)
 	pb.RegisterNotificationServiceServer(s, &notificationservice.Server{Db: db})
 
 	log.Println("UserService starting on :50056")
@@ -8,6 +8,7 @@ import (
 	"log"
 	"net"
 	"os"
+	"rubr/internal/auth"
 	"rubr/internal/rubricservice"
 	Pb "rubr/proto/rubric"
 	"strconv"
@@ -41,7 +42,17 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
+	keys, err := auth.KeySetFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	tokens := auth.NewTokenManager(keys, auth.AccessTokenTTL)
+	authenticator := auth.NewAuthenticator(tokens, db)
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(authenticator.UnaryInterceptor()),
+		grpc.StreamInterceptor(authenticator.StreamInterceptor()),
+	)
 	Pb.RegisterRubricServiceServer(s, &rubricservice.Server{Db: db})
 
 	log.Println("RubricService starting on :50055")
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	pb "rubr/proto/superacc"
+)
+
+const (
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 200
+)
+
+// auditFilter — параметры одной страницы журнала изменений
+type auditFilter struct {
+	actorID  int32
+	method   string
+	entity   string
+	targetID string
+	since    time.Time
+	until    time.Time
+	pageSize int
+	beforeID int64
+}
+
+func newAuditFilter(req *pb.QueryAuditLogRequest) (auditFilter, error) {
+	f := auditFilter{
+		actorID:  req.ActorId,
+		method:   strings.TrimSpace(req.Method),
+		entity:   strings.TrimSpace(req.Entity),
+		targetID: strings.TrimSpace(req.TargetId),
+		pageSize: int(req.PageSize),
+		beforeID: req.BeforeId,
+	}
+	if f.pageSize <= 0 {
+		f.pageSize = defaultAuditPageSize
+	}
+	if f.pageSize > maxAuditPageSize {
+		f.pageSize = maxAuditPageSize
+	}
+	var err error
+	if req.Since != "" {
+		if f.since, err = time.Parse(time.RFC3339, req.Since); err != nil {
+			return f, fmt.Errorf("since must be RFC3339")
+		}
+	}
+	if req.Until != "" {
+		if f.until, err = time.Parse(time.RFC3339, req.Until); err != nil {
+			return f, fmt.Errorf("until must be RFC3339")
+		}
+	}
+	return f, nil
+}
+
+// QueryAuditLog возвращает записи журнала от новых к старым и id, с которого
+// начинается следующая страница
+func (r *Repository) QueryAuditLog(ctx context.Context, f auditFilter) ([]*pb.AuditEntry, int64, error) {
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where := []string{"TRUE"}
+	if f.actorID != 0 {
+		where = append(where, "a.actor_id = "+arg(f.actorID))
+	}
+	if f.method != "" {
+		where = append(where, "a.method ILIKE "+arg("%"+escapeLike(f.method)+"%"))
+	}
+	if f.entity != "" {
+		where = append(where, "a.entity = "+arg(f.entity))
+	}
+	if f.targetID != "" {
+		where = append(where, "a.target_ids @> "+arg(pq.Array([]string{f.targetID})))
+	}
+	if !f.since.IsZero() {
+		where = append(where, "a.created_at >= "+arg(f.since))
+	}
+	if !f.until.IsZero() {
+		where = append(where, "a.created_at < "+arg(f.until))
+	}
+	if f.beforeID > 0 {
+		where = append(where, "a.id < "+arg(f.beforeID))
+	}
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT a.id, a.actor_id, COALESCE(u.email, ''), a.actor_role, COALESCE(a.impersonator_id, 0),
+		       a.method, a.entity, a.target_ids, a.before, a.after, a.succeeded, a.status_code, a.created_at
+		FROM audit_log a
+		LEFT JOIN users u ON u.id = a.actor_id
+		WHERE %s
+		ORDER BY a.id DESC
+		LIMIT %s`, strings.Join(where, " AND "), arg(f.pageSize+1)), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var entries []*pb.AuditEntry
+	var nextBeforeID int64
+	for rows.Next() {
+		if len(entries) == f.pageSize {
+			nextBeforeID = entries[len(entries)-1].Id
+			break
+		}
+		e := &pb.AuditEntry{}
+		var targets pq.StringArray
+		var before, after sql.NullString
+		var createdAt time.Time
+		if err := rows.Scan(&e.Id, &e.ActorId, &e.ActorEmail, &e.ActorRole, &e.ImpersonatorId,
+			&e.Method, &e.Entity, &targets, &before, &after, &e.Succeeded, &e.StatusCode, &createdAt); err != nil {
+			return nil, 0, err
+		}
+		e.TargetIds = targets
+		e.BeforeJson = before.String
+		e.AfterJson = after.String
+		e.CreatedAt = createdAt.Format(time.RFC3339)
+		entries = append(entries, e)
+	}
+	return entries, nextBeforeID, rows.Err()
+}
+
+func (s *Service) QueryAuditLog(ctx context.Context, req *pb.QueryAuditLogRequest) (*pb.QueryAuditLogResponse, error) {
+	filter, err := newAuditFilter(req)
+	if err != nil {
+		return &pb.QueryAuditLogResponse{Message: err.Error(), Success: false}, nil
+	}
+	entries, nextBeforeID, err := s.repo.QueryAuditLog(ctx, filter)
+	if err != nil {
+		log.Printf("Failed to query audit log: %v", err)
+		return &pb.QueryAuditLogResponse{Message: "failed to query audit log", Success: false}, nil
+	}
+	return &pb.QueryAuditLogResponse{Success: true, Entries: entries, NextBeforeId: nextBeforeID}, nil
+}
@@ -0,0 +1,997 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"rubr/internal/auth"
+	pb "rubr/proto/superacc"
+)
+
+// Форматы выгрузки справочника
+const (
+	directoryCSV  = "csv"
+	directoryJSON = "json"
+)
+
+const (
+	directoryVersion   = 4                // 4: у дисциплины владелец, соавторы и лекторы только на чтение
+	directoryCSVMarker = "rubr-directory" // первая запись CSV: маркер, версия, время выгрузки
+	directoryChunkSize = 32 * 1024
+	maxDirectorySize   = 64 << 20
+)
+
+// directoryCSVColumns — число колонок каждой записи CSV вместе с её типом
+var directoryCSVColumns = map[string]int{
+	"user":       7, // user, email, surname, name, patronymic, role, active
+	"group":      3, // group, name, description
+	"member":     3, // member, email, group
+	"term":       5, // term, name, starts_on, ends_on, state
+	"discipline": 6, // discipline, term, name, owner_email, co_lecturer_emails, read_only_emails
+	"assignment": 6, // assignment, term, discipline, group, seminarist_emails, assistant_emails
+}
+
+// directoryListSep разделяет несколько адресов в одной колонке CSV
+const directoryListSep = ";"
+
+type directoryUser struct {
+	Email      string `json:"email"`
+	Surname    string `json:"surname"`
+	Name       string `json:"name"`
+	Patronymic string `json:"patronymic,omitempty"`
+	Role       string `json:"role"`
+	Active     bool   `json:"active"`
+}
+
+type directoryGroup struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type directoryMember struct {
+	Email string `json:"email"`
+	Group string `json:"group"`
+}
+
+type directoryTerm struct {
+	Name     string `json:"name"`
+	StartsOn string `json:"starts_on"`
+	EndsOn   string `json:"ends_on"`
+	State    string `json:"state"`
+}
+
+type directoryDiscipline struct {
+	Term        string   `json:"term"`
+	Name        string   `json:"name"`
+	OwnerEmail  string   `json:"owner_email,omitempty"`
+	CoLecturers []string `json:"co_lecturers,omitempty"`
+	ReadOnly    []string `json:"read_only,omitempty"`
+}
+
+// lecturers возвращает лекторов дисциплины с ролями, владельца первым
+func (d directoryDiscipline) lecturers() (emails, roles []string) {
+	if d.OwnerEmail != "" {
+		emails, roles = append(emails, d.OwnerEmail), append(roles, auth.LecturerOwner)
+	}
+	for _, e := range d.CoLecturers {
+		emails, roles = append(emails, e), append(roles, auth.LecturerCo)
+	}
+	for _, e := range d.ReadOnly {
+		emails, roles = append(emails, e), append(roles, auth.LecturerReadOnly)
+	}
+	return emails, roles
+}
+
+func (d directoryDiscipline) lecturerEmails() []string {
+	emails, _ := d.lecturers()
+	return emails
+}
+
+type directoryAssignment struct {
+	Term        string   `json:"term"`
+	Discipline  string   `json:"discipline"`
+	Group       string   `json:"group"`
+	Seminarists []string `json:"seminarists,omitempty"`
+	Assistants  []string `json:"assistants,omitempty"`
+}
+
+// splitEmails разбирает колонку CSV со списком адресов
+func splitEmails(s string) []string {
+	var emails []string
+	for _, e := range strings.Split(s, directoryListSep) {
+		if e = strings.TrimSpace(e); e != "" {
+			emails = append(emails, e)
+		}
+	}
+	return emails
+}
+
+// directory — снимок справочника; записи связаны почтой и названиями, а не id
+type directory struct {
+	Version     int                   `json:"version"`
+	ExportedAt  string                `json:"exported_at"`
+	Users       []directoryUser       `json:"users"`
+	Groups      []directoryGroup      `json:"groups"`
+	Members     []directoryMember     `json:"members"`
+	Terms       []directoryTerm       `json:"terms"`
+	Disciplines []directoryDiscipline `json:"disciplines"`
+	Assignments []directoryAssignment `json:"assignments"`
+}
+
+func validDirectoryFormat(format string) bool {
+	return format == directoryCSV || format == directoryJSON
+}
+
+// queryEach выполняет запрос и вызывает scan для каждой строки
+func queryEach(ctx context.Context, tx *sql.Tx, query string, scan func(rows *sql.Rows) error) error {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// ExportDirectory читает справочник в одной транзакции REPEATABLE READ, чтобы все таблицы
+// попали в выгрузку в согласованном состоянии
+func (r *Repository) ExportDirectory(ctx context.Context) (*directory, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	dir := &directory{Version: directoryVersion, ExportedAt: time.Now().UTC().Format(time.RFC3339)}
+	err = queryEach(ctx, tx, `
+		SELECT email, surname, name, COALESCE(patronymic, ''), role, deactivated_at IS NULL
+		FROM users ORDER BY email`, func(rows *sql.Rows) error {
+		var u directoryUser
+		if err := rows.Scan(&u.Email, &u.Surname, &u.Name, &u.Patronymic, &u.Role, &u.Active); err != nil {
+			return err
+		}
+		dir.Users = append(dir.Users, u)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("users: %w", err)
+	}
+	err = queryEach(ctx, tx, `
+		SELECT name, COALESCE(description, '') FROM student_groups ORDER BY name, id`, func(rows *sql.Rows) error {
+		var g directoryGroup
+		if err := rows.Scan(&g.Name, &g.Description); err != nil {
+			return err
+		}
+		dir.Groups = append(dir.Groups, g)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("groups: %w", err)
+	}
+	err = queryEach(ctx, tx, `
+		SELECT u.email, g.name
+		FROM users_in_groups ug
+		JOIN users u ON u.id = ug.user_id
+		JOIN student_groups g ON g.id = ug.group_id
+		ORDER BY g.name, u.email`, func(rows *sql.Rows) error {
+		var m directoryMember
+		if err := rows.Scan(&m.Email, &m.Group); err != nil {
+			return err
+		}
+		dir.Members = append(dir.Members, m)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("members: %w", err)
+	}
+	err = queryEach(ctx, tx, `
+		SELECT name, to_char(starts_on, 'YYYY-MM-DD'), to_char(ends_on, 'YYYY-MM-DD'), state
+		FROM terms ORDER BY starts_on, name`, func(rows *sql.Rows) error {
+		var t directoryTerm
+		if err := rows.Scan(&t.Name, &t.StartsOn, &t.EndsOn, &t.State); err != nil {
+			return err
+		}
+		dir.Terms = append(dir.Terms, t)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("terms: %w", err)
+	}
+	err = queryEach(ctx, tx, `
+		SELECT tm.name, d.name,
+		       COALESCE(MAX(u.email) FILTER (WHERE dl.role = 'owner'), ''),
+		       COALESCE(array_agg(u.email ORDER BY u.email) FILTER (WHERE dl.role = 'co_lecturer'), '{}'),
+		       COALESCE(array_agg(u.email ORDER BY u.email) FILTER (WHERE dl.role = 'read_only'), '{}')
+		FROM disciplines d
+		JOIN terms tm ON tm.id = d.term_id
+		LEFT JOIN discipline_lecturers dl ON dl.discipline_id = d.id
+		LEFT JOIN users u ON u.id = dl.user_id
+		GROUP BY d.id, tm.starts_on, tm.name, d.name
+		ORDER BY tm.starts_on, d.name, d.id`, func(rows *sql.Rows) error {
+		var d directoryDiscipline
+		if err := rows.Scan(&d.Term, &d.Name, &d.OwnerEmail, pq.Array(&d.CoLecturers), pq.Array(&d.ReadOnly)); err != nil {
+			return err
+		}
+		dir.Disciplines = append(dir.Disciplines, d)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("disciplines: %w", err)
+	}
+	err = queryEach(ctx, tx, `
+		SELECT tm.name, d.name, g.name,
+		       COALESCE(array_agg(u.email ORDER BY u.email) FILTER (WHERE ds.role = 'seminarist'), '{}'),
+		       COALESCE(array_agg(u.email ORDER BY u.email) FILTER (WHERE ds.role = 'assistant'), '{}')
+		FROM groups_in_disciplines gd
+		JOIN disciplines d ON d.id = gd.discipline_id
+		JOIN terms tm ON tm.id = gd.term_id
+		JOIN student_groups g ON g.id = gd.group_id
+		LEFT JOIN discipline_staff ds ON ds.group_discipline_id = gd.id
+		LEFT JOIN users u ON u.id = ds.user_id
+		GROUP BY gd.id, tm.starts_on, tm.name, d.name, g.name
+		ORDER BY tm.starts_on, d.name, g.name`, func(rows *sql.Rows) error {
+		var a directoryAssignment
+		if err := rows.Scan(&a.Term, &a.Discipline, &a.Group, pq.Array(&a.Seminarists), pq.Array(&a.Assistants)); err != nil {
+			return err
+		}
+		dir.Assignments = append(dir.Assignments, a)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("assignments: %w", err)
+	}
+	return dir, tx.Commit()
+}
+
+// encodeDirectory пишет снимок в выбранном формате
+func encodeDirectory(w io.Writer, dir *directory, format string) error {
+	if format == directoryJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(dir)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{directoryCSVMarker, strconv.Itoa(dir.Version), dir.ExportedAt})
+	for _, u := range dir.Users {
+		cw.Write([]string{"user", u.Email, u.Surname, u.Name, u.Patronymic, u.Role, strconv.FormatBool(u.Active)})
+	}
+	for _, g := range dir.Groups {
+		cw.Write([]string{"group", g.Name, g.Description})
+	}
+	for _, m := range dir.Members {
+		cw.Write([]string{"member", m.Email, m.Group})
+	}
+	for _, t := range dir.Terms {
+		cw.Write([]string{"term", t.Name, t.StartsOn, t.EndsOn, t.State})
+	}
+	for _, d := range dir.Disciplines {
+		cw.Write([]string{"discipline", d.Term, d.Name, d.OwnerEmail,
+			strings.Join(d.CoLecturers, directoryListSep), strings.Join(d.ReadOnly, directoryListSep)})
+	}
+	for _, a := range dir.Assignments {
+		cw.Write([]string{"assignment", a.Term, a.Discipline, a.Group,
+			strings.Join(a.Seminarists, directoryListSep), strings.Join(a.Assistants, directoryListSep)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// decodeDirectory читает выгрузку, сделанную encodeDirectory
+func decodeDirectory(r io.Reader, format string) (*directory, error) {
+	var dir directory
+	if format == directoryJSON {
+		if err := json.NewDecoder(r).Decode(&dir); err != nil {
+			return nil, err
+		}
+		if dir.Version != directoryVersion {
+			return nil, fmt.Errorf("unsupported directory version %d", dir.Version)
+		}
+		return &dir, nil
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing %s header: %w", directoryCSVMarker, err)
+	}
+	if len(header) != 3 || header[0] != directoryCSVMarker {
+		return nil, fmt.Errorf("first record must be %s,<version>,<exported_at>", directoryCSVMarker)
+	}
+	if header[1] != strconv.Itoa(directoryVersion) {
+		return nil, fmt.Errorf("unsupported directory version %s", header[1])
+	}
+	dir.Version = directoryVersion
+	dir.ExportedAt = header[2]
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		want, ok := directoryCSVColumns[record[0]]
+		if !ok {
+			return nil, fmt.Errorf("line %d: unknown record type %q", line, record[0])
+		}
+		if len(record) != want {
+			return nil, fmt.Errorf("line %d: %s record must have %d columns, got %d", line, record[0], want, len(record))
+		}
+		switch record[0] {
+		case "user":
+			active, err := strconv.ParseBool(record[6])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid active flag %q", line, record[6])
+			}
+			dir.Users = append(dir.Users, directoryUser{Email: record[1], Surname: record[2], Name: record[3], Patronymic: record[4], Role: record[5], Active: active})
+		case "group":
+			dir.Groups = append(dir.Groups, directoryGroup{Name: record[1], Description: record[2]})
+		case "member":
+			dir.Members = append(dir.Members, directoryMember{Email: record[1], Group: record[2]})
+		case "term":
+			dir.Terms = append(dir.Terms, directoryTerm{Name: record[1], StartsOn: record[2], EndsOn: record[3], State: record[4]})
+		case "discipline":
+			dir.Disciplines = append(dir.Disciplines, directoryDiscipline{Term: record[1], Name: record[2], OwnerEmail: record[3],
+				CoLecturers: splitEmails(record[4]), ReadOnly: splitEmails(record[5])})
+		case "assignment":
+			dir.Assignments = append(dir.Assignments, directoryAssignment{Term: record[1], Discipline: record[2], Group: record[3],
+				Seminarists: splitEmails(record[4]), Assistants: splitEmails(record[5])})
+		}
+	}
+	return &dir, nil
+}
+
+// validateDirectory проверяет записи, которые можно проверить без БД
+func validateDirectory(dir *directory) []string {
+	var problems []string
+	validRoles := make(map[string]bool)
+	for _, role := range auth.AllRoles {
+		validRoles[role] = true
+	}
+
+	emails := make(map[string]bool)
+	for _, u := range dir.Users {
+		if addr, err := mail.ParseAddress(u.Email); err != nil || addr.Address != u.Email {
+			problems = append(problems, fmt.Sprintf("user %q: invalid email", u.Email))
+			continue
+		}
+		key := strings.ToLower(u.Email)
+		if emails[key] {
+			problems = append(problems, fmt.Sprintf("user %s: listed twice", u.Email))
+		}
+		emails[key] = true
+		for _, part := range []struct{ field, value string }{{"name", u.Name}, {"surname", u.Surname}, {"patronymic", u.Patronymic}} {
+			if part.value == "" && part.field != "patronymic" {
+				problems = append(problems, fmt.Sprintf("user %s: empty %s", u.Email, part.field))
+			}
+			if utf8.RuneCountInString(part.value) > maxNamePart {
+				problems = append(problems, fmt.Sprintf("user %s: %s is longer than %d characters", u.Email, part.field, maxNamePart))
+			}
+		}
+		if !validRoles[u.Role] {
+			problems = append(problems, fmt.Sprintf("user %s: unknown role %q", u.Email, u.Role))
+		}
+	}
+
+	groups := make(map[string]bool)
+	for _, g := range dir.Groups {
+		if g.Name == "" {
+			problems = append(problems, "group with empty name")
+		} else if groups[g.Name] {
+			problems = append(problems, fmt.Sprintf("group %s: listed twice", g.Name))
+		}
+		groups[g.Name] = true
+	}
+	terms := make(map[string]bool)
+	active := ""
+	for _, t := range dir.Terms {
+		if t.Name == "" {
+			problems = append(problems, "term with empty name")
+			continue
+		}
+		if terms[t.Name] {
+			problems = append(problems, fmt.Sprintf("term %s: listed twice", t.Name))
+		}
+		terms[t.Name] = true
+		startsOn, err1 := time.Parse(termDateLayout, t.StartsOn)
+		endsOn, err2 := time.Parse(termDateLayout, t.EndsOn)
+		if err1 != nil || err2 != nil {
+			problems = append(problems, fmt.Sprintf("term %s: dates must be YYYY-MM-DD", t.Name))
+		} else if !endsOn.After(startsOn) {
+			problems = append(problems, fmt.Sprintf("term %s: ends before it starts", t.Name))
+		}
+		switch t.State {
+		case termUpcoming, termArchived:
+		case termActive:
+			if active != "" {
+				problems = append(problems, fmt.Sprintf("term %s: %s is active too, only one term can be active", t.Name, active))
+			}
+			active = t.Name
+		default:
+			problems = append(problems, fmt.Sprintf("term %s: unknown state %q", t.Name, t.State))
+		}
+	}
+	disciplines := make(map[[2]string]bool)
+	for _, d := range dir.Disciplines {
+		key := [2]string{d.Term, d.Name}
+		if d.Name == "" {
+			problems = append(problems, "discipline with empty name")
+		} else if d.Term == "" {
+			problems = append(problems, fmt.Sprintf("discipline %s: empty term", d.Name))
+		} else if disciplines[key] {
+			problems = append(problems, fmt.Sprintf("discipline %s in term %s: listed twice", d.Name, d.Term))
+		}
+		disciplines[key] = true
+		lecturers := make(map[string]bool)
+		for _, email := range d.lecturerEmails() {
+			if _, err := mail.ParseAddress(email); err != nil {
+				problems = append(problems, fmt.Sprintf("discipline %s: invalid email %q", d.Name, email))
+			} else if lecturers[strings.ToLower(email)] {
+				problems = append(problems, fmt.Sprintf("discipline %s: lecturer %s listed twice", d.Name, email))
+			}
+			lecturers[strings.ToLower(email)] = true
+		}
+	}
+	assignments := make(map[[3]string]bool)
+	for _, a := range dir.Assignments {
+		key := [3]string{a.Term, a.Discipline, a.Group}
+		if assignments[key] {
+			problems = append(problems, fmt.Sprintf("assignment %s / %s in term %s: listed twice", a.Discipline, a.Group, a.Term))
+		}
+		assignments[key] = true
+		for _, email := range append(append([]string(nil), a.Seminarists...), a.Assistants...) {
+			if _, err := mail.ParseAddress(email); err != nil {
+				problems = append(problems, fmt.Sprintf("assignment %s / %s: invalid email %q", a.Discipline, a.Group, email))
+			}
+		}
+	}
+	return problems
+}
+
+// directoryIndex сопоставляет почту и названия с id в целевой базе
+type directoryIndex struct {
+	users       map[string]int32
+	groups      map[string][]int32
+	terms       map[string]int32
+	archived    map[string]bool // семестры, архивные ещё до импорта
+	activeTerm  string
+	disciplines map[string]map[string][]int32 // семестр -> название -> id
+}
+
+func loadDirectoryIndex(ctx context.Context, tx *sql.Tx) (*directoryIndex, error) {
+	idx := &directoryIndex{
+		users:       make(map[string]int32),
+		groups:      make(map[string][]int32),
+		terms:       make(map[string]int32),
+		archived:    make(map[string]bool),
+		disciplines: make(map[string]map[string][]int32),
+	}
+	err := queryEach(ctx, tx, "SELECT id, email FROM users", func(rows *sql.Rows) error {
+		var id int32
+		var email string
+		if err := rows.Scan(&id, &email); err != nil {
+			return err
+		}
+		idx.users[strings.ToLower(email)] = id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = queryEach(ctx, tx, "SELECT id, name FROM student_groups", func(rows *sql.Rows) error {
+		var id int32
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		idx.groups[name] = append(idx.groups[name], id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = queryEach(ctx, tx, "SELECT id, name, state FROM terms", func(rows *sql.Rows) error {
+		var id int32
+		var name, state string
+		if err := rows.Scan(&id, &name, &state); err != nil {
+			return err
+		}
+		idx.terms[name] = id
+		idx.archived[name] = state == termArchived
+		if state == termActive {
+			idx.activeTerm = name
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = queryEach(ctx, tx, `
+		SELECT d.id, tm.name, d.name FROM disciplines d JOIN terms tm ON tm.id = d.term_id`, func(rows *sql.Rows) error {
+		var id int32
+		var term, name string
+		if err := rows.Scan(&id, &term, &name); err != nil {
+			return err
+		}
+		idx.addDiscipline(term, name, id)
+		return nil
+	})
+	return idx, err
+}
+
+func (idx *directoryIndex) addDiscipline(term, name string, id int32) {
+	if idx.disciplines[term] == nil {
+		idx.disciplines[term] = make(map[string][]int32)
+	}
+	idx.disciplines[term][name] = append(idx.disciplines[term][name], id)
+}
+
+// resolve возвращает единственный id по названию или причину, по которой его нет
+func resolve(ids map[string][]int32, kind, name string) (int32, string) {
+	switch len(ids[name]) {
+	case 0:
+		return 0, fmt.Sprintf("%s %s not found", kind, name)
+	case 1:
+		return ids[name][0], ""
+	default:
+		return 0, fmt.Sprintf("%s name %s is ambiguous", kind, name)
+	}
+}
+
+func (idx *directoryIndex) user(email string) (int32, string) {
+	id, ok := idx.users[strings.ToLower(email)]
+	if !ok {
+		return 0, fmt.Sprintf("user %s not found", email)
+	}
+	return id, ""
+}
+
+func (idx *directoryIndex) discipline(term, name string) (int32, string) {
+	id, problem := resolve(idx.disciplines[term], "discipline", name)
+	if problem != "" {
+		problem += " in term " + term
+	}
+	return id, problem
+}
+
+// invitedUser — созданный импортом пользователь, которому нужно отправить приглашение
+type invitedUser struct {
+	id    int32
+	email string
+	token string
+}
+
+// ImportDirectory добавляет недостающие записи справочника в одной транзакции, а с
+// updateExisting ещё и обновляет существующие. Ничего не удаляется. Если найдена хоть одна
+// проблема, транзакция откатывается; в режиме dry_run она откатывается всегда.
+func (r *Repository) ImportDirectory(ctx context.Context, dir *directory, opts *pb.ImportDirectoryOptions, invitedBy int) (*pb.ImportDirectoryResponse, []invitedUser, error) {
+	resp := &pb.ImportDirectoryResponse{DryRun: opts.DryRun, Problems: validateDirectory(dir)}
+	if len(resp.Problems) > 0 {
+		return resp, nil, nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	idx, err := loadDirectoryIndex(ctx, tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashedPassword, err := unusablePassword()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var invited []invitedUser
+	for _, u := range dir.Users {
+		if id, ok := idx.users[strings.ToLower(u.Email)]; ok {
+			if !opts.UpdateExisting {
+				continue
+			}
+			// Блокировка существующих пользователей импортом не меняется
+			res, err := tx.ExecContext(ctx, `
+				UPDATE users SET name = $1, surname = $2, patronymic = NULLIF($3, ''), role = $4::user_role
+				WHERE id = $5 AND (name, surname, COALESCE(patronymic, ''), role) IS DISTINCT FROM ($1, $2, $3, $4::user_role)`,
+				u.Name, u.Surname, u.Patronymic, u.Role, id)
+			if err != nil {
+				return nil, nil, fmt.Errorf("user %s: %w", u.Email, err)
+			}
+			if n, _ := res.RowsAffected(); n > 0 {
+				resp.UsersUpdated++
+			}
+			continue
+		}
+		id, err := insertInvitedUser(ctx, tx, u.Name, u.Surname, u.Patronymic, u.Email, u.Role, hashedPassword)
+		if err != nil {
+			return nil, nil, fmt.Errorf("user %s: %w", u.Email, err)
+		}
+		if u.Active {
+			token, err := createInvitation(ctx, tx, id, invitedBy)
+			if err != nil {
+				return nil, nil, fmt.Errorf("user %s: %w", u.Email, err)
+			}
+			invited = append(invited, invitedUser{id: id, email: u.Email, token: token})
+		} else {
+			_, err := tx.ExecContext(ctx, "UPDATE users SET deactivated_at = now(), deactivated_by = $1 WHERE id = $2", invitedBy, id)
+			if err != nil {
+				return nil, nil, fmt.Errorf("user %s: %w", u.Email, err)
+			}
+		}
+		idx.users[strings.ToLower(u.Email)] = id
+		resp.UsersCreated++
+	}
+
+	for _, g := range dir.Groups {
+		if len(idx.groups[g.Name]) > 0 {
+			id, problem := resolve(idx.groups, "group", g.Name)
+			if problem != "" {
+				resp.Problems = append(resp.Problems, problem)
+				continue
+			}
+			if !opts.UpdateExisting {
+				continue
+			}
+			res, err := tx.ExecContext(ctx, `
+				UPDATE student_groups SET description = NULLIF($1, '')
+				WHERE id = $2 AND COALESCE(description, '') <> $1`, g.Description, id)
+			if err != nil {
+				return nil, nil, fmt.Errorf("group %s: %w", g.Name, err)
+			}
+			if n, _ := res.RowsAffected(); n > 0 {
+				resp.GroupsUpdated++
+			}
+			continue
+		}
+		var id int32
+		err := tx.QueryRowContext(ctx, "INSERT INTO student_groups (name, description) VALUES ($1, NULLIF($2, '')) RETURNING id",
+			g.Name, g.Description).Scan(&id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("group %s: %w", g.Name, err)
+		}
+		idx.groups[g.Name] = []int32{id}
+		resp.GroupsCreated++
+	}
+
+	for _, m := range dir.Members {
+		userID, problem := idx.user(m.Email)
+		if problem == "" {
+			var groupID int32
+			if groupID, problem = resolve(idx.groups, "group", m.Group); problem == "" {
+				res, err := tx.ExecContext(ctx, `
+					INSERT INTO users_in_groups (user_id, group_id)
+					SELECT $1, $2 WHERE NOT EXISTS (
+						SELECT 1 FROM users_in_groups WHERE user_id = $1 AND group_id = $2)`, userID, groupID)
+				if err != nil {
+					return nil, nil, fmt.Errorf("member %s of %s: %w", m.Email, m.Group, err)
+				}
+				if n, _ := res.RowsAffected(); n > 0 {
+					resp.MembershipsCreated++
+				}
+				continue
+			}
+		}
+		resp.Problems = append(resp.Problems, fmt.Sprintf("member %s of %s: %s", m.Email, m.Group, problem))
+	}
+
+	for _, t := range dir.Terms {
+		if id, ok := idx.terms[t.Name]; ok {
+			// Состояние существующего семестра импортом не меняется, архивный не меняется вовсе
+			if !opts.UpdateExisting || idx.archived[t.Name] {
+				continue
+			}
+			res, err := tx.ExecContext(ctx, `
+				UPDATE terms SET starts_on = $1, ends_on = $2
+				WHERE id = $3 AND (starts_on, ends_on) IS DISTINCT FROM ($1::date, $2::date)`, t.StartsOn, t.EndsOn, id)
+			if err != nil {
+				return nil, nil, fmt.Errorf("term %s: %w", t.Name, err)
+			}
+			if n, _ := res.RowsAffected(); n > 0 {
+				resp.TermsUpdated++
+			}
+			continue
+		}
+		if t.State == termActive && idx.activeTerm != "" {
+			resp.Problems = append(resp.Problems, fmt.Sprintf("term %s: term %s is already active", t.Name, idx.activeTerm))
+			continue
+		}
+		var id int32
+		err := tx.QueryRowContext(ctx, "INSERT INTO terms (name, starts_on, ends_on, state) VALUES ($1, $2, $3, $4) RETURNING id",
+			t.Name, t.StartsOn, t.EndsOn, t.State).Scan(&id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("term %s: %w", t.Name, err)
+		}
+		idx.terms[t.Name] = id
+		if t.State == termActive {
+			idx.activeTerm = t.Name
+		}
+		resp.TermsCreated++
+	}
+
+	for _, d := range dir.Disciplines {
+		termID, ok := idx.terms[d.Term]
+		if !ok {
+			resp.Problems = append(resp.Problems, fmt.Sprintf("discipline %s: term %s not found", d.Name, d.Term))
+			continue
+		}
+		emails, roles := d.lecturers()
+		lecturerIDs := make([]int32, len(emails))
+		problem := ""
+		for i, email := range emails {
+			if lecturerIDs[i], problem = idx.user(email); problem != "" {
+				break
+			}
+		}
+		if problem != "" {
+			resp.Problems = append(resp.Problems, fmt.Sprintf("discipline %s: lecturer %s", d.Name, problem))
+			continue
+		}
+		// Импорт только назначает роли: лекторов, которых нет в файле, он не убирает
+		setLecturers := func(id int32) (bool, error) {
+			changed := false
+			for i, userID := range lecturerIDs {
+				ok, err := setLecturer(ctx, tx, id, userID, roles[i])
+				var p lecturerProblem
+				if errors.As(err, &p) {
+					resp.Problems = append(resp.Problems, fmt.Sprintf("discipline %s: %s", d.Name, p))
+					continue
+				}
+				if err != nil {
+					return false, fmt.Errorf("discipline %s: %w", d.Name, err)
+				}
+				changed = changed || ok
+			}
+			return changed, nil
+		}
+		if len(idx.disciplines[d.Term][d.Name]) > 0 {
+			id, problem := idx.discipline(d.Term, d.Name)
+			if problem != "" {
+				resp.Problems = append(resp.Problems, problem)
+				continue
+			}
+			if !opts.UpdateExisting || idx.archived[d.Term] {
+				continue
+			}
+			changed, err := setLecturers(id)
+			if err != nil {
+				return nil, nil, err
+			}
+			if changed {
+				resp.DisciplinesUpdated++
+			}
+			continue
+		}
+		if idx.archived[d.Term] {
+			resp.Problems = append(resp.Problems, fmt.Sprintf("discipline %s: term %s is archived and read-only", d.Name, d.Term))
+			continue
+		}
+		var id int32
+		err := tx.QueryRowContext(ctx, "INSERT INTO disciplines (name, term_id) VALUES ($1, $2) RETURNING id",
+			d.Name, termID).Scan(&id)
+		if err != nil {
+			return nil, nil, fmt.Errorf("discipline %s: %w", d.Name, err)
+		}
+		if _, err := setLecturers(id); err != nil {
+			return nil, nil, err
+		}
+		idx.addDiscipline(d.Term, d.Name, id)
+		resp.DisciplinesCreated++
+	}
+
+	for _, a := range dir.Assignments {
+		var disciplineID, groupID int32
+		var problem string
+		if disciplineID, problem = idx.discipline(a.Term, a.Discipline); problem == "" {
+			groupID, problem = resolve(idx.groups, "group", a.Group)
+		}
+		type member struct {
+			userID int32
+			role   string
+		}
+		var staff []member
+		for _, m := range []struct {
+			emails []string
+			role   string
+		}{{a.Seminarists, staffSeminarist}, {a.Assistants, staffAssistant}} {
+			for _, email := range m.emails {
+				if problem != "" {
+					break
+				}
+				var userID int32
+				if userID, problem = idx.user(email); problem == "" {
+					staff = append(staff, member{userID, m.role})
+				}
+			}
+		}
+		if problem != "" {
+			resp.Problems = append(resp.Problems, fmt.Sprintf("assignment %s / %s: %s", a.Discipline, a.Group, problem))
+			continue
+		}
+		archived := idx.archived[a.Term]
+
+		// Импорт только добавляет преподавателей: тех, кого нет в файле, он не убирает
+		var gdID int32
+		isNew := false
+		err := tx.QueryRowContext(ctx, "SELECT id FROM groups_in_disciplines WHERE discipline_id = $1 AND group_id = $2 LIMIT 1",
+			disciplineID, groupID).Scan(&gdID)
+		switch {
+		case err == sql.ErrNoRows && archived:
+			resp.Problems = append(resp.Problems, fmt.Sprintf("assignment %s / %s: term %s is archived and read-only", a.Discipline, a.Group, a.Term))
+			continue
+		case err == sql.ErrNoRows:
+			err = tx.QueryRowContext(ctx, `
+				INSERT INTO groups_in_disciplines (group_id, discipline_id, term_id)
+				VALUES ($1, $2, $3) RETURNING id`, groupID, disciplineID, idx.terms[a.Term]).Scan(&gdID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("assignment %s / %s: %w", a.Discipline, a.Group, err)
+			}
+			resp.AssignmentsCreated++
+			isNew = true
+		case err != nil:
+			return nil, nil, fmt.Errorf("assignment %s / %s: %w", a.Discipline, a.Group, err)
+		case !opts.UpdateExisting || archived:
+			continue
+		}
+		added := false
+		for _, m := range staff {
+			created, err := addStaff(ctx, tx, gdID, m.userID, m.role)
+			if err != nil {
+				return nil, nil, fmt.Errorf("assignment %s / %s: %w", a.Discipline, a.Group, err)
+			}
+			added = added || created
+		}
+		if added && !isNew {
+			resp.AssignmentsUpdated++
+		}
+	}
+
+	if opts.DryRun || len(resp.Problems) > 0 {
+		return resp, nil, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	resp.Committed = true
+	return resp, invited, nil
+}
+
+// chunkWriter режет поток байтов на сообщения не больше directoryChunkSize
+type chunkWriter struct {
+	send func([]byte) error
+	buf  []byte
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for len(w.buf) >= directoryChunkSize {
+		if err := w.send(w.buf[:directoryChunkSize]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[directoryChunkSize:]
+	}
+	return len(p), nil
+}
+
+func (w *chunkWriter) Flush() error {
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.send(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (s *Service) ExportDirectory(req *pb.ExportDirectoryRequest, stream grpc.ServerStreamingServer[pb.ExportDirectoryChunk]) error {
+	if !validDirectoryFormat(req.Format) {
+		return status.Errorf(codes.InvalidArgument, "format must be %q or %q", directoryCSV, directoryJSON)
+	}
+	ctx := stream.Context()
+	dir, err := s.repo.ExportDirectory(ctx)
+	if err != nil {
+		log.Printf("Failed to export directory: %v", err)
+		return status.Errorf(codes.Internal, "failed to export directory")
+	}
+
+	w := &chunkWriter{send: func(data []byte) error {
+		return stream.Send(&pb.ExportDirectoryChunk{Data: data})
+	}}
+	if err := encodeDirectory(w, dir, req.Format); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	caller, _ := auth.FromContext(ctx)
+	log.Printf("Superaccount %d exported directory as %s: %d users, %d groups, %d terms, %d disciplines",
+		caller.UserID, req.Format, len(dir.Users), len(dir.Groups), len(dir.Terms), len(dir.Disciplines))
+	return nil
+}
+
+func (s *Service) ImportDirectory(stream grpc.ClientStreamingServer[pb.ImportDirectoryRequest, pb.ImportDirectoryResponse]) error {
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	options := first.GetOptions()
+	if options == nil {
+		return stream.SendAndClose(&pb.ImportDirectoryResponse{Message: "the first message must carry import options", Success: false})
+	}
+	if !validDirectoryFormat(options.Format) {
+		return stream.SendAndClose(&pb.ImportDirectoryResponse{Message: fmt.Sprintf("format must be %q or %q", directoryCSV, directoryJSON), Success: false})
+	}
+
+	var total int
+	dir, err := decodeDirectory(&chunkReader{next: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if msg.GetOptions() != nil {
+			return nil, errors.New("options must be sent only in the first message")
+		}
+		total += len(msg.GetChunk())
+		if total > maxDirectorySize {
+			return nil, fmt.Errorf("directory is larger than %d MiB", maxDirectorySize>>20)
+		}
+		return msg.GetChunk(), nil
+	}}, options.Format)
+	if err != nil {
+		log.Printf("Failed to read directory: %v", err)
+		return stream.SendAndClose(&pb.ImportDirectoryResponse{Message: "failed to read directory: " + err.Error(), Success: false})
+	}
+
+	caller, _ := auth.FromContext(ctx)
+	resp, invited, err := s.repo.ImportDirectory(ctx, dir, options, caller.UserID)
+	if err != nil {
+		log.Printf("Failed to import directory: %v", err)
+		return stream.SendAndClose(&pb.ImportDirectoryResponse{DryRun: options.DryRun, Message: "import rolled back: " + err.Error(), Success: false})
+	}
+	if len(resp.Problems) > 0 {
+		resp.Message = fmt.Sprintf("%d problems found, nothing imported", len(resp.Problems))
+		return stream.SendAndClose(resp)
+	}
+
+	if options.SendInvitations {
+		for _, u := range invited {
+			if err := s.sendInvitation(ctx, u.id, u.email, u.token); err != nil {
+				log.Printf("Failed to send invitation to user %d: %v", u.id, err)
+				resp.Problems = append(resp.Problems, fmt.Sprintf("user %s: invitation was not sent, use ResendInvitation", u.email))
+			}
+		}
+	}
+	resp.Success = true
+	if options.DryRun {
+		resp.Message = "Dry run: nothing was written"
+	} else {
+		log.Printf("Superaccount %d imported directory: %d users, %d groups, %d memberships, %d terms, %d disciplines, %d assignments created",
+			caller.UserID, resp.UsersCreated, resp.GroupsCreated, resp.MembershipsCreated, resp.TermsCreated, resp.DisciplinesCreated, resp.AssignmentsCreated)
+		resp.Message = "Directory imported"
+	}
+	return stream.SendAndClose(resp)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"rubr/internal/auth"
+)
+
+func sampleDirectory() *directory {
+	return &directory{
+		Version:    directoryVersion,
+		ExportedAt: "2026-09-01T10:00:00Z",
+		Users: []directoryUser{
+			{Email: "ivan@example.com", Surname: "Петров", Name: "Иван", Patronymic: "Сергеевич", Role: auth.RoleStudent, Active: true},
+			{Email: "anna@example.com", Surname: "Смирнова", Name: "Анна", Role: auth.RoleSeminarist, Active: true},
+			{Email: "oleg@example.com", Surname: "Орлов", Name: "Олег", Role: auth.RoleLecturer, Active: false},
+			{Email: "maria@example.com", Surname: "Козлова", Name: "Мария", Role: auth.RoleLecturer, Active: true},
+		},
+		Groups: []directoryGroup{
+			{Name: "Б01-001", Description: "первый курс, \"поток А\""},
+			{Name: "Б01-002"},
+		},
+		Members: []directoryMember{
+			{Email: "ivan@example.com", Group: "Б01-001"},
+		},
+		Terms: []directoryTerm{
+			{Name: "Осень 2026", StartsOn: "2026-09-01", EndsOn: "2026-12-31", State: termActive},
+			{Name: "Весна 2026", StartsOn: "2026-02-01", EndsOn: "2026-06-30", State: termArchived},
+		},
+		Disciplines: []directoryDiscipline{
+			{Term: "Осень 2026", Name: "Алгебра", OwnerEmail: "oleg@example.com",
+				CoLecturers: []string{"maria@example.com"}, ReadOnly: []string{"anna@example.com"}},
+			{Term: "Весна 2026", Name: "Алгебра"},
+		},
+		Assignments: []directoryAssignment{
+			{Term: "Осень 2026", Discipline: "Алгебра", Group: "Б01-001", Seminarists: []string{"anna@example.com"}},
+			{Term: "Осень 2026", Discipline: "Алгебра", Group: "Б01-002"},
+		},
+	}
+}
+
+func TestDirectoryRoundTrip(t *testing.T) {
+	for _, format := range []string{directoryCSV, directoryJSON} {
+		var buf bytes.Buffer
+		if err := encodeDirectory(&buf, sampleDirectory(), format); err != nil {
+			t.Fatalf("%s: encode: %v", format, err)
+		}
+		got, err := decodeDirectory(&buf, format)
+		if err != nil {
+			t.Fatalf("%s: decode: %v", format, err)
+		}
+		if want := sampleDirectory(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: round trip changed the directory:\n got %+v\nwant %+v", format, got, want)
+		}
+	}
+}
+
+func TestDecodeDirectoryRejectsMalformedInput(t *testing.T) {
+	header := directoryCSVMarker + ",4,2026-09-01T10:00:00Z\n"
+	cases := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{"empty csv", directoryCSV, ""},
+		{"missing marker", directoryCSV, "user,ivan@example.com,Петров,Иван,,student,true\n"},
+		{"old csv version", directoryCSV, directoryCSVMarker + ",3,2026-09-01T10:00:00Z\n"},
+		{"unknown record", directoryCSV, header + "teacher,ivan@example.com\n"},
+		{"short user record", directoryCSV, header + "user,ivan@example.com,Петров,Иван\n"},
+		{"long group record", directoryCSV, header + "group,Б01-001,,extra\n"},
+		{"bad active flag", directoryCSV, header + "user,ivan@example.com,Петров,Иван,,student,maybe\n"},
+		{"old json version", directoryJSON, `{"version": 3, "users": []}`},
+		{"broken json", directoryJSON, `{"version": 4, "users": [`},
+	}
+	for _, c := range cases {
+		if _, err := decodeDirectory(strings.NewReader(c.input), c.format); err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+}
+
+func TestValidateDirectory(t *testing.T) {
+	if problems := validateDirectory(sampleDirectory()); len(problems) != 0 {
+		t.Fatalf("sample directory should be valid, got %v", problems)
+	}
+
+	cases := []struct {
+		name    string
+		mutate  func(d *directory)
+		problem string
+	}{
+		{"invalid email", func(d *directory) { d.Users[0].Email = "ivan" }, "invalid email"},
+		{"email with display name", func(d *directory) { d.Users[0].Email = "Ivan <ivan@example.com>" }, "invalid email"},
+		{"duplicate email", func(d *directory) { d.Users[1].Email = "IVAN@example.com" }, "listed twice"},
+		{"empty name", func(d *directory) { d.Users[0].Name = "" }, "empty name"},
+		{"long surname", func(d *directory) { d.Users[0].Surname = strings.Repeat("Я", maxNamePart+1) }, "surname is longer"},
+		{"unknown role", func(d *directory) { d.Users[0].Role = "dean" }, "unknown role"},
+		{"empty group name", func(d *directory) { d.Groups[1].Name = "" }, "group with empty name"},
+		{"duplicate group", func(d *directory) { d.Groups[1].Name = "Б01-001" }, "group Б01-001: listed twice"},
+		{"duplicate term", func(d *directory) { d.Terms[1].Name = "Осень 2026" }, "term Осень 2026: listed twice"},
+		{"bad term date", func(d *directory) { d.Terms[0].StartsOn = "01.09.2026" }, "YYYY-MM-DD"},
+		{"term ends first", func(d *directory) { d.Terms[0].EndsOn = "2026-08-01" }, "ends before it starts"},
+		{"two active terms", func(d *directory) { d.Terms[1].State = termActive }, "only one term can be active"},
+		{"unknown term state", func(d *directory) { d.Terms[1].State = "frozen" }, "unknown state"},
+		{"discipline without term", func(d *directory) { d.Disciplines[1].Term = "" }, "empty term"},
+		{"duplicate discipline", func(d *directory) { d.Disciplines[1].Term = "Осень 2026" }, "listed twice"},
+		{"lecturer twice", func(d *directory) { d.Disciplines[0].ReadOnly = []string{"OLEG@example.com"} }, "lecturer OLEG@example.com listed twice"},
+		{"bad lecturer email", func(d *directory) { d.Disciplines[0].CoLecturers = []string{"maria"} }, "invalid email"},
+		{"duplicate assignment", func(d *directory) { d.Assignments[1].Group = "Б01-001" }, "listed twice"},
+		{"bad staff email", func(d *directory) { d.Assignments[1].Assistants = []string{"@"} }, "invalid email"},
+	}
+	for _, c := range cases {
+		dir := sampleDirectory()
+		c.mutate(dir)
+		problems := validateDirectory(dir)
+		found := false
+		for _, p := range problems {
+			if strings.Contains(p, c.problem) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s: expected a problem containing %q, got %v", c.name, c.problem, problems)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/mail"
+	"strings"
+
+	"google.golang.org/grpc"
+	"rubr/internal/auth"
+	pb "rubr/proto/superacc"
+)
+
+// maxImportRows ограничивает размер одного импорта
+const maxImportRows = 5000
+
+// Статусы строк отчёта ImportUsers
+const (
+	importCreated         = "created"
+	importAlreadyExists   = "already_exists"
+	importDuplicateInFile = "duplicate_in_file"
+	importInvalidGroup    = "invalid_group"
+	importInvalidRole     = "invalid_role"
+	importInvalidEmail    = "invalid_email"
+	importMalformedFIO    = "malformed_fio"
+	importMalformedRow    = "malformed_row"
+	importRolledBack      = "rolled_back"
+)
+
+// importRow — строка CSV после разбора
+type importRow struct {
+	result     *pb.ImportUserResult
+	name       string
+	surname    string
+	patronymic string
+	group      string
+	role       string
+	token      string // код приглашения созданного пользователя
+}
+
+// chunkReader склеивает части файла из клиентского потока в io.Reader
+type chunkReader struct {
+	next func() ([]byte, error)
+	buf  []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.next()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// parseImportRows читает CSV и проверяет строки, которые можно проверить без БД
+func parseImportRows(r io.Reader) ([]*importRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	validRoles := make(map[string]bool)
+	for _, role := range auth.AllRoles {
+		validRoles[role] = true
+	}
+	seen := make(map[string]int)
+
+	var rows []*importRow
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		// Строка заголовка необязательна
+		if first && len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "fio") {
+			continue
+		}
+		if len(rows) >= maxImportRows {
+			return nil, fmt.Errorf("too many rows, at most %d per import", maxImportRows)
+		}
+
+		row := &importRow{result: &pb.ImportUserResult{Line: int32(line)}}
+		rows = append(rows, row)
+		if len(record) != 4 {
+			row.reject(importMalformedRow, fmt.Sprintf("expected 4 columns (fio, email, group, role), got %d", len(record)))
+			continue
+		}
+		fio := strings.TrimSpace(record[0])
+		email := strings.TrimSpace(record[1])
+		row.group = strings.TrimSpace(record[2])
+		row.role = strings.TrimSpace(record[3])
+		row.result.Email = email
+
+		if row.name, row.surname, row.patronymic, err = parseFIO(fio); err != nil {
+			row.reject(importMalformedFIO, err.Error())
+			continue
+		}
+		if addr, err := mail.ParseAddress(email); err != nil || addr.Address != email {
+			row.reject(importInvalidEmail, fmt.Sprintf("invalid email %q", email))
+			continue
+		}
+		if row.role == "" {
+			row.role = auth.RoleStudent
+		}
+		if !validRoles[row.role] {
+			row.reject(importInvalidRole, fmt.Sprintf("unknown role %q", row.role))
+			continue
+		}
+		key := strings.ToLower(email)
+		if prev, ok := seen[key]; ok {
+			row.reject(importDuplicateInFile, fmt.Sprintf("same email as line %d", prev))
+			continue
+		}
+		seen[key] = line
+	}
+	return rows, nil
+}
+
+func (row *importRow) reject(status, message string) {
+	row.result.Status = status
+	row.result.Message = message
+}
+
+func (row *importRow) valid() bool {
+	return row.result.Status == ""
+}
+
+// ImportUsers создаёт пользователей с приглашениями для всех корректных строк в одной
+// транзакции. В режиме dry_run та же работа выполняется и откатывается.
+func (r *Repository) ImportUsers(ctx context.Context, rows []*importRow, dryRun bool, invitedBy int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	groupRows, err := tx.QueryContext(ctx, "SELECT id, name FROM student_groups")
+	if err != nil {
+		return err
+	}
+	groups := make(map[string][]int32)
+	for groupRows.Next() {
+		var id int32
+		var name string
+		if err := groupRows.Scan(&id, &name); err != nil {
+			groupRows.Close()
+			return err
+		}
+		groups[name] = append(groups[name], id)
+	}
+	groupRows.Close()
+	if err := groupRows.Err(); err != nil {
+		return err
+	}
+
+	// Одного хеша неизвестной строки достаточно на весь импорт
+	hashedPassword, err := unusablePassword()
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if !row.valid() {
+			continue
+		}
+		var groupID int32
+		if row.group != "" {
+			ids := groups[row.group]
+			if len(ids) == 0 {
+				row.reject(importInvalidGroup, fmt.Sprintf("group %s not found", row.group))
+				continue
+			}
+			if len(ids) > 1 {
+				row.reject(importInvalidGroup, fmt.Sprintf("group name %s is ambiguous", row.group))
+				continue
+			}
+			groupID = ids[0]
+		}
+
+		var exists bool
+		err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE lower(email) = lower($1))", row.result.Email).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", row.result.Line, err)
+		}
+		if exists {
+			row.reject(importAlreadyExists, fmt.Sprintf("user with email %s already exists", row.result.Email))
+			continue
+		}
+
+		userID, err := insertInvitedUser(ctx, tx, row.name, row.surname, row.patronymic, row.result.Email, row.role, hashedPassword)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", row.result.Line, err)
+		}
+		if groupID != 0 {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO users_in_groups (user_id, group_id) VALUES ($1, $2)", userID, groupID); err != nil {
+				return fmt.Errorf("line %d: %w", row.result.Line, err)
+			}
+		}
+		if row.token, err = createInvitation(ctx, tx, userID, invitedBy); err != nil {
+			return fmt.Errorf("line %d: %w", row.result.Line, err)
+		}
+		row.result.Status = importCreated
+		if dryRun {
+			row.result.Message = "would be created"
+		} else {
+			row.result.UserId = userID
+		}
+	}
+
+	if dryRun {
+		return nil
+	}
+	return tx.Commit()
+}
+
+func (s *Service) ImportUsers(stream grpc.ClientStreamingServer[pb.ImportUsersRequest, pb.ImportUsersResponse]) error {
+	ctx := stream.Context()
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	options := first.GetOptions()
+	if options == nil {
+		return stream.SendAndClose(&pb.ImportUsersResponse{Message: "the first message must carry import options", Success: false})
+	}
+
+	rows, err := parseImportRows(&chunkReader{next: func() ([]byte, error) {
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if msg.GetOptions() != nil {
+			return nil, errors.New("options must be sent only in the first message")
+		}
+		return msg.GetCsvChunk(), nil
+	}})
+	if err != nil {
+		log.Printf("Failed to read import CSV: %v", err)
+		return stream.SendAndClose(&pb.ImportUsersResponse{Message: "failed to read CSV: " + err.Error(), Success: false})
+	}
+
+	caller, _ := auth.FromContext(ctx)
+	resp := &pb.ImportUsersResponse{DryRun: options.DryRun}
+	if err := s.repo.ImportUsers(ctx, rows, options.DryRun, caller.UserID); err != nil {
+		log.Printf("Failed to import users: %v", err)
+		resp.Message = "import failed and was rolled back, no users were created"
+		for _, row := range rows {
+			// После отката не создан никто, включая строки, до которых импорт не дошёл
+			if row.valid() || row.result.Status == importCreated {
+				row.reject(importRolledBack, "not created: the import was rolled back")
+				row.result.UserId = 0
+			}
+			resp.Skipped++
+			resp.Results = append(resp.Results, row.result)
+		}
+		return stream.SendAndClose(resp)
+	}
+
+	for _, row := range rows {
+		if row.result.Status == importCreated {
+			resp.Created++
+			if !options.DryRun {
+				if err := s.sendInvitation(ctx, row.result.UserId, row.result.Email, row.token); err != nil {
+					log.Printf("Failed to send invitation to user %d: %v", row.result.UserId, err)
+					row.result.Message = "created, but the invitation email could not be sent; use ResendInvitation"
+				}
+			}
+		} else {
+			resp.Skipped++
+		}
+		resp.Results = append(resp.Results, row.result)
+	}
+	resp.Committed = !options.DryRun
+	resp.Success = true
+	if options.DryRun {
+		resp.Message = fmt.Sprintf("Dry run: %d users would be created, %d rows skipped", resp.Created, resp.Skipped)
+	} else {
+		log.Printf("Superaccount %d imported %d users, %d rows skipped", caller.UserID, resp.Created, resp.Skipped)
+		resp.Message = fmt.Sprintf("%d users created, %d rows skipped", resp.Created, resp.Skipped)
+	}
+	return stream.SendAndClose(resp)
+}
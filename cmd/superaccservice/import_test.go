@@ -0,0 +1,116 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFIO(t *testing.T) {
+	cases := []struct {
+		fio                       string
+		name, surname, patronymic string
+		wantErr                   bool
+	}{
+		{"Иван Петров Сергеевич", "Иван", "Петров", "Сергеевич", false},
+		{"  Иван   Петров  ", "Иван", "Петров", "", false},
+		{"Иван\tПетров\tСергеевич", "Иван", "Петров", "Сергеевич", false},
+		{"Иван", "", "", "", true},
+		{"", "", "", "", true},
+		{"Иван Петров Сергеевич Младший", "", "", "", true},
+		{"Иван " + strings.Repeat("Я", maxNamePart+1), "", "", "", true},
+		{"Иван " + strings.Repeat("Я", maxNamePart), "Иван", strings.Repeat("Я", maxNamePart), "", false},
+	}
+	for _, c := range cases {
+		name, surname, patronymic, err := parseFIO(c.fio)
+		if (err != nil) != c.wantErr {
+			t.Errorf("parseFIO(%q): err = %v, wantErr %v", c.fio, err, c.wantErr)
+			continue
+		}
+		if name != c.name || surname != c.surname || patronymic != c.patronymic {
+			t.Errorf("parseFIO(%q) = %q, %q, %q; want %q, %q, %q", c.fio, name, surname, patronymic, c.name, c.surname, c.patronymic)
+		}
+	}
+}
+
+func TestParseImportRows(t *testing.T) {
+	csv := strings.Join([]string{
+		"fio,email,group,role",
+		"Иван Петров,ivan@example.com,Б01-001,student",
+		"Анна Смирнова Олеговна,anna@example.com,,",
+		"Пётр,petr@example.com,Б01-001,student",
+		"Олег Иванов,not-an-email,Б01-001,student",
+		"Олег Иванов,Oleg <oleg@example.com>,Б01-001,student",
+		"Мария Козлова,maria@example.com,Б01-001,dean",
+		"Иван Петров,IVAN@example.com,Б01-002,student",
+		"Лишняя колонка,x@example.com,Б01-001,student,extra",
+		"Сергей Орлов,sergey@example.com,,lecturer",
+	}, "\n")
+
+	rows, err := parseImportRows(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		line   int32
+		status string
+		role   string
+	}{
+		{2, "", "student"},
+		{3, "", "student"},
+		{4, importMalformedFIO, ""},
+		{5, importInvalidEmail, ""},
+		{6, importInvalidEmail, ""},
+		{7, importInvalidRole, ""},
+		{8, importDuplicateInFile, ""},
+		{9, importMalformedRow, ""},
+		{10, "", "lecturer"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(rows), len(want))
+	}
+	for i, w := range want {
+		row := rows[i]
+		if row.result.Line != w.line || row.result.Status != w.status {
+			t.Errorf("row %d: got line %d status %q, want line %d status %q (%s)",
+				i, row.result.Line, row.result.Status, w.line, w.status, row.result.Message)
+		}
+		if w.role != "" && row.role != w.role {
+			t.Errorf("row %d: got role %q, want %q", i, row.role, w.role)
+		}
+	}
+	if !strings.Contains(rows[6].result.Message, "line 2") {
+		t.Errorf("duplicate should point at line 2, got %q", rows[6].result.Message)
+	}
+	if rows[1].patronymic != "Олеговна" || rows[1].group != "" {
+		t.Errorf("got patronymic %q group %q", rows[1].patronymic, rows[1].group)
+	}
+}
+
+func TestParseImportRowsHeaderIsOptional(t *testing.T) {
+	rows, err := parseImportRows(strings.NewReader("Иван Петров,ivan@example.com,Б01-001,student\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || !rows[0].valid() || rows[0].result.Line != 1 {
+		t.Fatalf("headerless file: got %d rows, first %+v", len(rows), rows[0].result)
+	}
+
+	// Заголовок распознаётся только в первой строке
+	rows, err = parseImportRows(strings.NewReader("Иван Петров,ivan@example.com,,\nfio,email,group,role\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[1].result.Status != importMalformedFIO {
+		t.Fatalf("a header after the first line must be treated as data, got %d rows", len(rows))
+	}
+}
+
+func TestParseImportRowsLimit(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i <= maxImportRows; i++ {
+		b.WriteString("Иван Петров,ivan@example.com,,\n")
+	}
+	if _, err := parseImportRows(strings.NewReader(b.String())); err == nil {
+		t.Errorf("expected an error for more than %d rows", maxImportRows)
+	}
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"rubr/internal/auth"
+	pb "rubr/proto/superacc"
+)
+
+func validLecturerRole(role string) bool {
+	switch role {
+	case auth.LecturerOwner, auth.LecturerCo, auth.LecturerReadOnly:
+		return true
+	}
+	return false
+}
+
+// lecturerProblem — отказ назначить роль по правилам дисциплины, а не ошибка базы;
+// транзакция после него остаётся рабочей
+type lecturerProblem string
+
+func (p lecturerProblem) Error() string { return string(p) }
+
+// setLecturer назначает лектору роль в дисциплине и сообщает, изменилось ли что-то.
+// Владелец у дисциплины один: новый владелец понижает прежнего до соавтора, а самого
+// владельца понизить нельзя — сначала нужно передать дисциплину другому
+func setLecturer(ctx context.Context, tx *sql.Tx, disciplineID, userID int32, role string) (bool, error) {
+	var userRole string
+	err := tx.QueryRowContext(ctx, "SELECT role FROM users WHERE id = $1", userID).Scan(&userRole)
+	if err == sql.ErrNoRows {
+		return false, lecturerProblem(fmt.Sprintf("user %d not found", userID))
+	}
+	if err != nil {
+		return false, err
+	}
+	if userRole != "lecturer" {
+		return false, lecturerProblem(fmt.Sprintf("user %d is not a lecturer", userID))
+	}
+
+	var current string
+	err = tx.QueryRowContext(ctx, "SELECT role FROM discipline_lecturers WHERE discipline_id = $1 AND user_id = $2 FOR UPDATE",
+		disciplineID, userID).Scan(&current)
+	if err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	if current == role {
+		return false, nil
+	}
+	if current == auth.LecturerOwner {
+		return false, lecturerProblem(fmt.Sprintf("user %d owns discipline %d; transfer ownership first", userID, disciplineID))
+	}
+	if role == auth.LecturerOwner {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE discipline_lecturers SET role = 'co_lecturer'
+			WHERE discipline_id = $1 AND role = 'owner'`, disciplineID)
+		if err != nil {
+			return false, err
+		}
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO discipline_lecturers (discipline_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (discipline_id, user_id) DO UPDATE SET role = EXCLUDED.role`, disciplineID, userID, role)
+	return err == nil, err
+}
+
+func (r *Repository) SetDisciplineLecturer(ctx context.Context, disciplineID, userID int32, role string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkDisciplinesWritable(ctx, tx, []int32{disciplineID}); err != nil {
+		return err
+	}
+	if _, err := setLecturer(ctx, tx, disciplineID, userID, role); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RemoveDisciplineLecturer убирает лектора из дисциплины; владельца убрать нельзя
+func (r *Repository) RemoveDisciplineLecturer(ctx context.Context, disciplineID, userID int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkDisciplinesWritable(ctx, tx, []int32{disciplineID}); err != nil {
+		return err
+	}
+	var role string
+	err = tx.QueryRowContext(ctx, "DELETE FROM discipline_lecturers WHERE discipline_id = $1 AND user_id = $2 RETURNING role",
+		disciplineID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("user %d does not teach discipline %d", userID, disciplineID)
+	}
+	if err != nil {
+		return err
+	}
+	if role == auth.LecturerOwner {
+		return fmt.Errorf("user %d owns discipline %d; transfer ownership first", userID, disciplineID)
+	}
+	return tx.Commit()
+}
+
+func (r *Repository) ListDisciplineLecturers(ctx context.Context, disciplineID int32) ([]*pb.DisciplineLecturer, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT u.id, u.name, u.surname, COALESCE(u.patronymic, ''), u.email, dl.role
+		FROM discipline_lecturers dl
+		JOIN users u ON u.id = dl.user_id
+		WHERE dl.discipline_id = $1
+		ORDER BY dl.role, u.surname, u.name`, disciplineID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lecturers []*pb.DisciplineLecturer
+	for rows.Next() {
+		l := &pb.DisciplineLecturer{}
+		var name, surname, patronymic string
+		if err := rows.Scan(&l.UserId, &name, &surname, &patronymic, &l.Email, &l.Role); err != nil {
+			return nil, err
+		}
+		l.Fio = fmt.Sprintf("%s %s %s", name, surname, patronymic)
+		if patronymic == "" {
+			l.Fio = fmt.Sprintf("%s %s", name, surname)
+		}
+		lecturers = append(lecturers, l)
+	}
+	return lecturers, rows.Err()
+}
+
+func (s *Service) ManageDisciplineLecturer(ctx context.Context, req *pb.ManageDisciplineLecturerRequest) (*pb.ManageDisciplineLecturerResponse, error) {
+	if req.DisciplineId <= 0 || req.UserId <= 0 {
+		return &pb.ManageDisciplineLecturerResponse{Message: "invalid discipline or user ID", Success: false}, nil
+	}
+	var err error
+	switch req.Action {
+	case "set":
+		if !validLecturerRole(req.Role) {
+			return &pb.ManageDisciplineLecturerResponse{Message: fmt.Sprintf("unknown lecturer role %q", req.Role), Success: false}, nil
+		}
+		err = s.repo.SetDisciplineLecturer(ctx, req.DisciplineId, req.UserId, req.Role)
+	case "remove":
+		err = s.repo.RemoveDisciplineLecturer(ctx, req.DisciplineId, req.UserId)
+	default:
+		return &pb.ManageDisciplineLecturerResponse{Message: "action must be set or remove", Success: false}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to manage lecturer %d of discipline %d: %v", req.UserId, req.DisciplineId, err)
+		return &pb.ManageDisciplineLecturerResponse{Message: err.Error(), Success: false}, nil
+	}
+	caller, _ := auth.FromContext(ctx)
+	log.Printf("Superaccount %d: %s lecturer %d of discipline %d %s", caller.UserID, req.Action, req.UserId, req.DisciplineId, req.Role)
+	return &pb.ManageDisciplineLecturerResponse{Message: "Lecturers updated", Success: true}, nil
+}
+
+func (s *Service) ListDisciplineLecturers(ctx context.Context, req *pb.ListDisciplineLecturersRequest) (*pb.ListDisciplineLecturersResponse, error) {
+	if req.DisciplineId <= 0 {
+		return &pb.ListDisciplineLecturersResponse{Message: "invalid discipline ID", Success: false}, nil
+	}
+	lecturers, err := s.repo.ListDisciplineLecturers(ctx, req.DisciplineId)
+	if err != nil {
+		log.Printf("Failed to list lecturers of discipline %d: %v", req.DisciplineId, err)
+		return &pb.ListDisciplineLecturersResponse{Message: "failed to list lecturers", Success: false}, nil
+	}
+	return &pb.ListDisciplineLecturersResponse{Success: true, Lecturers: lecturers}, nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/lib/pq"
 	"google.golang.org/grpc/codes"
@@ -11,9 +12,14 @@ import (
 	"net"
 	"os"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
+	"rubr/internal/auth"
+	"rubr/internal/password"
+	notifypb "rubr/proto/notification"
 	pb "rubr/proto/superacc"
 )
 
@@ -31,52 +37,52 @@ func NewRepository(db *sql.DB) *Repository {
 }
 
 type Service struct {
-	repo *Repository
+	repo   *Repository
+	notify notifypb.NotificationServiceClient
+	tokens *auth.TokenManager
 	pb.UnimplementedSuperAccServiceServer
 }
 
-func NewService(repo *Repository) *Service {
-	return &Service{repo: repo}
+func NewService(repo *Repository, notify notifypb.NotificationServiceClient, tokens *auth.TokenManager) *Service {
+	return &Service{repo: repo, notify: notify, tokens: tokens}
 }
 
-func (r *Repository) AddUser(ctx context.Context, fio, email, group, status string) (int32, error) {
+// invitationTTL — срок действия приглашения
+const invitationTTL = 7 * 24 * time.Hour
+
+// AddUser создаёт пользователя вместе с приглашением и возвращает код приглашения
+func (r *Repository) AddUser(ctx context.Context, fio, email, group, status string, invitedBy int) (int32, string, error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	defer tx.Rollback()
 
 	if email == "" {
-		return 0, fmt.Errorf("email is required")
+		return 0, "", fmt.Errorf("email is required")
 	}
 
 	// Проверяем, существует ли пользователь с таким email
 	var exists bool
 	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM users WHERE email = $1)", email).Scan(&exists)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 	if exists {
-		return 0, fmt.Errorf("user with email %s already exists", email)
+		return 0, "", fmt.Errorf("user with email %s already exists", email)
 	}
 
-	// Разделяем FIO на name, surname, patronymic
-	parts := strings.Fields(fio)
-	name := parts[0]
-	surname := parts[1]
-	var patronymic string
-	if len(parts) > 2 {
-		patronymic = parts[2]
+	name, surname, patronymic, err := parseFIO(fio)
+	if err != nil {
+		return 0, "", err
 	}
-
-	// Генерируем временный пароль
-	defaultPassword := "temp123"
-
-	query := "INSERT INTO users (name, surname, patronymic, email, password, role) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id"
-	var newUserID int32
-	err = tx.QueryRowContext(ctx, query, name, surname, patronymic, email, defaultPassword, status).Scan(&newUserID)
+	hashedPassword, err := unusablePassword()
 	if err != nil {
-		return 0, err
+		return 0, "", err
+	}
+	newUserID, err := insertInvitedUser(ctx, tx, name, surname, patronymic, email, status, hashedPassword)
+	if err != nil {
+		return 0, "", err
 	}
 
 	// Если указана группа, добавляем в users_in_groups
@@ -85,239 +91,605 @@ func (r *Repository) AddUser(ctx context.Context, fio, email, group, status stri
 		err = tx.QueryRowContext(ctx, "SELECT id FROM student_groups WHERE name = $1", group).Scan(&groupID)
 		if err != nil {
 			if err == sql.ErrNoRows {
-				return 0, fmt.Errorf("group %s not found", group)
+				return 0, "", fmt.Errorf("group %s not found", group)
 			}
-			return 0, err
+			return 0, "", err
 		}
 		_, err = tx.ExecContext(ctx, "INSERT INTO users_in_groups (user_id, group_id) VALUES ($1, $2)", newUserID, groupID)
 		if err != nil {
-			return 0, err
+			return 0, "", err
 		}
 	}
 
+	token, err := createInvitation(ctx, tx, newUserID, invitedBy)
+	if err != nil {
+		return 0, "", err
+	}
+
 	if err := tx.Commit(); err != nil {
-		return 0, err
+		return 0, "", err
+	}
+	return newUserID, token, nil
+}
+
+// maxNamePart совпадает с VARCHAR(32) у name, surname и patronymic
+const maxNamePart = 32
+
+// parseFIO разбирает ФИО в порядке имя, фамилия, отчество; отчество необязательно
+func parseFIO(fio string) (name, surname, patronymic string, err error) {
+	parts := strings.Fields(fio)
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", "", "", fmt.Errorf("malformed FIO %q: expected name, surname and optional patronymic", fio)
+	}
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) > maxNamePart {
+			return "", "", "", fmt.Errorf("malformed FIO %q: each part must be at most %d characters", fio, maxNamePart)
+		}
+	}
+	name, surname = parts[0], parts[1]
+	if len(parts) == 3 {
+		patronymic = parts[2]
+	}
+	return name, surname, patronymic, nil
+}
+
+// unusablePassword возвращает хеш случайной строки, которую никто не знает:
+// свой пароль пользователь задаст, приняв приглашение
+func unusablePassword() (string, error) {
+	secret, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	return password.Hash(secret)
+}
+
+// insertInvitedUser создаёт пользователя, ожидающего приглашения; почта подтвердится,
+// когда он его примет
+func insertInvitedUser(ctx context.Context, tx *sql.Tx, name, surname, patronymic, email, role, hashedPassword string) (int32, error) {
+	query := "INSERT INTO users (name, surname, patronymic, email, password, role) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id"
+	var userID int32
+	err := tx.QueryRowContext(ctx, query, name, surname, patronymic, email, hashedPassword, role).Scan(&userID)
+	return userID, err
+}
+
+// createInvitation отменяет неиспользованные приглашения пользователя и создаёт новое
+func createInvitation(ctx context.Context, tx *sql.Tx, userID int32, invitedBy int) (string, error) {
+	token, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE invitations SET revoked_at = now()
+		WHERE user_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`, userID)
+	if err != nil {
+		return "", err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO invitations (user_id, token_hash, invited_by, expires_at)
+		VALUES ($1, $2, $3, $4)`,
+		userID, tokenHash, invitedBy, time.Now().Add(invitationTTL))
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ResendInvitation выпускает новое приглашение вместо прежнего и возвращает код и почту
+func (r *Repository) ResendInvitation(ctx context.Context, userID int32, invitedBy int) (string, string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", "", err
+	}
+	defer tx.Rollback()
+
+	var email string
+	var accepted, active bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT u.email, EXISTS(SELECT 1 FROM invitations i WHERE i.user_id = u.id AND i.accepted_at IS NOT NULL),
+		       u.deactivated_at IS NULL
+		FROM users u WHERE u.id = $1
+		FOR UPDATE OF u`, userID).Scan(&email, &accepted, &active)
+	if err == sql.ErrNoRows {
+		return "", "", fmt.Errorf("user %d not found", userID)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	if accepted {
+		return "", "", fmt.Errorf("user %d has already accepted an invitation", userID)
+	}
+	if !active {
+		return "", "", fmt.Errorf("user %d is deactivated", userID)
+	}
+
+	token, err := createInvitation(ctx, tx, userID, invitedBy)
+	if err != nil {
+		return "", "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return "", "", err
+	}
+	return token, email, nil
+}
+
+// RevokeInvitation отменяет неиспользованное приглашение. Аккаунт остаётся,
+// но войти в него без нового приглашения нельзя: пароль неизвестен, почта не подтверждена.
+func (r *Repository) RevokeInvitation(ctx context.Context, invitationID int32) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE invitations SET revoked_at = now()
+		WHERE id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`, invitationID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("pending invitation %d not found", invitationID)
+	}
+	return nil
+}
+
+func (r *Repository) ListInvitations(ctx context.Context, pendingOnly bool) ([]*pb.Invitation, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT i.id, i.user_id, u.surname || ' ' || u.name || COALESCE(' ' || u.patronymic, ''), u.email, u.role,
+		       CASE
+		           WHEN i.accepted_at IS NOT NULL THEN 'accepted'
+		           WHEN i.revoked_at IS NOT NULL THEN 'revoked'
+		           WHEN i.expires_at <= now() THEN 'expired'
+		           ELSE 'pending'
+		       END AS status,
+		       i.created_at, i.expires_at
+		FROM invitations i
+		JOIN users u ON u.id = i.user_id
+		WHERE NOT $1 OR (i.accepted_at IS NULL AND i.revoked_at IS NULL AND i.expires_at > now())
+		ORDER BY i.created_at DESC`, pendingOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invitations []*pb.Invitation
+	for rows.Next() {
+		var inv pb.Invitation
+		var createdAt, expiresAt time.Time
+		if err := rows.Scan(&inv.Id, &inv.UserId, &inv.Fio, &inv.Email, &inv.Role, &inv.Status, &createdAt, &expiresAt); err != nil {
+			return nil, err
+		}
+		inv.CreatedAt = createdAt.Format(time.RFC3339)
+		inv.ExpiresAt = expiresAt.Format(time.RFC3339)
+		invitations = append(invitations, &inv)
+	}
+	return invitations, rows.Err()
+}
+
+// sendInvitation отправляет код приглашения через notificationservice
+func (s *Service) sendInvitation(ctx context.Context, userID int32, email, token string) error {
+	resp, err := s.notify.SendInvitationNotification(ctx, &notifypb.NotificationRequest{
+		UserId: userID,
+		Email:  email,
+		Message: fmt.Sprintf("Для вас создан аккаунт в системе оценивания.\nКод приглашения: %s\nВ приложении нажмите «Принять приглашение», введите код и задайте пароль. Код действует %d дней.",
+			token, int(invitationTTL.Hours()/24)),
+		CreatedAt: time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("notification service: %s", resp.Error)
+	}
+	return nil
+}
+
+func (s *Service) ListInvitations(ctx context.Context, req *pb.ListInvitationsRequest) (*pb.ListInvitationsResponse, error) {
+	invitations, err := s.repo.ListInvitations(ctx, req.PendingOnly)
+	if err != nil {
+		log.Printf("Failed to list invitations: %v", err)
+		return &pb.ListInvitationsResponse{Message: "failed to list invitations", Success: false}, nil
+	}
+	return &pb.ListInvitationsResponse{Invitations: invitations, Success: true}, nil
+}
+
+func (s *Service) ResendInvitation(ctx context.Context, req *pb.ResendInvitationRequest) (*pb.ResendInvitationResponse, error) {
+	if req.UserId <= 0 {
+		return &pb.ResendInvitationResponse{Message: "invalid user ID", Success: false}, nil
+	}
+	caller, _ := auth.FromContext(ctx)
+	token, email, err := s.repo.ResendInvitation(ctx, req.UserId, caller.UserID)
+	if err != nil {
+		return &pb.ResendInvitationResponse{Message: err.Error(), Success: false}, nil
+	}
+	if err := s.sendInvitation(ctx, req.UserId, email, token); err != nil {
+		log.Printf("Failed to send invitation to user %d: %v", req.UserId, err)
+		return &pb.ResendInvitationResponse{Message: "invitation created, but the email could not be sent", Success: false}, nil
+	}
+	return &pb.ResendInvitationResponse{Message: "Invitation sent", Success: true}, nil
+}
+
+func (s *Service) RevokeInvitation(ctx context.Context, req *pb.RevokeInvitationRequest) (*pb.RevokeInvitationResponse, error) {
+	if req.InvitationId <= 0 {
+		return &pb.RevokeInvitationResponse{Message: "invalid invitation ID", Success: false}, nil
 	}
-	return newUserID, nil
+	if err := s.repo.RevokeInvitation(ctx, req.InvitationId); err != nil {
+		return &pb.RevokeInvitationResponse{Message: err.Error(), Success: false}, nil
+	}
+	return &pb.RevokeInvitationResponse{Message: "Invitation revoked", Success: true}, nil
 }
 
 func (s *Service) AddUser(ctx context.Context, req *pb.AddUserRequest) (*pb.AddUserResponse, error) {
 	if req.Email == "" {
 		return &pb.AddUserResponse{Message: "email is required", Success: false}, nil
 	}
-	newUserID, err := s.repo.AddUser(ctx, req.Fio, req.Email, req.Group, req.Status)
+	role, ok := userRoles[req.Status]
+	if !ok {
+		return &pb.AddUserResponse{Message: "role is required", Success: false}, nil
+	}
+	caller, _ := auth.FromContext(ctx)
+	newUserID, token, err := s.repo.AddUser(ctx, req.Fio, req.Email, req.Group, role, caller.UserID)
 	if err != nil {
 		return &pb.AddUserResponse{Message: err.Error(), Success: false}, err
 	}
-	return &pb.AddUserResponse{Message: "User added successfully", Success: true, UserId: newUserID}, nil
+	if err := s.sendInvitation(ctx, newUserID, req.Email, token); err != nil {
+		log.Printf("Failed to send invitation to user %d: %v", newUserID, err)
+		return &pb.AddUserResponse{Message: "User added, but the invitation email could not be sent; use ResendInvitation", Success: true, UserId: newUserID}, nil
+	}
+	return &pb.AddUserResponse{Message: "User added, invitation sent", Success: true, UserId: newUserID}, nil
 }
 
-func (r *Repository) RemoveUser(ctx context.Context, email string) error {
-	query := "DELETE FROM users WHERE email = $1"
-	result, err := r.db.ExecContext(ctx, query, email)
+// RemoveUser деактивирует пользователя по почте. Раньше строка удалялась, и каскад
+// уносил работы и оценки студента; окончательное удаление теперь делает PurgeUser.
+func (r *Repository) RemoveUser(ctx context.Context, email string, deactivatedBy int) error {
+	var userID int32
+	err := r.db.QueryRowContext(ctx, "SELECT id FROM users WHERE email = $1", email).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("user with email %s not found", email)
+	}
 	if err != nil {
 		return err
 	}
-	if affected, _ := result.RowsAffected(); affected == 0 {
-		return fmt.Errorf("user with email %s not found", email)
-	}
-	return nil
+	return r.DeactivateUser(ctx, userID, deactivatedBy)
 }
 
 func (s *Service) RemoveUser(ctx context.Context, req *pb.RemoveUserRequest) (*pb.RemoveUserResponse, error) {
 	if req.Email == "" {
 		return &pb.RemoveUserResponse{Message: "email is required", Success: false}, nil
 	}
-	err := s.repo.RemoveUser(ctx, req.Email)
+	caller, _ := auth.FromContext(ctx)
+	err := s.repo.RemoveUser(ctx, req.Email, caller.UserID)
 	if err != nil {
-		return &pb.RemoveUserResponse{Message: err.Error(), Success: false}, err
+		return &pb.RemoveUserResponse{Message: err.Error(), Success: false}, nil
 	}
-	return &pb.RemoveUserResponse{Message: "User removed successfully", Success: true}, nil
+	return &pb.RemoveUserResponse{Message: "User deactivated successfully", Success: true}, nil
 }
 
-func (s *Service) GetGroupStaff(ctx context.Context, req *pb.GetGroupStaffRequest) (*pb.GetGroupStaffResponse, error) {
-	// Валидация входного параметра
-	if req.GroupId <= 0 {
-		return &pb.GetGroupStaffResponse{Message: "invalid group ID", Success: false}, status.Errorf(codes.InvalidArgument, "group ID must be positive")
+// DeactivateUser запрещает пользователю вход и отзывает его сессии и приглашения.
+// Работы, оценки и авторство заданий остаются на месте.
+func (r *Repository) DeactivateUser(ctx context.Context, userID int32, deactivatedBy int) error {
+	if int(userID) == deactivatedBy {
+		return fmt.Errorf("you cannot deactivate your own account")
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
 
-	// SQL-запрос для поиска семинариста и ассистента в группе
-	query := `
-        SELECT u.id
-        FROM users_in_groups ug
-        JOIN users u ON ug.user_id = u.id
-        WHERE ug.group_id = $1 AND u.role IN ('seminarist', 'assistant')
-    `
-	rows, err := s.repo.db.QueryContext(ctx, query, req.GroupId)
+	var active bool
+	err = tx.QueryRowContext(ctx, "SELECT deactivated_at IS NULL FROM users WHERE id = $1 FOR UPDATE", userID).Scan(&active)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("user %d not found", userID)
+	}
 	if err != nil {
-		log.Printf("Failed to query group staff: %v", err)
-		return &pb.GetGroupStaffResponse{Message: err.Error(), Success: false}, status.Errorf(codes.Internal, "database query failed")
+		return err
 	}
-	defer rows.Close()
+	if !active {
+		return fmt.Errorf("user %d is already deactivated", userID)
+	}
+	if err := deactivate(ctx, tx, userID, deactivatedBy); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
 
-	var seminaristID, assistantID int32
-	foundSeminarist, foundAssistant := false, false
+// deactivate помечает пользователя деактивированным и отзывает его сессии,
+// открытые имперсонации — его и под его именем — и неиспользованные приглашения
+func deactivate(ctx context.Context, tx *sql.Tx, userID int32, deactivatedBy int) error {
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users SET deactivated_at = now(), deactivated_by = $2 WHERE id = $1`, userID, deactivatedBy); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE impersonations SET ended_at = now()
+		WHERE (user_id = $1 OR superaccount_id = $1) AND ended_at IS NULL`, userID); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `
+		UPDATE invitations SET revoked_at = now()
+		WHERE user_id = $1 AND accepted_at IS NULL AND revoked_at IS NULL`, userID)
+	return err
+}
 
-	for rows.Next() {
-		var userID int32
-		var role string
-		err := rows.Scan(&userID)
-		if err != nil {
-			log.Printf("Failed to scan row: %v", err)
-			return &pb.GetGroupStaffResponse{Message: err.Error(), Success: false}, status.Errorf(codes.Internal, "scan failed")
-		}
+func (s *Service) DeactivateUser(ctx context.Context, req *pb.DeactivateUserRequest) (*pb.DeactivateUserResponse, error) {
+	if req.UserId <= 0 {
+		return &pb.DeactivateUserResponse{Message: "invalid user ID", Success: false}, nil
+	}
+	caller, _ := auth.FromContext(ctx)
+	if err := s.repo.DeactivateUser(ctx, req.UserId, caller.UserID); err != nil {
+		log.Printf("Failed to deactivate user %d: %v", req.UserId, err)
+		return &pb.DeactivateUserResponse{Message: err.Error(), Success: false}, nil
+	}
+	return &pb.DeactivateUserResponse{Message: "User deactivated successfully", Success: true}, nil
+}
 
-		// Получаем роль пользователя для определения seminarist или assistant
-		roleQuery := "SELECT role FROM users WHERE id = $1"
-		err = s.repo.db.QueryRowContext(ctx, roleQuery, userID).Scan(&role)
-		if err != nil {
-			log.Printf("Failed to get user role: %v", err)
-			continue
-		}
+// ReactivateUser снова разрешает вход; прежний пароль продолжает действовать
+func (r *Repository) ReactivateUser(ctx context.Context, userID int32) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users SET deactivated_at = NULL, deactivated_by = NULL
+		WHERE id = $1 AND deactivated_at IS NOT NULL`, userID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("deactivated user %d not found", userID)
+	}
+	return nil
+}
 
-		if role == "seminarist" && !foundSeminarist {
-			seminaristID = userID
-			foundSeminarist = true
-		} else if role == "assistant" && !foundAssistant {
-			assistantID = userID
-			foundAssistant = true
-		}
+func (s *Service) ReactivateUser(ctx context.Context, req *pb.ReactivateUserRequest) (*pb.ReactivateUserResponse, error) {
+	if req.UserId <= 0 {
+		return &pb.ReactivateUserResponse{Message: "invalid user ID", Success: false}, nil
+	}
+	if err := s.repo.ReactivateUser(ctx, req.UserId); err != nil {
+		log.Printf("Failed to reactivate user %d: %v", req.UserId, err)
+		return &pb.ReactivateUserResponse{Message: err.Error(), Success: false}, nil
+	}
+	return &pb.ReactivateUserResponse{Message: "User reactivated successfully", Success: true}, nil
+}
 
-		// Если оба найдены, можно прервать цикл
-		if foundSeminarist && foundAssistant {
-			break
-		}
+// PurgeUser окончательно удаляет деактивированного пользователя и пишет запись в user_purges.
+// Каскад удаляет его работы и оценки. Лектора с заданиями удалить нельзя: вместе с заданиями
+// пропали бы работы всех студентов по ним. Владельца дисциплины и единственного
+// преподавателя группы по дисциплине тоже: сначала их нужно заменить.
+func (r *Repository) PurgeUser(ctx context.Context, userID int32, confirmEmail, reason string, purgedBy int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback()
 
-	if err := rows.Err(); err != nil {
-		log.Printf("Rows iteration error: %v", err)
-		return &pb.GetGroupStaffResponse{Message: err.Error(), Success: false}, status.Errorf(codes.Internal, "rows iteration failed")
+	var email, fio, role string
+	var active bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT email, surname || ' ' || name || COALESCE(' ' || patronymic, ''), role, deactivated_at IS NULL
+		FROM users WHERE id = $1
+		FOR UPDATE`, userID).Scan(&email, &fio, &role, &active)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("user %d not found", userID)
+	}
+	if err != nil {
+		return err
+	}
+	if active {
+		return fmt.Errorf("user %d must be deactivated before purge", userID)
+	}
+	if !strings.EqualFold(strings.TrimSpace(confirmEmail), email) {
+		return fmt.Errorf("confirmation email does not match user %d", userID)
 	}
 
-	// Если ни один пользователь не найден, возвращаем нули
-	if !foundSeminarist && !foundAssistant {
-		return &pb.GetGroupStaffResponse{Success: true, SeminaristId: 0, AssistantId: 0}, nil
+	var tasks int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM tasks WHERE lector_id = $1", userID).Scan(&tasks); err != nil {
+		return err
+	}
+	if tasks > 0 {
+		return fmt.Errorf("user %d authored %d tasks; delete or reassign them before purge", userID, tasks)
 	}
 
-	return &pb.GetGroupStaffResponse{
-		Success:      true,
-		SeminaristId: seminaristID,
-		AssistantId:  assistantID,
-	}, nil
-}
+	var owned, soleStaff int
+	err = tx.QueryRowContext(ctx, `
+		SELECT (SELECT COUNT(*) FROM discipline_lecturers WHERE user_id = $1 AND role = 'owner'),
+		       (SELECT COUNT(*) FROM discipline_staff ds
+		        WHERE ds.user_id = $1 AND NOT EXISTS(
+		            SELECT 1 FROM discipline_staff other
+		            WHERE other.group_discipline_id = ds.group_discipline_id AND other.user_id <> $1))`,
+		userID).Scan(&owned, &soleStaff)
+	if err != nil {
+		return err
+	}
+	if owned > 0 {
+		return fmt.Errorf("user %d owns %d disciplines; transfer ownership before purge", userID, owned)
+	}
+	if soleStaff > 0 {
+		return fmt.Errorf("user %d is the only teacher of %d group assignments; assign someone else before purge", userID, soleStaff)
+	}
 
-func (r *Repository) UpdateUserRole(ctx context.Context, userID int, role string) error {
-	query := "UPDATE users SET role = $1 WHERE id = $2"
-	_, err := r.db.ExecContext(ctx, query, role, userID)
-	return err
-}
+	var works, marks int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(DISTINCT sw.id), COUNT(scm.id)
+		FROM student_works sw
+		LEFT JOIN student_criteria_marks scm ON scm.student_work_id = sw.id
+		WHERE sw.student_id = $1`, userID).Scan(&works, &marks)
+	if err != nil {
+		return err
+	}
 
-func (s *Service) UpdateUserRole(ctx context.Context, req *pb.UpdateRoleRequest) (*pb.UpdateRoleResponse, error) {
-	validRoles := map[string]bool{
-		"student":      true,
-		"assistant":    true,
-		"seminarist":   true,
-		"lecturer":     true,
-		"superaccount": true,
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO user_purges (user_id, email, fio, role, purged_by, reason, deleted_works, deleted_marks)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		userID, email, fio, role, purgedBy, reason, works, marks); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM users WHERE id = $1", userID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
 	}
+	log.Printf("User %d (%s) purged by %d: %d works, %d marks deleted; reason: %s", userID, email, purgedBy, works, marks, reason)
+	return nil
+}
 
+func (s *Service) PurgeUser(ctx context.Context, req *pb.PurgeUserRequest) (*pb.PurgeUserResponse, error) {
 	if req.UserId <= 0 {
-		return &pb.UpdateRoleResponse{Message: "invalid user ID", Success: false}, nil
+		return &pb.PurgeUserResponse{Message: "invalid user ID", Success: false}, nil
 	}
-	if req.Role == "" {
-		return &pb.UpdateRoleResponse{Message: "role cannot be empty", Success: false}, nil
+	if strings.TrimSpace(req.Reason) == "" {
+		return &pb.PurgeUserResponse{Message: "reason is required", Success: false}, nil
 	}
-	if !validRoles[req.Role] {
-		return &pb.UpdateRoleResponse{Message: "invalid role, must be one of: student, assistant, seminarist, lecturer, superaccount", Success: false}, nil
+	caller, _ := auth.FromContext(ctx)
+	if err := s.repo.PurgeUser(ctx, req.UserId, req.ConfirmEmail, strings.TrimSpace(req.Reason), caller.UserID); err != nil {
+		log.Printf("Failed to purge user %d: %v", req.UserId, err)
+		return &pb.PurgeUserResponse{Message: err.Error(), Success: false}, nil
 	}
+	return &pb.PurgeUserResponse{Message: "User purged", Success: true}, nil
+}
 
-	err := s.repo.UpdateUserRole(ctx, int(req.UserId), req.Role)
+// UnlockUser снимает блокировку входа после неудачных попыток (см. userservice/throttle.go)
+func (r *Repository) UnlockUser(ctx context.Context, userID int32) error {
+	var email string
+	err := r.db.QueryRowContext(ctx, "SELECT email FROM users WHERE id = $1", userID).Scan(&email)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("user %d not found", userID)
+	}
 	if err != nil {
-		return &pb.UpdateRoleResponse{Message: err.Error(), Success: false}, err
+		return err
 	}
-	return &pb.UpdateRoleResponse{Message: "Role updated successfully", Success: true}, nil
+	_, err = r.db.ExecContext(ctx, "DELETE FROM login_throttle WHERE scope = 'account' AND subject = $1", strings.ToLower(strings.TrimSpace(email)))
+	return err
 }
 
-func (r *Repository) ListAllUsers(ctx context.Context) ([]*pb.User, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, name, surname, patronymic, email, role 
-		FROM users`)
+func (s *Service) UnlockUser(ctx context.Context, req *pb.UnlockUserRequest) (*pb.UnlockUserResponse, error) {
+	if req.UserId <= 0 {
+		return &pb.UnlockUserResponse{Message: "invalid user ID", Success: false}, nil
+	}
+	if err := s.repo.UnlockUser(ctx, req.UserId); err != nil {
+		log.Printf("Failed to unlock user %d: %v", req.UserId, err)
+		return &pb.UnlockUserResponse{Message: err.Error(), Success: false}, nil
+	}
+	return &pb.UnlockUserResponse{Message: "User unlocked successfully", Success: true}, nil
+}
+
+// StartImpersonation заводит запись об имперсонации. Под чужим именем нельзя
+// работать от суперпользователя, деактивированного пользователя и самого себя.
+func (r *Repository) StartImpersonation(ctx context.Context, superaccountID int, userID int32, reason string) (int32, string, string, error) {
+	if int(userID) == superaccountID {
+		return 0, "", "", fmt.Errorf("you cannot impersonate yourself")
+	}
+	var role, fio string
+	var active bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT role, surname || ' ' || name || COALESCE(' ' || patronymic, ''), deactivated_at IS NULL
+		FROM users WHERE id = $1`, userID).Scan(&role, &fio, &active)
+	if err == sql.ErrNoRows {
+		return 0, "", "", fmt.Errorf("user %d not found", userID)
+	}
 	if err != nil {
-		return nil, err
+		return 0, "", "", err
+	}
+	if role == auth.RoleSuperaccount {
+		return 0, "", "", fmt.Errorf("superaccounts cannot be impersonated")
+	}
+	if !active {
+		return 0, "", "", fmt.Errorf("user %d is deactivated", userID)
 	}
-	defer rows.Close()
 
-	var users []*pb.User
-	for rows.Next() {
-		var id int32
-		var name, surname, patronymic, email, role string
-		if err := rows.Scan(&id, &name, &surname, &patronymic, &email, &role); err != nil {
-			return nil, err
-		}
-		fio := fmt.Sprintf("%s %s %s", name, surname, patronymic)
-		if patronymic == "" {
-			fio = fmt.Sprintf("%s %s", name, surname)
-		}
-		users = append(users, &pb.User{
-			Id:     id,
-			Fio:    fio,
-			Email:  email,
-			Group:  "", // Группа определяется через users_in_groups, если нужно
-			Status: role,
-		})
+	var impersonationID int32
+	err = r.db.QueryRowContext(ctx, `
+		INSERT INTO impersonations (superaccount_id, user_id, reason, expires_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id`, superaccountID, userID, reason, time.Now().Add(auth.ImpersonationTTL)).Scan(&impersonationID)
+	if err != nil {
+		return 0, "", "", err
 	}
-	return users, nil
+	return impersonationID, role, fio, nil
 }
 
-func (s *Service) ListAllUsers(ctx context.Context, req *pb.ListAllUsersRequest) (*pb.ListAllUsersResponse, error) {
-	users, err := s.repo.ListAllUsers(ctx)
+// EndImpersonation завершает имперсонацию; токен перестаёт действовать сразу
+func (r *Repository) EndImpersonation(ctx context.Context, superaccountID int, impersonationID int32) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE impersonations SET ended_at = now()
+		WHERE id = $1 AND superaccount_id = $2 AND ended_at IS NULL`, impersonationID, superaccountID)
 	if err != nil {
-		return &pb.ListAllUsersResponse{Message: err.Error(), Success: false}, err
+		return err
 	}
-	return &pb.ListAllUsersResponse{Success: true, Users: users}, nil
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("active impersonation %d not found", impersonationID)
+	}
+	return nil
 }
 
-func (s *Service) ListUsersByGroup(ctx context.Context, req *pb.ListUsersByGroupRequest) (*pb.ListUsersByGroupResponse, error) {
-	users, err := s.repo.ListUsersByGroup(ctx, req.GroupName)
+func (s *Service) Impersonate(ctx context.Context, req *pb.ImpersonateRequest) (*pb.ImpersonateResponse, error) {
+	if req.UserId <= 0 {
+		return &pb.ImpersonateResponse{Message: "invalid user ID", Success: false}, nil
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return &pb.ImpersonateResponse{Message: "reason is required", Success: false}, nil
+	}
+	caller, _ := auth.FromContext(ctx)
+	impersonationID, role, fio, err := s.repo.StartImpersonation(ctx, caller.UserID, req.UserId, reason)
 	if err != nil {
-		return &pb.ListUsersByGroupResponse{Message: err.Error(), Success: false}, err
+		log.Printf("Failed to start impersonation of user %d by %d: %v", req.UserId, caller.UserID, err)
+		return &pb.ImpersonateResponse{Message: err.Error(), Success: false}, nil
 	}
-	return &pb.ListUsersByGroupResponse{Success: true, Users: users}, nil
+	token, err := s.tokens.GenerateImpersonation(int(req.UserId), role, int(impersonationID), caller.UserID)
+	if err != nil {
+		log.Printf("Failed to generate impersonation token: %v", err)
+		if err := s.repo.EndImpersonation(ctx, caller.UserID, impersonationID); err != nil {
+			log.Printf("Failed to end impersonation %d: %v", impersonationID, err)
+		}
+		return &pb.ImpersonateResponse{Message: "failed to generate token", Success: false}, nil
+	}
+	log.Printf("Superaccount %d started impersonation %d of user %d: %s", caller.UserID, impersonationID, req.UserId, reason)
+	return &pb.ImpersonateResponse{
+		Token:           token,
+		ExpiresAt:       time.Now().Add(auth.ImpersonationTTL).Unix(),
+		ImpersonationId: impersonationID,
+		UserId:          req.UserId,
+		Role:            role,
+		Fio:             fio,
+		Success:         true,
+	}, nil
 }
 
-func (r *Repository) ListUsersByGroup(ctx context.Context, groupName string) ([]*pb.User, error) {
-	query := `
-		SELECT u.id, u.name, u.surname, u.patronymic, u.email, u.role 
-		FROM users u
-		JOIN users_in_groups ug ON u.id = ug.user_id
-		JOIN student_groups sg ON ug.group_id = sg.id
-		WHERE sg.name = $1`
-	rows, err := r.db.QueryContext(ctx, query, groupName)
-	if err != nil {
-		return nil, err
+func (s *Service) EndImpersonation(ctx context.Context, req *pb.EndImpersonationRequest) (*pb.EndImpersonationResponse, error) {
+	if req.ImpersonationId <= 0 {
+		return &pb.EndImpersonationResponse{Message: "invalid impersonation ID", Success: false}, nil
 	}
-	defer rows.Close()
+	caller, _ := auth.FromContext(ctx)
+	if err := s.repo.EndImpersonation(ctx, caller.UserID, req.ImpersonationId); err != nil {
+		return &pb.EndImpersonationResponse{Message: err.Error(), Success: false}, nil
+	}
+	return &pb.EndImpersonationResponse{Message: "Impersonation ended", Success: true}, nil
+}
 
-	var users []*pb.User
-	for rows.Next() {
-		var id int32
-		var name, surname, patronymic, email, role string
-		if err := rows.Scan(&id, &name, &surname, &patronymic, &email, &role); err != nil {
-			return nil, err
-		}
-		fio := fmt.Sprintf("%s %s %s", name, surname, patronymic)
-		if patronymic == "" {
-			fio = fmt.Sprintf("%s %s", name, surname)
-		}
-		users = append(users, &pb.User{
-			Id:     id,
-			Fio:    fio,
-			Email:  email,
-			Group:  groupName,
-			Status: role,
-		})
+func (s *Service) GetGroupStaff(ctx context.Context, req *pb.GetGroupStaffRequest) (*pb.GetGroupStaffResponse, error) {
+	// Валидация входного параметра
+	if req.GroupId <= 0 {
+		return &pb.GetGroupStaffResponse{Message: "invalid group ID", Success: false}, status.Errorf(codes.InvalidArgument, "group ID must be positive")
 	}
-	return users, nil
+
+	// Семинарист и ассистент, первыми назначенные группе по любой из её дисциплин
+	var seminaristID, assistantID int32
+	err := s.repo.db.QueryRowContext(ctx, `
+        SELECT
+            COALESCE((SELECT ds.user_id FROM discipline_staff ds
+                      JOIN groups_in_disciplines gd ON gd.id = ds.group_discipline_id
+                      WHERE gd.group_id = $1 AND ds.role = 'seminarist' ORDER BY ds.id LIMIT 1), 0),
+            COALESCE((SELECT ds.user_id FROM discipline_staff ds
+                      JOIN groups_in_disciplines gd ON gd.id = ds.group_discipline_id
+                      WHERE gd.group_id = $1 AND ds.role = 'assistant' ORDER BY ds.id LIMIT 1), 0)`,
+		req.GroupId).Scan(&seminaristID, &assistantID)
+	if err != nil {
+		log.Printf("Failed to query group staff: %v", err)
+		return &pb.GetGroupStaffResponse{Message: "failed to query group staff", Success: false}, status.Errorf(codes.Internal, "database query failed")
+	}
+
+	return &pb.GetGroupStaffResponse{
+		Success:      true,
+		SeminaristId: seminaristID,
+		AssistantId:  assistantID,
+	}, nil
 }
 
 func (r *Repository) ListGroups(ctx context.Context) ([]*pb.Group, error) {
@@ -352,12 +724,16 @@ func (r *Repository) ListGroups(ctx context.Context) ([]*pb.Group, error) {
 }
 
 func (s *Service) ManageGroup(ctx context.Context, req *pb.ManageGroupRequest) (*pb.ManageGroupResponse, error) {
-	if req.GroupId <= 0 || req.UserId <= 0 || req.Action == "" || req.Role == "" {
+	if req.GroupId <= 0 || req.UserId <= 0 || req.Action == "" {
 		return &pb.ManageGroupResponse{Message: "invalid input parameters", Success: false}, status.Errorf(codes.InvalidArgument, "group ID, user ID, action, and role must be valid")
 	}
 	if req.Action != "add" && req.Action != "remove" {
 		return &pb.ManageGroupResponse{Message: "invalid action", Success: false}, status.Errorf(codes.InvalidArgument, "action must be 'add' or 'remove'")
 	}
+	role, ok := userRoles[req.Role]
+	if !ok {
+		return &pb.ManageGroupResponse{Message: "role is required", Success: false}, status.Errorf(codes.InvalidArgument, "role must be valid")
+	}
 
 	tx, err := s.repo.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -392,11 +768,13 @@ func (s *Service) ManageGroup(ctx context.Context, req *pb.ManageGroupRequest) (
 			return &pb.ManageGroupResponse{Message: err.Error(), Success: false}, status.Errorf(codes.Internal, "failed to add user to group")
 		}
 
-		if req.Role != "" {
-			_, err = tx.ExecContext(ctx, "UPDATE users SET role = $1 WHERE id = $2", req.Role, req.UserId)
-			if err != nil {
-				return &pb.ManageGroupResponse{Message: err.Error(), Success: false}, status.Errorf(codes.Internal, "failed to update user role")
-			}
+		err = setUserRole(ctx, tx, req.UserId, role)
+		var problem roleProblem
+		if errors.As(err, &problem) {
+			return &pb.ManageGroupResponse{Message: problem.Error(), Success: false}, nil
+		}
+		if err != nil {
+			return &pb.ManageGroupResponse{Message: err.Error(), Success: false}, status.Errorf(codes.Internal, "failed to update user role")
 		}
 	} else if req.Action == "remove" {
 		result, err := tx.ExecContext(ctx, "DELETE FROM users_in_groups WHERE user_id = $1 AND group_id = $2", req.UserId, req.GroupId)
@@ -472,29 +850,69 @@ func (s *Service) ManageGroupEntity(ctx context.Context, req *pb.ManageGroupEnti
 }
 
 func (s *Service) ListDisciplines(ctx context.Context, req *pb.ListDisciplinesRequest) (*pb.ListDisciplinesResponse, error) {
-	disciplines, err := s.repo.ListDisciplines(ctx)
+	disciplines, err := s.repo.ListDisciplines(ctx, req.TermId)
 	if err != nil {
 		return &pb.ListDisciplinesResponse{Message: err.Error(), Success: false}, err
 	}
 	return &pb.ListDisciplinesResponse{Success: true, Disciplines: disciplines}, nil
 }
 
-func (r *Repository) ManageDiscipline(ctx context.Context, disciplineID, groupID, seminaristID, assistantID int) error {
-	query := `
-		UPDATE groups_in_disciplines 
-		SET seminarist_id = $1, assistant_id = $2 
-		WHERE discipline_id = $3 AND group_id = $4`
-	_, err := r.db.ExecContext(ctx, query, seminaristID, assistantID, disciplineID, groupID)
-	return err
+// ManageDiscipline заменяет семинаристов и ассистентов группы по дисциплине одним
+// семинаристом и одним ассистентом; нулевой id оставляет роль без изменений
+func (r *Repository) ManageDiscipline(ctx context.Context, disciplineID, groupID, seminaristID, assistantID int32) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkDisciplinesWritable(ctx, tx, []int32{disciplineID}); err != nil {
+		return err
+	}
+	gdID, err := groupDiscipline(ctx, tx, disciplineID, groupID)
+	if err != nil {
+		return err
+	}
+	for _, m := range []struct {
+		userID int32
+		role   string
+	}{{seminaristID, staffSeminarist}, {assistantID, staffAssistant}} {
+		if m.userID == 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM discipline_staff WHERE group_discipline_id = $1 AND role = $2", gdID, m.role); err != nil {
+			return err
+		}
+		if _, err := addStaff(ctx, tx, gdID, m.userID, m.role); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
 }
 
 func (s *Service) ManageDiscipline(ctx context.Context, req *pb.ManageDisciplineRequest) (*pb.ManageDisciplineResponse, error) {
 	if req.DisciplineId <= 0 || req.GroupId <= 0 {
 		return &pb.ManageDisciplineResponse{Message: "invalid discipline or group ID", Success: false}, nil
 	}
-	err := s.repo.ManageDiscipline(ctx, int(req.DisciplineId), int(req.GroupId), int(req.SeminaristId), int(req.AssistantId))
+	var err error
+	switch req.Action {
+	case "":
+		err = s.repo.ManageDiscipline(ctx, req.DisciplineId, req.GroupId, req.SeminaristId, req.AssistantId)
+	case "add", "remove":
+		if req.UserId <= 0 || !validStaffRole(req.Role) {
+			return &pb.ManageDisciplineResponse{Message: "user_id and role (seminarist or assistant) are required", Success: false}, nil
+		}
+		if req.Action == "add" {
+			err = s.repo.AddDisciplineStaff(ctx, req.DisciplineId, req.GroupId, req.UserId, req.Role)
+		} else {
+			err = s.repo.RemoveDisciplineStaff(ctx, req.DisciplineId, req.GroupId, req.UserId, req.Role)
+		}
+	default:
+		return &pb.ManageDisciplineResponse{Message: "action must be add, remove or empty", Success: false}, nil
+	}
 	if err != nil {
-		return &pb.ManageDisciplineResponse{Message: err.Error(), Success: false}, err
+		log.Printf("Failed to manage staff of discipline %d, group %d: %v", req.DisciplineId, req.GroupId, err)
+		return &pb.ManageDisciplineResponse{Message: err.Error(), Success: false}, nil
 	}
 	return &pb.ManageDisciplineResponse{Message: "Discipline managed successfully", Success: true}, nil
 }
@@ -508,6 +926,9 @@ func (r *Repository) ManageDisciplineEntity(ctx context.Context, action string,
 	defer tx.Rollback()
 
 	if action == "attach" {
+		if err := checkDisciplinesWritable(ctx, tx, disciplineIDs); err != nil {
+			return err
+		}
 		for _, disciplineID := range disciplineIDs {
 			var exists bool
 			err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM groups_in_disciplines WHERE group_id = $1 AND discipline_id = $2)", groupID, disciplineID).Scan(&exists)
@@ -515,11 +936,24 @@ func (r *Repository) ManageDisciplineEntity(ctx context.Context, action string,
 				return err
 			}
 			if !exists {
-				_, err = tx.ExecContext(ctx, "INSERT INTO groups_in_disciplines (group_id, discipline_id, seminarist_id, assistant_id) VALUES ($1, $2, $3, $4)",
-					groupID, disciplineID, seminaristID, assistantID)
+				var gdID int32
+				err = tx.QueryRowContext(ctx, `
+					INSERT INTO groups_in_disciplines (group_id, discipline_id, term_id)
+					SELECT $1, d.id, d.term_id FROM disciplines d WHERE d.id = $2
+					RETURNING id`, groupID, disciplineID).Scan(&gdID)
 				if err != nil {
 					return err
 				}
+				if seminaristID != 0 {
+					if _, err := addStaff(ctx, tx, gdID, seminaristID, staffSeminarist); err != nil {
+						return err
+					}
+				}
+				if assistantID != 0 {
+					if _, err := addStaff(ctx, tx, gdID, assistantID, staffAssistant); err != nil {
+						return err
+					}
+				}
 			}
 		}
 	} else {
@@ -544,6 +978,9 @@ func (r *Repository) DeleteDiscipline(ctx context.Context, disciplineIDs []int32
 	}
 	defer tx.Rollback()
 
+	if err := checkDisciplinesWritable(ctx, tx, disciplineIDs); err != nil {
+		return err
+	}
 	for _, id := range disciplineIDs {
 		// Проверка на наличие зависимостей
 		var count int
@@ -568,6 +1005,27 @@ func (r *Repository) DeleteDiscipline(ctx context.Context, disciplineIDs []int32
 	return tx.Commit()
 }
 
+// CreateDiscipline создаёт дисциплину; лектор, если указан, становится её владельцем
+func (r *Repository) CreateDiscipline(ctx context.Context, name string, lectorID, termID int32) (int32, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int32
+	err = tx.QueryRowContext(ctx, `INSERT INTO disciplines (name, term_id) VALUES ($1, $2) RETURNING id`, name, termID).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	if lectorID != 0 {
+		if _, err := setLecturer(ctx, tx, id, lectorID, auth.LecturerOwner); err != nil {
+			return 0, err
+		}
+	}
+	return id, tx.Commit()
+}
+
 func (s *Service) CreateDiscipline(ctx context.Context, req *pb.ManageDisciplineEntityRequest) (*pb.ManageDisciplineEntityResponse, error) {
 	if req.Action != "create" {
 		return &pb.ManageDisciplineEntityResponse{Message: "Недопустимое действие, должно быть 'create'", Success: false}, nil
@@ -578,16 +1036,22 @@ func (s *Service) CreateDiscipline(ctx context.Context, req *pb.ManageDiscipline
 	}
 
 	newDisciplineID := int32(0)
-	query := `INSERT INTO disciplines (name, lector_id) VALUES ($1, $2) RETURNING id`
-	var id int
-	err := s.repo.db.QueryRow(query, req.Name, req.LectorId).Scan(&id)
+	termID, termState, err := resolveTerm(ctx, s.repo.db, req.TermId)
+	if err != nil {
+		log.Printf("Failed to resolve term %d: %v", req.TermId, err)
+		return &pb.ManageDisciplineEntityResponse{Message: err.Error(), Success: false}, nil
+	}
+	if termState == termArchived {
+		return &pb.ManageDisciplineEntityResponse{Message: "Нельзя создать дисциплину в архивном семестре", Success: false}, nil
+	}
+	id, err := s.repo.CreateDiscipline(ctx, req.Name, req.LectorId, termID)
 	if err != nil {
 		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" { // Уникальность email
 			return &pb.ManageDisciplineEntityResponse{Message: "Discipline already exists", Success: false, DisciplineId: newDisciplineID}, nil
 		}
 		return &pb.ManageDisciplineEntityResponse{Message: err.Error(), Success: true, DisciplineId: newDisciplineID}, nil
 	}
-	newDisciplineID = int32(id)
+	newDisciplineID = id
 
 	log.Printf("Discipline created successfully with ID: %d", newDisciplineID)
 	return &pb.ManageDisciplineEntityResponse{Message: "", Success: true, DisciplineId: newDisciplineID}, nil
@@ -613,6 +1077,10 @@ func (s *Service) DeleteDiscipline(ctx context.Context, req *pb.DeleteDiscipline
 		return &pb.DeleteDisciplineResponse{Message: "Список ID дисциплин пуст", Success: false}, nil
 	}
 
+	if err = checkDisciplinesWritable(ctx, tx, req.DisciplineIds); err != nil {
+		log.Printf("Refused to delete disciplines %v: %v", req.DisciplineIds, err)
+		return &pb.DeleteDisciplineResponse{Message: err.Error(), Success: false}, nil
+	}
 	for _, id := range req.DisciplineIds {
 		var count int
 		err = tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM groups_in_disciplines WHERE discipline_id = $1", id).Scan(&count)
@@ -638,8 +1106,12 @@ func (s *Service) DeleteDiscipline(ctx context.Context, req *pb.DeleteDiscipline
 	return &pb.DeleteDisciplineResponse{Message: "Дисциплины удалены успешно", Success: true}, nil
 }
 
-func (r *Repository) ListDisciplines(ctx context.Context) ([]*pb.Discipline, error) {
-	rows, err := r.db.QueryContext(ctx, "SELECT id, name FROM disciplines")
+// ListDisciplines возвращает дисциплины семестра; при termID = 0 — активного
+func (r *Repository) ListDisciplines(ctx context.Context, termID int32) ([]*pb.Discipline, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, term_id FROM disciplines
+		WHERE term_id = COALESCE(NULLIF($1::int, 0), (SELECT id FROM terms WHERE state = 'active'))
+		ORDER BY name`, termID)
 	if err != nil {
 		return nil, err
 	}
@@ -647,12 +1119,12 @@ func (r *Repository) ListDisciplines(ctx context.Context) ([]*pb.Discipline, err
 
 	var disciplines []*pb.Discipline
 	for rows.Next() {
-		var id int32
+		var id, termID int32
 		var name string
-		if err := rows.Scan(&id, &name); err != nil {
+		if err := rows.Scan(&id, &name, &termID); err != nil {
 			return nil, err
 		}
-		disciplines = append(disciplines, &pb.Discipline{Id: id, Name: name})
+		disciplines = append(disciplines, &pb.Discipline{Id: id, Name: name, TermId: termID})
 	}
 	return disciplines, nil
 }
@@ -664,6 +1136,9 @@ func (r *Repository) DetachDisciplinesFromGroup(ctx context.Context, groupID int
 	}
 	defer tx.Rollback()
 
+	if err := checkDisciplinesWritable(ctx, tx, disciplineIDs); err != nil {
+		return err
+	}
 	for _, disciplineID := range disciplineIDs {
 		query := "DELETE FROM groups_in_disciplines WHERE group_id = $1 AND discipline_id = $2"
 		result, err := tx.ExecContext(ctx, query, groupID, disciplineID)
@@ -712,14 +1187,35 @@ func main() {
 		log.Fatalf("Database ping failed: %v", err)
 	}
 
-	repo := NewRepository(db)
-	svc := NewService(repo)
-
 	lis, err := net.Listen("tcp", ":50052")
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
+	keys, err := auth.KeySetFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	tokens := auth.NewTokenManager(keys, auth.AccessTokenTTL)
+	authenticator := auth.NewAuthenticator(tokens, db)
+
+	// Приглашения отправляет notificationservice
+	notificationAddr := os.Getenv("NOTIFICATION_ADDR")
+	if notificationAddr == "" {
+		notificationAddr = "notificationservice:50056"
+	}
+	notifyConn, err := grpc.Dial(notificationAddr, grpc.WithInsecure(), auth.WithServiceAuth(tokens, "superaccservice"))
+	if err != nil {
+		log.Fatalf("Failed to connect to notificationservice: %v", err)
+	}
+	defer notifyConn.Close()
+
+	repo := NewRepository(db)
+	svc := NewService(repo, notifypb.NewNotificationServiceClient(notifyConn), tokens)
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(authenticator.UnaryInterceptor()),
+		grpc.StreamInterceptor(authenticator.StreamInterceptor()),
+	)
 	pb.RegisterSuperAccServiceServer(s, svc)
 
 	log.Printf("Server listening at %v", lis.Addr())
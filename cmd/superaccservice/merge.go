@@ -0,0 +1,330 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+	"rubr/internal/auth"
+	pb "rubr/proto/superacc"
+)
+
+// mergeProblem — отказ объединять учётные записи, а не ошибка базы
+type mergeProblem string
+
+func (p mergeProblem) Error() string { return string(p) }
+
+// lecturerRank — чем меньше, тем шире права; при объединении остаётся более широкая роль
+var lecturerRank = map[string]int{
+	auth.LecturerOwner:    0,
+	auth.LecturerCo:       1,
+	auth.LecturerReadOnly: 2,
+}
+
+// mergeSide — работы одной учётной записи по заданию
+type mergeSide struct {
+	works  []int32
+	latest time.Time
+}
+
+// mergeConflicts находит задания, по которым работы есть у обеих учётных записей, и по
+// политике решает, чьи работы остаются. Без политики kept и discarded пусты
+func mergeConflicts(ctx context.Context, tx *sql.Tx, sourceID, targetID int32, policy pb.MergeConflictPolicy) ([]*pb.MergeConflict, []int32, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT sw.task_id, t.title, sw.id, sw.student_id = $2, sw.created_at
+		FROM student_works sw
+		JOIN tasks t ON t.id = sw.task_id
+		WHERE sw.student_id IN ($1, $2) AND sw.task_id IN (
+			SELECT task_id FROM student_works WHERE student_id = $1
+			INTERSECT
+			SELECT task_id FROM student_works WHERE student_id = $2)
+		ORDER BY sw.task_id, sw.id`, sourceID, targetID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var conflicts []*pb.MergeConflict
+	var sides []map[bool]*mergeSide
+	for rows.Next() {
+		var taskID, workID int32
+		var title string
+		var isTarget bool
+		var createdAt sql.NullTime
+		if err := rows.Scan(&taskID, &title, &workID, &isTarget, &createdAt); err != nil {
+			return nil, nil, err
+		}
+		if len(conflicts) == 0 || conflicts[len(conflicts)-1].TaskId != taskID {
+			conflicts = append(conflicts, &pb.MergeConflict{TaskId: taskID, TaskTitle: title})
+			sides = append(sides, map[bool]*mergeSide{false: {}, true: {}})
+		}
+		side := sides[len(sides)-1][isTarget]
+		side.works = append(side.works, workID)
+		if createdAt.Valid && createdAt.Time.After(side.latest) {
+			side.latest = createdAt.Time
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	var discarded []int32
+	for i, c := range conflicts {
+		keepTarget, decided := keepTargetWorks(policy, sides[i][false], sides[i][true])
+		if !decided {
+			continue
+		}
+		c.KeptWorkIds = sides[i][keepTarget].works
+		c.DiscardedWorkIds = sides[i][!keepTarget].works
+		discarded = append(discarded, c.DiscardedWorkIds...)
+	}
+	return conflicts, discarded, nil
+}
+
+// keepTargetWorks решает по политике, остаются ли работы target (иначе — source).
+// decided = false, если политика не задана. При равенстве по времени остаётся target
+func keepTargetWorks(policy pb.MergeConflictPolicy, source, target *mergeSide) (keepTarget, decided bool) {
+	switch policy {
+	case pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_TARGET:
+		return true, true
+	case pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_SOURCE:
+		return false, true
+	case pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_LATEST:
+		return !source.latest.After(target.latest), true
+	}
+	return false, false
+}
+
+// widerLecturerRole сообщает, даёт ли роль лектора role больше прав, чем than
+func widerLecturerRole(role, than string) bool {
+	return lecturerRank[role] < lecturerRank[than]
+}
+
+// execCount выполняет запрос и возвращает число затронутых строк
+func execCount(ctx context.Context, tx *sql.Tx, query string, args ...any) (int32, error) {
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int32(affected), err
+}
+
+// moveLecturerRoles переносит роли лектора в дисциплинах; если лекторами дисциплины
+// записаны оба, остаётся более широкая роль
+func moveLecturerRoles(ctx context.Context, tx *sql.Tx, sourceID, targetID int32) (int32, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT s.discipline_id, s.role, COALESCE(t.role::text, '')
+		FROM discipline_lecturers s
+		LEFT JOIN discipline_lecturers t ON t.discipline_id = s.discipline_id AND t.user_id = $2
+		WHERE s.user_id = $1
+		ORDER BY s.discipline_id`, sourceID, targetID)
+	if err != nil {
+		return 0, err
+	}
+	type lecturerRow struct {
+		disciplineID       int32
+		source, targetRole string
+	}
+	var roles []lecturerRow
+	for rows.Next() {
+		var r lecturerRow
+		if err := rows.Scan(&r.disciplineID, &r.source, &r.targetRole); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		roles = append(roles, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, r := range roles {
+		if r.targetRole == "" {
+			_, err = tx.ExecContext(ctx, "UPDATE discipline_lecturers SET user_id = $3 WHERE discipline_id = $1 AND user_id = $2",
+				r.disciplineID, sourceID, targetID)
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+		// Сначала уходит строка source: владелец у дисциплины может быть только один
+		_, err = tx.ExecContext(ctx, "DELETE FROM discipline_lecturers WHERE discipline_id = $1 AND user_id = $2", r.disciplineID, sourceID)
+		if err != nil {
+			return 0, err
+		}
+		if widerLecturerRole(r.source, r.targetRole) {
+			_, err = tx.ExecContext(ctx, "UPDATE discipline_lecturers SET role = $3 WHERE discipline_id = $1 AND user_id = $2",
+				r.disciplineID, targetID, r.source)
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+	return int32(len(roles)), nil
+}
+
+// MergeUsers переносит историю source на target и деактивирует source. Обе учётные
+// записи должны иметь одну роль. Пробный прогон выполняет всё то же и откатывает
+// транзакцию, поэтому счётчики в ответе совпадают с настоящим объединением
+func (r *Repository) MergeUsers(ctx context.Context, sourceID, targetID int32, policy pb.MergeConflictPolicy, dryRun bool, mergedBy int) (*pb.MergeUsersResponse, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	roles := make(map[int32]string)
+	active := make(map[int32]bool)
+	rows, err := tx.QueryContext(ctx, "SELECT id, role, deactivated_at IS NULL FROM users WHERE id = ANY($1) ORDER BY id FOR UPDATE",
+		pq.Array([]int32{sourceID, targetID}))
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id int32
+		var role string
+		var isActive bool
+		if err := rows.Scan(&id, &role, &isActive); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		roles[id] = role
+		active[id] = isActive
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	for _, id := range []int32{sourceID, targetID} {
+		if _, ok := roles[id]; !ok {
+			return nil, mergeProblem(fmt.Sprintf("user %d not found", id))
+		}
+	}
+	if roles[sourceID] != roles[targetID] {
+		return nil, mergeProblem(fmt.Sprintf("user %d is a %s and user %d is a %s; align roles first",
+			sourceID, roles[sourceID], targetID, roles[targetID]))
+	}
+	if !active[targetID] {
+		return nil, mergeProblem(fmt.Sprintf("target user %d is deactivated", targetID))
+	}
+
+	resp := &pb.MergeUsersResponse{Success: true}
+	var discarded []int32
+	resp.Conflicts, discarded, err = mergeConflicts(ctx, tx, sourceID, targetID, policy)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Conflicts) > 0 && policy == pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_UNSPECIFIED {
+		if !dryRun {
+			return nil, mergeProblem(fmt.Sprintf("%d tasks have works from both users; choose a conflict policy", len(resp.Conflicts)))
+		}
+		resp.Message = fmt.Sprintf("%d tasks have works from both users; choose a conflict policy", len(resp.Conflicts))
+		return resp, nil
+	}
+
+	// Оценки удаляются вместе с отброшенными работами, остальные переезжают со своими работами
+	if resp.WorksDiscarded, err = execCount(ctx, tx, "DELETE FROM student_works WHERE id = ANY($1)", pq.Array(discarded)); err != nil {
+		return nil, err
+	}
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM student_criteria_marks m
+		JOIN student_works sw ON sw.id = m.student_work_id
+		WHERE sw.student_id = $1`, sourceID).Scan(&resp.MarksMoved)
+	if err != nil {
+		return nil, err
+	}
+	if resp.WorksMoved, err = execCount(ctx, tx, "UPDATE student_works SET student_id = $2 WHERE student_id = $1", sourceID, targetID); err != nil {
+		return nil, err
+	}
+
+	resp.GroupsMoved, err = execCount(ctx, tx, `
+		INSERT INTO users_in_groups (user_id, group_id)
+		SELECT DISTINCT $2::bigint, group_id FROM users_in_groups
+		WHERE user_id = $1 AND group_id NOT IN (SELECT group_id FROM users_in_groups WHERE user_id = $2)`, sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM users_in_groups WHERE user_id = $1", sourceID); err != nil {
+		return nil, err
+	}
+	if resp.NotificationsMoved, err = execCount(ctx, tx, "UPDATE notifications SET user_id = $2 WHERE user_id = $1", sourceID, targetID); err != nil {
+		return nil, err
+	}
+
+	staffMoves := []string{
+		`INSERT INTO discipline_staff (group_discipline_id, user_id, role)
+		 SELECT group_discipline_id, $2::bigint, role FROM discipline_staff WHERE user_id = $1
+		 ON CONFLICT DO NOTHING`,
+		"UPDATE tasks SET lector_id = $2 WHERE lector_id = $1",
+		"UPDATE student_works SET seminarist_id = $2 WHERE seminarist_id = $1",
+		"UPDATE student_works SET assistant_id = $2 WHERE assistant_id = $1",
+	}
+	for _, query := range staffMoves {
+		moved, err := execCount(ctx, tx, query, sourceID, targetID)
+		if err != nil {
+			return nil, err
+		}
+		resp.StaffAssignmentsMoved += moved
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM discipline_staff WHERE user_id = $1", sourceID); err != nil {
+		return nil, err
+	}
+	moved, err := moveLecturerRoles(ctx, tx, sourceID, targetID)
+	if err != nil {
+		return nil, err
+	}
+	resp.StaffAssignmentsMoved += moved
+
+	if active[sourceID] {
+		if err := deactivate(ctx, tx, sourceID, mergedBy); err != nil {
+			return nil, err
+		}
+	}
+	if dryRun {
+		resp.Message = fmt.Sprintf("%d works and %d marks would move to user %d", resp.WorksMoved, resp.MarksMoved, targetID)
+		return resp, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	resp.Applied = true
+	resp.Message = fmt.Sprintf("User %d merged into %d and deactivated", sourceID, targetID)
+	return resp, nil
+}
+
+func (s *Service) MergeUsers(ctx context.Context, req *pb.MergeUsersRequest) (*pb.MergeUsersResponse, error) {
+	if req.SourceUserId <= 0 || req.TargetUserId <= 0 {
+		return &pb.MergeUsersResponse{Message: "invalid user ID", Success: false}, nil
+	}
+	if req.SourceUserId == req.TargetUserId {
+		return &pb.MergeUsersResponse{Message: "source and target must be different users", Success: false}, nil
+	}
+	if _, ok := pb.MergeConflictPolicy_name[int32(req.ConflictPolicy)]; !ok {
+		return &pb.MergeUsersResponse{Message: "unknown conflict policy", Success: false}, nil
+	}
+	caller, _ := auth.FromContext(ctx)
+	if int(req.SourceUserId) == caller.UserID {
+		return &pb.MergeUsersResponse{Message: "you cannot merge away your own account", Success: false}, nil
+	}
+
+	resp, err := s.repo.MergeUsers(ctx, req.SourceUserId, req.TargetUserId, req.ConflictPolicy, req.DryRun, caller.UserID)
+	var problem mergeProblem
+	if errors.As(err, &problem) {
+		return &pb.MergeUsersResponse{Message: problem.Error(), Success: false}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to merge user %d into %d: %v", req.SourceUserId, req.TargetUserId, err)
+		return &pb.MergeUsersResponse{Message: "failed to merge users", Success: false}, nil
+	}
+	if resp.Applied {
+		log.Printf("Superaccount %d: merged user %d into %d (%d works, %d discarded)",
+			caller.UserID, req.SourceUserId, req.TargetUserId, resp.WorksMoved, resp.WorksDiscarded)
+	}
+	return resp, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"rubr/internal/auth"
+	pb "rubr/proto/superacc"
+)
+
+func TestKeepTargetWorks(t *testing.T) {
+	earlier := &mergeSide{latest: time.Date(2026, 9, 1, 10, 0, 0, 0, time.UTC)}
+	later := &mergeSide{latest: time.Date(2026, 9, 2, 10, 0, 0, 0, time.UTC)}
+	never := &mergeSide{}
+	cases := []struct {
+		name           string
+		policy         pb.MergeConflictPolicy
+		source, target *mergeSide
+		keepTarget     bool
+		decided        bool
+	}{
+		{"no policy", pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_UNSPECIFIED, earlier, later, false, false},
+		{"unknown policy", pb.MergeConflictPolicy(42), earlier, later, false, false},
+		{"keep target", pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_TARGET, later, earlier, true, true},
+		{"keep source", pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_SOURCE, earlier, later, false, true},
+		{"latest is target", pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_LATEST, earlier, later, true, true},
+		{"latest is source", pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_LATEST, later, earlier, false, true},
+		// При равенстве остаётся target
+		{"same time", pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_LATEST, later, later, true, true},
+		{"source without date", pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_LATEST, never, earlier, true, true},
+		{"target without date", pb.MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_LATEST, earlier, never, false, true},
+	}
+	for _, c := range cases {
+		keepTarget, decided := keepTargetWorks(c.policy, c.source, c.target)
+		if keepTarget != c.keepTarget || decided != c.decided {
+			t.Errorf("%s: got keepTarget %v decided %v, want %v %v", c.name, keepTarget, decided, c.keepTarget, c.decided)
+		}
+	}
+}
+
+func TestWiderLecturerRole(t *testing.T) {
+	cases := []struct {
+		role, than string
+		wider      bool
+	}{
+		{auth.LecturerOwner, auth.LecturerCo, true},
+		{auth.LecturerOwner, auth.LecturerReadOnly, true},
+		{auth.LecturerCo, auth.LecturerReadOnly, true},
+		{auth.LecturerCo, auth.LecturerOwner, false},
+		{auth.LecturerReadOnly, auth.LecturerCo, false},
+		{auth.LecturerCo, auth.LecturerCo, false},
+	}
+	for _, c := range cases {
+		if got := widerLecturerRole(c.role, c.than); got != c.wider {
+			t.Errorf("widerLecturerRole(%q, %q) = %v, want %v", c.role, c.than, got, c.wider)
+		}
+	}
+	for _, role := range []string{auth.LecturerOwner, auth.LecturerCo, auth.LecturerReadOnly} {
+		if _, ok := lecturerRank[role]; !ok {
+			t.Errorf("lecturer role %q has no rank", role)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"rubr/internal/auth"
+	"rubr/internal/workstatus"
+	pb "rubr/proto/superacc"
+)
+
+// userRoles — роли пользователей по значениям UserRole
+var userRoles = map[pb.UserRole]string{
+	pb.UserRole_USER_ROLE_STUDENT:      auth.RoleStudent,
+	pb.UserRole_USER_ROLE_ASSISTANT:    auth.RoleAssistant,
+	pb.UserRole_USER_ROLE_SEMINARIST:   auth.RoleSeminarist,
+	pb.UserRole_USER_ROLE_LECTURER:     auth.RoleLecturer,
+	pb.UserRole_USER_ROLE_SUPERACCOUNT: auth.RoleSuperaccount,
+}
+
+// roleProblem — отказ сменить роль по правилам, а не ошибка базы
+type roleProblem string
+
+func (p roleProblem) Error() string { return string(p) }
+
+// setUserRole меняет роль пользователя. Нельзя понизить последнего активного
+// суперпользователя и семинариста, за которым ещё числятся непроверенные работы.
+// Понижение снимает семинариста с групп, чтобы новые работы ему больше не доставались
+func setUserRole(ctx context.Context, tx *sql.Tx, userID int32, role string) error {
+	var current string
+	err := tx.QueryRowContext(ctx, "SELECT role FROM users WHERE id = $1 FOR UPDATE", userID).Scan(&current)
+	if err == sql.ErrNoRows {
+		return roleProblem(fmt.Sprintf("user %d not found", userID))
+	}
+	if err != nil {
+		return err
+	}
+	if current == role {
+		return nil
+	}
+
+	switch current {
+	case auth.RoleSuperaccount:
+		// Остальные суперпользователи блокируются, чтобы два одновременных понижения
+		// не оставили систему без них
+		var others int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM (
+				SELECT id FROM users
+				WHERE role = 'superaccount' AND deactivated_at IS NULL AND id <> $1
+				FOR UPDATE
+			) s`, userID).Scan(&others)
+		if err != nil {
+			return err
+		}
+		if others == 0 {
+			return roleProblem(fmt.Sprintf("user %d is the last active superaccount", userID))
+		}
+	case auth.RoleSeminarist:
+		var assigned int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM student_works WHERE seminarist_id = $1 AND status <> $2`,
+			userID, workstatus.GradedBySeminarist).Scan(&assigned)
+		if err != nil {
+			return err
+		}
+		if assigned > 0 {
+			return roleProblem(fmt.Sprintf("seminarist %d still has %d assigned works; reassign them first", userID, assigned))
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM discipline_staff WHERE user_id = $1 AND role = 'seminarist'", userID); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE users SET role = $1 WHERE id = $2", role, userID)
+	return err
+}
+
+func (r *Repository) UpdateUserRole(ctx context.Context, userID int32, role string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := setUserRole(ctx, tx, userID, role); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *Service) UpdateUserRole(ctx context.Context, req *pb.UpdateRoleRequest) (*pb.UpdateRoleResponse, error) {
+	if req.UserId <= 0 {
+		return &pb.UpdateRoleResponse{Message: "invalid user ID", Success: false}, nil
+	}
+	role, ok := userRoles[req.Role]
+	if !ok {
+		return &pb.UpdateRoleResponse{Message: "role is required", Success: false}, nil
+	}
+
+	err := s.repo.UpdateUserRole(ctx, req.UserId, role)
+	var problem roleProblem
+	if errors.As(err, &problem) {
+		return &pb.UpdateRoleResponse{Message: problem.Error(), Success: false}, nil
+	}
+	if err != nil {
+		return &pb.UpdateRoleResponse{Message: err.Error(), Success: false}, err
+	}
+	return &pb.UpdateRoleResponse{Message: "Role updated successfully", Success: true}, nil
+}
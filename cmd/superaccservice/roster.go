@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/lib/pq"
+	"rubr/internal/auth"
+	pb "rubr/proto/superacc"
+)
+
+const (
+	rosterAdd    = "add"
+	rosterMove   = "move"
+	rosterRemove = "remove"
+)
+
+// rosterMember — студент из списка или из текущего состава групп
+type rosterMember struct {
+	id    int32
+	email string
+}
+
+// ReconcileRoster сверяет студентов перечисленных групп со списком и, если это не пробный
+// прогон и проблем нет, применяет разницу одной транзакцией. Студент, который уходит
+// из одной перечисленной группы и приходит в другую, переводится, а не удаляется
+// и добавляется заново
+func (r *Repository) ReconcileRoster(ctx context.Context, roster []*pb.RosterGroup, dryRun bool) ([]*pb.RosterChange, []string, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	names, desired, emails, problems := parseRoster(roster)
+
+	// Блокировка групп не даёт двум сверкам одного состава идти одновременно
+	groupIDs := make(map[string]int32)
+	groupNames := make(map[int32]string)
+	rows, err := tx.QueryContext(ctx, "SELECT id, name FROM student_groups WHERE name = ANY($1) ORDER BY id FOR UPDATE", pq.Array(names))
+	if err != nil {
+		return nil, nil, err
+	}
+	for rows.Next() {
+		var id int32
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		groupIDs[name] = id
+		groupNames[id] = name
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	for _, name := range names {
+		if _, ok := groupIDs[name]; !ok {
+			problems = append(problems, fmt.Sprintf("group %q not found", name))
+		}
+	}
+
+	users := make(map[string]rosterMember)
+	rows, err = tx.QueryContext(ctx, `
+		SELECT id, lower(email), role, deactivated_at IS NULL FROM users
+		WHERE lower(email) = ANY($1)`, pq.Array(emails))
+	if err != nil {
+		return nil, nil, err
+	}
+	roles := make(map[string]string)
+	active := make(map[string]bool)
+	for rows.Next() {
+		var m rosterMember
+		var role string
+		var isActive bool
+		if err := rows.Scan(&m.id, &m.email, &role, &isActive); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		users[m.email] = m
+		roles[m.email] = role
+		active[m.email] = isActive
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	reported := make(map[string]bool)
+	for _, email := range emails {
+		if reported[email] {
+			continue
+		}
+		reported[email] = true
+		switch _, ok := users[email]; {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("user %s not found", email))
+		case roles[email] != auth.RoleStudent:
+			problems = append(problems, fmt.Sprintf("user %s is a %s, not a student", email, roles[email]))
+		case !active[email]:
+			problems = append(problems, fmt.Sprintf("user %s is deactivated", email))
+		}
+	}
+	if len(problems) > 0 {
+		return nil, problems, nil
+	}
+
+	// Текущий состав: только студенты, остальных сверка не трогает
+	ids := make([]int32, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		ids = append(ids, id)
+	}
+	current := make(map[string]map[string]bool) // группа -> почты
+	rows, err = tx.QueryContext(ctx, `
+		SELECT ug.group_id, u.id, lower(u.email)
+		FROM users_in_groups ug
+		JOIN users u ON u.id = ug.user_id
+		WHERE ug.group_id = ANY($1) AND u.role = 'student'`, pq.Array(ids))
+	if err != nil {
+		return nil, nil, err
+	}
+	for rows.Next() {
+		var groupID int32
+		var m rosterMember
+		if err := rows.Scan(&groupID, &m.id, &m.email); err != nil {
+			rows.Close()
+			return nil, nil, err
+		}
+		name := groupNames[groupID]
+		if current[name] == nil {
+			current[name] = make(map[string]bool)
+		}
+		current[name][m.email] = true
+		users[m.email] = m
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	changes := diffRoster(names, desired, current, users)
+	if dryRun || len(changes) == 0 {
+		return changes, nil, nil
+	}
+
+	for _, c := range changes {
+		switch c.Action {
+		case rosterAdd:
+			_, err = tx.ExecContext(ctx, "INSERT INTO users_in_groups (user_id, group_id) VALUES ($1, $2)", c.UserId, groupIDs[c.ToGroup])
+		case rosterMove:
+			_, err = tx.ExecContext(ctx, "UPDATE users_in_groups SET group_id = $3 WHERE user_id = $1 AND group_id = $2",
+				c.UserId, groupIDs[c.FromGroup], groupIDs[c.ToGroup])
+		case rosterRemove:
+			_, err = tx.ExecContext(ctx, "DELETE FROM users_in_groups WHERE user_id = $1 AND group_id = $2", c.UserId, groupIDs[c.FromGroup])
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s %s: %w", c.Action, c.Email, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+	return changes, nil, nil
+}
+
+func (s *Service) ReconcileRoster(ctx context.Context, req *pb.ReconcileRosterRequest) (*pb.ReconcileRosterResponse, error) {
+	if len(req.Groups) == 0 {
+		return &pb.ReconcileRosterResponse{Message: "no groups listed", Success: false}, nil
+	}
+	changes, problems, err := s.repo.ReconcileRoster(ctx, req.Groups, req.DryRun)
+	if err != nil {
+		log.Printf("Failed to reconcile roster: %v", err)
+		return &pb.ReconcileRosterResponse{Message: "failed to reconcile roster", Success: false}, nil
+	}
+	if len(problems) > 0 {
+		return &pb.ReconcileRosterResponse{Message: fmt.Sprintf("%d problems found, nothing applied", len(problems)), Success: false, Problems: problems}, nil
+	}
+	if req.DryRun {
+		return &pb.ReconcileRosterResponse{Message: fmt.Sprintf("%d changes to apply", len(changes)), Success: true, Changes: changes}, nil
+	}
+	caller, _ := auth.FromContext(ctx)
+	log.Printf("Superaccount %d: reconciled %d groups, %d changes", caller.UserID, len(req.Groups), len(changes))
+	return &pb.ReconcileRosterResponse{Message: fmt.Sprintf("%d changes applied", len(changes)), Success: true, Changes: changes, Applied: true}, nil
+}
+
+// parseRoster нормализует список групп: обрезает пробелы, приводит почты к нижнему
+// регистру и сообщает о пустых и повторных группах и почтах. names — группы в порядке
+// списка, emails — все почты списка
+func parseRoster(roster []*pb.RosterGroup) (names []string, desired map[string]map[string]bool, emails []string, problems []string) {
+	desired = make(map[string]map[string]bool) // группа -> почты
+	for _, g := range roster {
+		name := strings.TrimSpace(g.GroupName)
+		if name == "" {
+			problems = append(problems, "group name is empty")
+			continue
+		}
+		if desired[name] != nil {
+			problems = append(problems, fmt.Sprintf("group %q is listed twice", name))
+			continue
+		}
+		names = append(names, name)
+		desired[name] = make(map[string]bool)
+		for _, email := range g.Emails {
+			email = strings.ToLower(strings.TrimSpace(email))
+			if email == "" {
+				continue
+			}
+			if desired[name][email] {
+				problems = append(problems, fmt.Sprintf("group %q: %s is listed twice", name, email))
+				continue
+			}
+			desired[name][email] = true
+			emails = append(emails, email)
+		}
+	}
+	return names, desired, emails, problems
+}
+
+// diffRoster сравнивает желаемый состав групп с текущим (группа -> почты) и возвращает
+// изменения, отсортированные по почте. Уход из одной группы списка и приход в другую
+// даёт перевод
+func diffRoster(names []string, desired, current map[string]map[string]bool, users map[string]rosterMember) []*pb.RosterChange {
+	// По каждому студенту: в какие группы прийти и из каких уйти
+	joins := make(map[string][]string)
+	leaves := make(map[string][]string)
+	for _, name := range names {
+		for email := range desired[name] {
+			if !current[name][email] {
+				joins[email] = append(joins[email], name)
+			}
+		}
+		for email := range current[name] {
+			if !desired[name][email] {
+				leaves[email] = append(leaves[email], name)
+			}
+		}
+	}
+
+	var changes []*pb.RosterChange
+	for email, m := range users {
+		to, from := joins[email], leaves[email]
+		sort.Strings(to)
+		sort.Strings(from)
+		for len(to) > 0 && len(from) > 0 {
+			changes = append(changes, &pb.RosterChange{Action: rosterMove, UserId: m.id, Email: email, FromGroup: from[0], ToGroup: to[0]})
+			to, from = to[1:], from[1:]
+		}
+		for _, name := range to {
+			changes = append(changes, &pb.RosterChange{Action: rosterAdd, UserId: m.id, Email: email, ToGroup: name})
+		}
+		for _, name := range from {
+			changes = append(changes, &pb.RosterChange{Action: rosterRemove, UserId: m.id, Email: email, FromGroup: name})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Email != changes[j].Email {
+			return changes[i].Email < changes[j].Email
+		}
+		return changes[i].FromGroup+changes[i].ToGroup < changes[j].FromGroup+changes[j].ToGroup
+	})
+	return changes
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "rubr/proto/superacc"
+)
+
+func TestParseRoster(t *testing.T) {
+	names, desired, emails, problems := parseRoster([]*pb.RosterGroup{
+		{GroupName: " Б01-001 ", Emails: []string{"Ivan@Example.com", " anna@example.com", "", "ivan@example.com"}},
+		{GroupName: "Б01-002", Emails: []string{"ivan@example.com"}},
+		{GroupName: "Б01-001"},
+		{GroupName: "  "},
+	})
+	if want := []string{"Б01-001", "Б01-002"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+	if !desired["Б01-001"]["ivan@example.com"] || !desired["Б01-001"]["anna@example.com"] || len(desired["Б01-001"]) != 2 {
+		t.Errorf("Б01-001 = %v", desired["Б01-001"])
+	}
+	// Студент в двух группах списка — не повтор: это выяснится при сверке
+	if want := []string{"ivan@example.com", "anna@example.com", "ivan@example.com"}; !reflect.DeepEqual(emails, want) {
+		t.Errorf("emails = %v, want %v", emails, want)
+	}
+	want := []string{
+		`group "Б01-001": ivan@example.com is listed twice`,
+		`group "Б01-001" is listed twice`,
+		"group name is empty",
+	}
+	if !reflect.DeepEqual(problems, want) {
+		t.Errorf("problems = %q, want %q", problems, want)
+	}
+}
+
+func TestDiffRoster(t *testing.T) {
+	users := map[string]rosterMember{
+		"anna@example.com":  {id: 1, email: "anna@example.com"},
+		"ivan@example.com":  {id: 2, email: "ivan@example.com"},
+		"oleg@example.com":  {id: 3, email: "oleg@example.com"},
+		"petr@example.com":  {id: 4, email: "petr@example.com"},
+		"maria@example.com": {id: 5, email: "maria@example.com"},
+	}
+	group := func(emails ...string) map[string]bool {
+		m := make(map[string]bool)
+		for _, e := range emails {
+			m[e] = true
+		}
+		return m
+	}
+	change := func(c *pb.RosterChange) string {
+		return fmt.Sprintf("%s %d %s %s->%s", c.Action, c.UserId, c.Email, c.FromGroup, c.ToGroup)
+	}
+
+	cases := []struct {
+		name    string
+		names   []string
+		desired map[string]map[string]bool
+		current map[string]map[string]bool
+		want    []string
+	}{
+		{
+			name:    "nothing changes",
+			names:   []string{"A"},
+			desired: map[string]map[string]bool{"A": group("anna@example.com")},
+			current: map[string]map[string]bool{"A": group("anna@example.com")},
+		},
+		{
+			name:    "add to an empty group",
+			names:   []string{"A"},
+			desired: map[string]map[string]bool{"A": group("anna@example.com", "ivan@example.com")},
+			want:    []string{"add 1 anna@example.com ->A", "add 2 ivan@example.com ->A"},
+		},
+		{
+			name:    "remove",
+			names:   []string{"A"},
+			desired: map[string]map[string]bool{"A": group()},
+			current: map[string]map[string]bool{"A": group("oleg@example.com")},
+			want:    []string{"remove 3 oleg@example.com A->"},
+		},
+		{
+			name:    "leaving one group for another is a move",
+			names:   []string{"A", "B"},
+			desired: map[string]map[string]bool{"A": group(), "B": group("ivan@example.com")},
+			current: map[string]map[string]bool{"A": group("ivan@example.com"), "B": group()},
+			want:    []string{"move 2 ivan@example.com A->B"},
+		},
+		{
+			name:    "move plus an extra group",
+			names:   []string{"A", "B", "C"},
+			desired: map[string]map[string]bool{"A": group(), "B": group("petr@example.com"), "C": group("petr@example.com")},
+			current: map[string]map[string]bool{"A": group("petr@example.com")},
+			want:    []string{"move 4 petr@example.com A->B", "add 4 petr@example.com ->C"},
+		},
+		{
+			name:    "groups outside the roster are left alone",
+			names:   []string{"A"},
+			desired: map[string]map[string]bool{"A": group("maria@example.com")},
+			current: map[string]map[string]bool{"A": group(), "Z": group("anna@example.com")},
+			want:    []string{"add 5 maria@example.com ->A"},
+		},
+	}
+	for _, c := range cases {
+		var got []string
+		for _, ch := range diffRoster(c.names, c.desired, c.current, users) {
+			got = append(got, change(ch))
+		}
+		if strings.Join(got, "; ") != strings.Join(c.want, "; ") {
+			t.Errorf("%s:\n got %q\nwant %q", c.name, got, c.want)
+		}
+	}
+}
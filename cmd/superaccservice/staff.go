@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	pb "rubr/proto/superacc"
+)
+
+// Роли в составе преподавателей группы по дисциплине, как в типе staff_role
+const (
+	staffSeminarist = "seminarist"
+	staffAssistant  = "assistant"
+)
+
+func validStaffRole(role string) bool {
+	return role == staffSeminarist || role == staffAssistant
+}
+
+// groupDiscipline возвращает id назначения дисциплины группе
+func groupDiscipline(ctx context.Context, tx *sql.Tx, disciplineID, groupID int32) (int32, error) {
+	var id int32
+	err := tx.QueryRowContext(ctx, "SELECT id FROM groups_in_disciplines WHERE discipline_id = $1 AND group_id = $2 LIMIT 1",
+		disciplineID, groupID).Scan(&id)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("discipline %d is not attached to group %d", disciplineID, groupID)
+	}
+	return id, err
+}
+
+// addStaff добавляет преподавателя к назначению; повторное добавление ничего не меняет.
+// Возвращает true, если запись создана
+func addStaff(ctx context.Context, tx *sql.Tx, groupDisciplineID, userID int32, role string) (bool, error) {
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO discipline_staff (group_discipline_id, user_id, role) VALUES ($1, $2, $3)
+		ON CONFLICT (group_discipline_id, user_id, role) DO NOTHING`, groupDisciplineID, userID, role)
+	if err != nil {
+		return false, err
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// AddDisciplineStaff добавляет семинариста или ассистента к группе по дисциплине
+func (r *Repository) AddDisciplineStaff(ctx context.Context, disciplineID, groupID, userID int32, role string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkDisciplinesWritable(ctx, tx, []int32{disciplineID}); err != nil {
+		return err
+	}
+	gdID, err := groupDiscipline(ctx, tx, disciplineID, groupID)
+	if err != nil {
+		return err
+	}
+	if _, err := addStaff(ctx, tx, gdID, userID, role); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RemoveDisciplineStaff убирает преподавателя из группы по дисциплине; уже назначенные
+// ему работы остаются за ним
+func (r *Repository) RemoveDisciplineStaff(ctx context.Context, disciplineID, groupID, userID int32, role string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkDisciplinesWritable(ctx, tx, []int32{disciplineID}); err != nil {
+		return err
+	}
+	gdID, err := groupDiscipline(ctx, tx, disciplineID, groupID)
+	if err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, "DELETE FROM discipline_staff WHERE group_discipline_id = $1 AND user_id = $2 AND role = $3",
+		gdID, userID, role)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("user %d is not a %s of this group", userID, role)
+	}
+	return tx.Commit()
+}
+
+// ListDisciplineStaff возвращает состав преподавателей группы; при disciplineID = 0 — по
+// всем её дисциплинам
+func (r *Repository) ListDisciplineStaff(ctx context.Context, groupID, disciplineID int32) ([]*pb.StaffMember, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT gd.discipline_id, d.name, u.id, u.name, u.surname, COALESCE(u.patronymic, ''), u.email, ds.role
+		FROM discipline_staff ds
+		JOIN groups_in_disciplines gd ON gd.id = ds.group_discipline_id
+		JOIN disciplines d ON d.id = gd.discipline_id
+		JOIN users u ON u.id = ds.user_id
+		WHERE gd.group_id = $1 AND ($2 = 0 OR gd.discipline_id = $2)
+		ORDER BY d.name, ds.role DESC, u.surname, u.name`, groupID, disciplineID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var staff []*pb.StaffMember
+	for rows.Next() {
+		m := &pb.StaffMember{GroupId: groupID}
+		var name, surname, patronymic string
+		if err := rows.Scan(&m.DisciplineId, &m.DisciplineName, &m.UserId, &name, &surname, &patronymic, &m.Email, &m.Role); err != nil {
+			return nil, err
+		}
+		m.Fio = fmt.Sprintf("%s %s %s", name, surname, patronymic)
+		if patronymic == "" {
+			m.Fio = fmt.Sprintf("%s %s", name, surname)
+		}
+		staff = append(staff, m)
+	}
+	return staff, rows.Err()
+}
+
+func (s *Service) ListDisciplineStaff(ctx context.Context, req *pb.ListDisciplineStaffRequest) (*pb.ListDisciplineStaffResponse, error) {
+	if req.GroupId <= 0 {
+		return &pb.ListDisciplineStaffResponse{Message: "invalid group ID", Success: false}, nil
+	}
+	staff, err := s.repo.ListDisciplineStaff(ctx, req.GroupId, req.DisciplineId)
+	if err != nil {
+		log.Printf("Failed to list staff of group %d: %v", req.GroupId, err)
+		return &pb.ListDisciplineStaffResponse{Message: "failed to list staff", Success: false}, nil
+	}
+	return &pb.ListDisciplineStaffResponse{Success: true, Staff: staff}, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"rubr/internal/auth"
+	pb "rubr/proto/superacc"
+)
+
+// Состояния семестра, как в типе term_state
+const (
+	termUpcoming = "upcoming"
+	termActive   = "active"
+	termArchived = "archived"
+)
+
+const termDateLayout = "2006-01-02"
+
+// resolveTerm возвращает семестр по id, а при 0 — активный семестр
+func resolveTerm(ctx context.Context, q auth.Querier, termID int32) (int32, string, error) {
+	var id int32
+	var state string
+	var err error
+	if termID == 0 {
+		err = q.QueryRowContext(ctx, "SELECT id, state FROM terms WHERE state = 'active'").Scan(&id, &state)
+		if err == sql.ErrNoRows {
+			return 0, "", errors.New("no active term")
+		}
+	} else {
+		err = q.QueryRowContext(ctx, "SELECT id, state FROM terms WHERE id = $1", termID).Scan(&id, &state)
+		if err == sql.ErrNoRows {
+			return 0, "", fmt.Errorf("term %d not found", termID)
+		}
+	}
+	return id, state, err
+}
+
+// checkDisciplinesWritable отклоняет изменения, если хотя бы одна из дисциплин
+// относится к архивному семестру
+func checkDisciplinesWritable(ctx context.Context, q auth.Querier, disciplineIDs []int32) error {
+	var id int32
+	var term string
+	err := q.QueryRowContext(ctx, `
+		SELECT d.id, tm.name FROM disciplines d
+		JOIN terms tm ON d.term_id = tm.id
+		WHERE d.id = ANY($1) AND tm.state = 'archived'
+		LIMIT 1`, pq.Array(disciplineIDs)).Scan(&id, &term)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("discipline %d belongs to archived term %s and is read-only", id, term)
+}
+
+func (r *Repository) CreateTerm(ctx context.Context, name string, startsOn, endsOn time.Time) (int32, error) {
+	var id int32
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO terms (name, starts_on, ends_on) VALUES ($1, $2, $3) RETURNING id`,
+		name, startsOn, endsOn).Scan(&id)
+	return id, err
+}
+
+func (r *Repository) ListTerms(ctx context.Context) ([]*pb.Term, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, starts_on, ends_on, state FROM terms ORDER BY starts_on DESC, id DESC")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var terms []*pb.Term
+	for rows.Next() {
+		var t pb.Term
+		var startsOn, endsOn time.Time
+		if err := rows.Scan(&t.Id, &t.Name, &startsOn, &endsOn, &t.State); err != nil {
+			return nil, err
+		}
+		t.StartsOn = startsOn.Format(termDateLayout)
+		t.EndsOn = endsOn.Format(termDateLayout)
+		terms = append(terms, &t)
+	}
+	return terms, rows.Err()
+}
+
+// SetTermState меняет состояние семестра; активировать можно, только если другого
+// активного семестра нет
+func (r *Repository) SetTermState(ctx context.Context, termID int32, state string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if state == termActive {
+		var activeName string
+		err := tx.QueryRowContext(ctx, "SELECT name FROM terms WHERE state = 'active' AND id <> $1 FOR UPDATE", termID).Scan(&activeName)
+		if err == nil {
+			return fmt.Errorf("term %s is already active; archive it first", activeName)
+		}
+		if err != sql.ErrNoRows {
+			return err
+		}
+	}
+	result, err := tx.ExecContext(ctx, "UPDATE terms SET state = $1 WHERE id = $2", state, termID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("term %d not found", termID)
+	}
+	return tx.Commit()
+}
+
+func (s *Service) CreateTerm(ctx context.Context, req *pb.CreateTermRequest) (*pb.CreateTermResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return &pb.CreateTermResponse{Message: "term name is required", Success: false}, nil
+	}
+	startsOn, err := time.Parse(termDateLayout, req.StartsOn)
+	if err != nil {
+		return &pb.CreateTermResponse{Message: "starts_on must be YYYY-MM-DD", Success: false}, nil
+	}
+	endsOn, err := time.Parse(termDateLayout, req.EndsOn)
+	if err != nil {
+		return &pb.CreateTermResponse{Message: "ends_on must be YYYY-MM-DD", Success: false}, nil
+	}
+	if !endsOn.After(startsOn) {
+		return &pb.CreateTermResponse{Message: "the term must end after it starts", Success: false}, nil
+	}
+	id, err := s.repo.CreateTerm(ctx, name, startsOn, endsOn)
+	if err != nil {
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Code == "23505" {
+			return &pb.CreateTermResponse{Message: fmt.Sprintf("term %s already exists", name), Success: false}, nil
+		}
+		log.Printf("Failed to create term %s: %v", name, err)
+		return &pb.CreateTermResponse{Message: "failed to create term", Success: false}, nil
+	}
+	return &pb.CreateTermResponse{Message: "Term created", Success: true, TermId: id}, nil
+}
+
+func (s *Service) ListTerms(ctx context.Context, req *pb.ListTermsRequest) (*pb.ListTermsResponse, error) {
+	terms, err := s.repo.ListTerms(ctx)
+	if err != nil {
+		log.Printf("Failed to list terms: %v", err)
+		return &pb.ListTermsResponse{Message: "failed to list terms", Success: false}, nil
+	}
+	return &pb.ListTermsResponse{Success: true, Terms: terms}, nil
+}
+
+func (s *Service) SetTermState(ctx context.Context, req *pb.SetTermStateRequest) (*pb.SetTermStateResponse, error) {
+	switch req.State {
+	case termUpcoming, termActive, termArchived:
+	default:
+		return &pb.SetTermStateResponse{Message: fmt.Sprintf("unknown term state %q", req.State), Success: false}, nil
+	}
+	if err := s.repo.SetTermState(ctx, req.TermId, req.State); err != nil {
+		log.Printf("Failed to set state of term %d: %v", req.TermId, err)
+		return &pb.SetTermStateResponse{Message: err.Error(), Success: false}, nil
+	}
+	caller, _ := auth.FromContext(ctx)
+	log.Printf("Superaccount %d set term %d to %s", caller.UserID, req.TermId, req.State)
+	return &pb.SetTermStateResponse{Message: "Term state updated", Success: true}, nil
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+	"rubr/internal/auth"
+	pb "rubr/proto/superacc"
+)
+
+const (
+	defaultUsersPageSize = 50
+	maxUsersPageSize     = 200
+)
+
+// userSortKeys — выражения сортировки; вместе с u.id они дают строгий порядок для курсора
+var userSortKeys = map[string]string{
+	"fio":   "lower(u.surname || ' ' || u.name || ' ' || COALESCE(u.patronymic, ''))",
+	"email": "lower(u.email)",
+	"role":  "u.role::text",
+	"id":    "''",
+}
+
+// userFilter — параметры одной страницы списка пользователей
+type userFilter struct {
+	pageSize     int
+	cursor       string
+	after        *userCursor // разобранный cursor
+	roles        []string
+	groupID      int32
+	groupName    string
+	disciplineID int32
+	search       string
+	sort         string
+	descending   bool
+}
+
+// userCursor — позиция последней строки страницы; сортировка сохраняется, чтобы курсор
+// нельзя было применить к другому порядку
+type userCursor struct {
+	Sort string `json:"s"`
+	Desc bool   `json:"d"`
+	Key  string `json:"k"`
+	ID   int32  `json:"i"`
+}
+
+func encodeUserCursor(c userCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeUserCursor(s string) (userCursor, error) {
+	var c userCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil {
+		return c, fmt.Errorf("malformed cursor")
+	}
+	return c, nil
+}
+
+// normalize подставляет значения по умолчанию и проверяет фильтр
+func (f *userFilter) normalize() error {
+	if f.pageSize <= 0 {
+		f.pageSize = defaultUsersPageSize
+	}
+	if f.pageSize > maxUsersPageSize {
+		f.pageSize = maxUsersPageSize
+	}
+	if f.sort == "" {
+		f.sort = "fio"
+	}
+	if _, ok := userSortKeys[f.sort]; !ok {
+		return fmt.Errorf("unknown sort %q", f.sort)
+	}
+	validRoles := make(map[string]bool)
+	for _, role := range auth.AllRoles {
+		validRoles[role] = true
+	}
+	for _, role := range f.roles {
+		if !validRoles[role] {
+			return fmt.Errorf("unknown role %q", role)
+		}
+	}
+	if f.cursor != "" {
+		c, err := decodeUserCursor(f.cursor)
+		if err != nil {
+			return err
+		}
+		if c.Sort != f.sort || c.Desc != f.descending {
+			return fmt.Errorf("cursor does not match sort order")
+		}
+		f.after = &c
+	}
+	return nil
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// ListUsers возвращает страницу пользователей, курсор следующей страницы и общее число
+// пользователей по фильтрам; фильтр должен пройти normalize
+func (r *Repository) ListUsers(ctx context.Context, f userFilter) ([]*pb.User, string, int32, error) {
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where := []string{"TRUE"}
+	if len(f.roles) > 0 {
+		where = append(where, "u.role::text = ANY("+arg(pq.Array(f.roles))+")")
+	}
+	if f.groupID != 0 {
+		where = append(where, "EXISTS (SELECT 1 FROM users_in_groups ug WHERE ug.user_id = u.id AND ug.group_id = "+arg(f.groupID)+")")
+	}
+	if f.groupName != "" {
+		where = append(where, `EXISTS (
+			SELECT 1 FROM users_in_groups ug JOIN student_groups sg ON sg.id = ug.group_id
+			WHERE ug.user_id = u.id AND sg.name = `+arg(f.groupName)+")")
+	}
+	if f.disciplineID != 0 {
+		p := arg(f.disciplineID)
+		where = append(where, `(
+			EXISTS (SELECT 1 FROM groups_in_disciplines gd JOIN users_in_groups ug ON ug.group_id = gd.group_id
+			        WHERE gd.discipline_id = `+p+` AND ug.user_id = u.id)
+			OR EXISTS (SELECT 1 FROM groups_in_disciplines gd
+			           JOIN discipline_staff ds ON ds.group_discipline_id = gd.id
+			           WHERE gd.discipline_id = `+p+` AND ds.user_id = u.id)
+			OR EXISTS (SELECT 1 FROM discipline_lecturers dl WHERE dl.discipline_id = `+p+` AND dl.user_id = u.id))`)
+	}
+	for _, word := range strings.Fields(f.search) {
+		p := arg("%" + escapeLike(word) + "%")
+		where = append(where, fmt.Sprintf("(u.name ILIKE %[1]s OR u.surname ILIKE %[1]s OR u.patronymic ILIKE %[1]s OR u.email ILIKE %[1]s)", p))
+	}
+
+	var total int32
+	countQuery := "SELECT COUNT(*) FROM users u WHERE " + strings.Join(where, " AND ")
+	if err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, "", 0, err
+	}
+
+	key := userSortKeys[f.sort]
+	op, dir := ">", "ASC"
+	if f.descending {
+		op, dir = "<", "DESC"
+	}
+	if f.after != nil {
+		where = append(where, fmt.Sprintf("(%s, u.id) %s (%s, %s)", key, op, arg(f.after.Key), arg(f.after.ID)))
+	}
+
+	query := fmt.Sprintf(`
+		SELECT u.id, u.name, u.surname, COALESCE(u.patronymic, ''), u.email, u.role, u.deactivated_at IS NULL, %s
+		FROM users u
+		WHERE %s
+		ORDER BY 8 %s, u.id %s
+		LIMIT %s`, key, strings.Join(where, " AND "), dir, dir, arg(f.pageSize+1))
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, "", 0, err
+	}
+	defer rows.Close()
+
+	var users []*pb.User
+	var lastKey string
+	nextCursor := ""
+	for rows.Next() {
+		if len(users) == f.pageSize {
+			last := users[len(users)-1]
+			nextCursor = encodeUserCursor(userCursor{Sort: f.sort, Desc: f.descending, Key: lastKey, ID: last.Id})
+			break
+		}
+		var id int32
+		var name, surname, patronymic, email, role string
+		var active bool
+		if err := rows.Scan(&id, &name, &surname, &patronymic, &email, &role, &active, &lastKey); err != nil {
+			return nil, "", 0, err
+		}
+		fio := fmt.Sprintf("%s %s %s", name, surname, patronymic)
+		if patronymic == "" {
+			fio = fmt.Sprintf("%s %s", name, surname)
+		}
+		users = append(users, &pb.User{
+			Id:     id,
+			Fio:    fio,
+			Email:  email,
+			Group:  f.groupName,
+			Status: role,
+			Active: active,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", 0, err
+	}
+	return users, nextCursor, total, nil
+}
+
+func (s *Service) ListAllUsers(ctx context.Context, req *pb.ListAllUsersRequest) (*pb.ListAllUsersResponse, error) {
+	filter := userFilter{
+		pageSize:     int(req.PageSize),
+		cursor:       req.Cursor,
+		roles:        req.Roles,
+		groupID:      req.GroupId,
+		disciplineID: req.DisciplineId,
+		search:       req.Search,
+		sort:         req.Sort,
+		descending:   req.Descending,
+	}
+	if err := filter.normalize(); err != nil {
+		return &pb.ListAllUsersResponse{Message: err.Error(), Success: false}, nil
+	}
+	users, nextCursor, total, err := s.repo.ListUsers(ctx, filter)
+	if err != nil {
+		log.Printf("Failed to list users: %v", err)
+		return &pb.ListAllUsersResponse{Message: "failed to list users", Success: false}, nil
+	}
+	return &pb.ListAllUsersResponse{Success: true, Users: users, NextCursor: nextCursor, TotalCount: total}, nil
+}
+
+func (s *Service) ListUsersByGroup(ctx context.Context, req *pb.ListUsersByGroupRequest) (*pb.ListUsersByGroupResponse, error) {
+	if req.GroupName == "" {
+		return &pb.ListUsersByGroupResponse{Message: "group name is required", Success: false}, nil
+	}
+	filter := userFilter{
+		pageSize:   int(req.PageSize),
+		cursor:     req.Cursor,
+		groupName:  req.GroupName,
+		search:     req.Search,
+		sort:       req.Sort,
+		descending: req.Descending,
+	}
+	if err := filter.normalize(); err != nil {
+		return &pb.ListUsersByGroupResponse{Message: err.Error(), Success: false}, nil
+	}
+	users, nextCursor, total, err := s.repo.ListUsers(ctx, filter)
+	if err != nil {
+		log.Printf("Failed to list users of group %s: %v", req.GroupName, err)
+		return &pb.ListUsersByGroupResponse{Message: "failed to list users", Success: false}, nil
+	}
+	return &pb.ListUsersByGroupResponse{Success: true, Users: users, NextCursor: nextCursor, TotalCount: total}, nil
+}
@@ -7,7 +7,9 @@ import (
 	"log"
 	"net"
 	"os"
+	"rubr/internal/auth"
 	"rubr/internal/userservice"
+	notifypb "rubr/proto/notification"
 	pb "rubr/proto/user"
 	"strconv"
 )
@@ -39,8 +41,41 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
-	pb.RegisterUserServiceServer(s, &userservice.Server{Db: db})
+	keys, err := auth.KeySetFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	tokens := auth.NewTokenManager(keys, auth.AccessTokenTTL)
+	authenticator := auth.NewAuthenticator(tokens, db)
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(authenticator.UnaryInterceptor()),
+		grpc.StreamInterceptor(authenticator.StreamInterceptor()),
+	)
+
+	// Письма пользователям отправляет notificationservice
+	notificationAddr := os.Getenv("NOTIFICATION_ADDR")
+	if notificationAddr == "" {
+		notificationAddr = "notificationservice:50056"
+	}
+	notifyConn, err := grpc.Dial(notificationAddr, grpc.WithInsecure(), auth.WithServiceAuth(tokens, "userservice"))
+	if err != nil {
+		log.Fatalf("Failed to connect to notificationservice: %v", err)
+	}
+	defer notifyConn.Close()
+
+	// Например, TOTP_REQUIRED_ROLES=lecturer,superaccount
+	totpRequiredRoles, err := userservice.ParseTotpRequiredRoles(os.Getenv("TOTP_REQUIRED_ROLES"))
+	if err != nil {
+		log.Fatalf("Invalid TOTP_REQUIRED_ROLES: %v", err)
+	}
+
+	pb.RegisterUserServiceServer(s, &userservice.Server{
+		Db:                db,
+		Tokens:            tokens,
+		Notify:            notifypb.NewNotificationServiceClient(notifyConn),
+		TotpRequiredRoles: totpRequiredRoles,
+	})
 
 	log.Println("UserService starting on :50051")
 	if err := s.Serve(lis); err != nil {
@@ -8,6 +8,7 @@ import (
 	"log"
 	"net"
 	"os"
+	"rubr/internal/auth"
 	"rubr/internal/workassignmentservice"
 	Pb "rubr/proto/workassignment"
 	"strconv"
@@ -43,7 +44,17 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
+	keys, err := auth.KeySetFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	tokens := auth.NewTokenManager(keys, auth.AccessTokenTTL)
+	authenticator := auth.NewAuthenticator(tokens, db)
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(authenticator.UnaryInterceptor()),
+		grpc.StreamInterceptor(authenticator.StreamInterceptor()),
+	)
 	Pb.RegisterWorkAssignmentServiceServer(s, &workassignmentservice.Server{Db: db})
 
 	log.Println("WorkAssignmentService starting on :50054")
@@ -0,0 +1,310 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	gradepb "rubr/proto/grade"
+	rubricpb "rubr/proto/rubric"
+	superaccpb "rubr/proto/superacc"
+	workpb "rubr/proto/work"
+)
+
+// auditSpec описывает, как журналировать изменяющий метод: какой объект он меняет,
+// из какого поля запроса берётся id объекта (поле ответа created — для созданных
+// объектов) и каким запросом снимается состояние объекта до и после вызова.
+// Путь к полю может проходить через вложенные сообщения: "assignments.work_id";
+// несколько путей перечисляются через запятую
+type auditSpec struct {
+	entity   string
+	field    string
+	created  string
+	snapshot string // SELECT по id = ANY($1); пустой — без снимков
+}
+
+// Снимки объектов; пароли и токены в журнал не попадают
+const (
+	snapshotUser = `SELECT id, email, name, surname, patronymic, role, email_verified, deactivated_at
+		FROM users WHERE id = ANY($1::bigint[])`
+	snapshotUserByEmail = `SELECT id, email, name, surname, patronymic, role, email_verified, deactivated_at,
+		       ARRAY(SELECT ug.group_id FROM users_in_groups ug WHERE ug.user_id = u.id ORDER BY 1) AS groups
+		FROM users u WHERE lower(email) = ANY(SELECT lower(e) FROM unnest($1::text[]) e)`
+	snapshotUserHistory = `SELECT id, email, role, deactivated_at,
+		       ARRAY(SELECT ug.group_id FROM users_in_groups ug WHERE ug.user_id = u.id ORDER BY 1) AS groups,
+		       ARRAY(SELECT sw.id FROM student_works sw WHERE sw.student_id = u.id ORDER BY 1) AS works
+		FROM users u WHERE id = ANY($1::bigint[])`
+	snapshotGroup = `SELECT sg.id, sg.name, sg.description,
+		       ARRAY(SELECT ug.user_id FROM users_in_groups ug WHERE ug.group_id = sg.id ORDER BY 1) AS members
+		FROM student_groups sg WHERE sg.id = ANY($1::bigint[])`
+	snapshotGroupByName = `SELECT sg.id, sg.name,
+		       ARRAY(SELECT ug.user_id FROM users_in_groups ug WHERE ug.group_id = sg.id ORDER BY 1) AS members
+		FROM student_groups sg WHERE sg.name = ANY($1::text[]) ORDER BY sg.id`
+	snapshotGroupDisciplines = `SELECT gd.id, gd.group_id, gd.discipline_id,
+		       ARRAY(SELECT ds.role::text || ':' || ds.user_id FROM discipline_staff ds
+		             WHERE ds.group_discipline_id = gd.id ORDER BY 1) AS staff
+		FROM groups_in_disciplines gd WHERE gd.group_id = ANY($1::bigint[]) ORDER BY gd.id`
+	snapshotDiscipline = `SELECT d.id, d.name, d.term_id,
+		       ARRAY(SELECT dl.role::text || ':' || dl.user_id FROM discipline_lecturers dl
+		             WHERE dl.discipline_id = d.id ORDER BY 1) AS lecturers
+		FROM disciplines d WHERE d.id = ANY($1::bigint[])`
+	snapshotTerm = `SELECT id, name, starts_on, ends_on, state FROM terms WHERE id = ANY($1::bigint[])`
+	snapshotTask = `SELECT id, title, description, deadline, group_id, discipline_id, content_url
+		FROM tasks WHERE id = ANY($1::bigint[])`
+	snapshotTaskRubric = `SELECT cg.id, cg.task_id, cg.group_name, cg.block_flag,
+		       ARRAY(SELECT c.id FROM criteria c WHERE c.criteria_group_id = cg.id ORDER BY 1) AS criteria
+		FROM criteria_groups cg WHERE cg.task_id = ANY($1::bigint[]) ORDER BY cg.id`
+	snapshotCriteriaGroup = `SELECT id, task_id, group_name, block_flag FROM criteria_groups WHERE id = ANY($1::bigint[])`
+	snapshotCriterion     = `SELECT * FROM criteria WHERE id = ANY($1::bigint[])`
+	snapshotWork          = `SELECT sw.id, sw.student_id, sw.task_id, sw.status, sw.seminarist_id, sw.assistant_id,
+		       ARRAY(SELECT json_build_object('criterion_id', m.criteria_id, 'mark', m.mark, 'comment', m.comment)
+		             FROM student_criteria_marks m WHERE m.student_work_id = sw.id ORDER BY m.criteria_id) AS marks
+		FROM student_works sw WHERE sw.id = ANY($1::bigint[])`
+)
+
+// auditedMethods — административные изменения и изменения оценок. Удаление
+// пользователя журналируется без снимков: иначе персональные данные пережили бы его
+var auditedMethods = map[string]auditSpec{
+	superaccpb.SuperAccService_UpdateUserRole_FullMethodName:             {entity: "user", field: "user_id", snapshot: snapshotUser},
+	superaccpb.SuperAccService_UnlockUser_FullMethodName:                 {entity: "user", field: "user_id", snapshot: snapshotUser},
+	superaccpb.SuperAccService_DeactivateUser_FullMethodName:             {entity: "user", field: "user_id", snapshot: snapshotUser},
+	superaccpb.SuperAccService_ReactivateUser_FullMethodName:             {entity: "user", field: "user_id", snapshot: snapshotUser},
+	superaccpb.SuperAccService_MergeUsers_FullMethodName:                 {entity: "user", field: "source_user_id,target_user_id", snapshot: snapshotUserHistory},
+	superaccpb.SuperAccService_PurgeUser_FullMethodName:                  {entity: "user", field: "user_id"},
+	superaccpb.SuperAccService_AddUser_FullMethodName:                    {entity: "user", field: "email", snapshot: snapshotUserByEmail},
+	superaccpb.SuperAccService_RemoveUser_FullMethodName:                 {entity: "user", field: "email", snapshot: snapshotUserByEmail},
+	superaccpb.SuperAccService_ResendInvitation_FullMethodName:           {entity: "user", field: "user_id", snapshot: snapshotUser},
+	superaccpb.SuperAccService_RevokeInvitation_FullMethodName:           {entity: "invitation", field: "invitation_id"},
+	superaccpb.SuperAccService_ManageGroup_FullMethodName:                {entity: "group", field: "group_id", snapshot: snapshotGroup},
+	superaccpb.SuperAccService_ManageGroupEntity_FullMethodName:          {entity: "group", field: "group_id", created: "group_id", snapshot: snapshotGroup},
+	superaccpb.SuperAccService_ManageDiscipline_FullMethodName:           {entity: "group_discipline", field: "group_id", snapshot: snapshotGroupDisciplines},
+	superaccpb.SuperAccService_ManageDisciplineEntity_FullMethodName:     {entity: "group_discipline", field: "group_id", snapshot: snapshotGroupDisciplines},
+	superaccpb.SuperAccService_DetachDisciplinesFromGroup_FullMethodName: {entity: "group_discipline", field: "group_id", snapshot: snapshotGroupDisciplines},
+	superaccpb.SuperAccService_CreateDiscipline_FullMethodName:           {entity: "discipline", created: "discipline_id", snapshot: snapshotDiscipline},
+	superaccpb.SuperAccService_DeleteDiscipline_FullMethodName:           {entity: "discipline", field: "discipline_ids", snapshot: snapshotDiscipline},
+	superaccpb.SuperAccService_ManageDisciplineLecturer_FullMethodName:   {entity: "discipline", field: "discipline_id", snapshot: snapshotDiscipline},
+	superaccpb.SuperAccService_CreateTerm_FullMethodName:                 {entity: "term", created: "term_id", snapshot: snapshotTerm},
+	superaccpb.SuperAccService_SetTermState_FullMethodName:               {entity: "term", field: "term_id", snapshot: snapshotTerm},
+	superaccpb.SuperAccService_ReconcileRoster_FullMethodName:            {entity: "group", field: "groups.group_name", snapshot: snapshotGroupByName},
+	superaccpb.SuperAccService_ImportUsers_FullMethodName:                {entity: "directory"},
+	superaccpb.SuperAccService_ImportDirectory_FullMethodName:            {entity: "directory"},
+
+	workpb.WorkService_CreateWork_FullMethodName:                   {entity: "task", created: "task_id", snapshot: snapshotTask},
+	workpb.WorkService_DeleteTask_FullMethodName:                   {entity: "task", field: "task_id", snapshot: snapshotTask},
+	workpb.WorkService_SetTaskTitle_FullMethodName:                 {entity: "task", field: "task_id", snapshot: snapshotTask},
+	workpb.WorkService_SetTaskDescription_FullMethodName:           {entity: "task", field: "task_id", snapshot: snapshotTask},
+	workpb.WorkService_SetTaskDeadline_FullMethodName:              {entity: "task", field: "task_id", snapshot: snapshotTask},
+	workpb.WorkService_UpdateTaskGroupAndDiscipline_FullMethodName: {entity: "task", field: "task_id", snapshot: snapshotTask},
+	workpb.WorkService_CloneDiscipline_FullMethodName:              {entity: "discipline", created: "discipline_id", snapshot: snapshotDiscipline},
+	workpb.WorkService_UpdateWork_FullMethodName:                   {entity: "work", field: "work_id", snapshot: snapshotWork},
+	workpb.WorkService_AssignAssistantsToWorks_FullMethodName:      {entity: "work", field: "assignments.work_id", snapshot: snapshotWork},
+
+	gradepb.GradingService_SetBlockingCriteriaMark_FullMethodName: {entity: "work", field: "work_id", snapshot: snapshotWork},
+	gradepb.GradingService_SetMainCriteriaMark_FullMethodName:     {entity: "work", field: "work_id", snapshot: snapshotWork},
+	gradepb.GradingService_UpdateWorkStatus_FullMethodName:        {entity: "work", field: "work_id", snapshot: snapshotWork},
+
+	rubricpb.RubricService_CreateNewBlockingCriteria_FullMethodName:   {entity: "criteria_group", created: "criteria_group_id", snapshot: snapshotCriteriaGroup},
+	rubricpb.RubricService_CreateNewCriteriaGroup_FullMethodName:      {entity: "criteria_group", created: "criteria_group_id", snapshot: snapshotCriteriaGroup},
+	rubricpb.RubricService_CreateCriteriaGroup_FullMethodName:         {entity: "criteria_group", created: "group_id", snapshot: snapshotCriteriaGroup},
+	rubricpb.RubricService_DeleteCriteriaGroup_FullMethodName:         {entity: "criteria_group", field: "group_id", snapshot: snapshotCriteriaGroup},
+	rubricpb.RubricService_DeleteTaskBlockingCriterias_FullMethodName: {entity: "rubric", field: "task_id", snapshot: snapshotTaskRubric},
+	rubricpb.RubricService_CreateNewMainCriteria_FullMethodName:       {entity: "criterion", created: "criteria_id", snapshot: snapshotCriterion},
+	rubricpb.RubricService_CreateCriterion_FullMethodName:             {entity: "criterion", created: "criterion_id", snapshot: snapshotCriterion},
+	rubricpb.RubricService_CreateCriteriaDescription_FullMethodName:   {entity: "criterion", field: "criteria_id", snapshot: snapshotCriterion},
+	rubricpb.RubricService_SetCriteriaWeight_FullMethodName:           {entity: "criterion", field: "criteria_id", snapshot: snapshotCriterion},
+	rubricpb.RubricService_UpdateCriterionWeight_FullMethodName:       {entity: "criterion", field: "criterion_id", snapshot: snapshotCriterion},
+	rubricpb.RubricService_UpdateCriterionComment_FullMethodName:      {entity: "criterion", field: "criterion_id", snapshot: snapshotCriterion},
+	rubricpb.RubricService_DeleteBlockingCriteria_FullMethodName:      {entity: "criterion", field: "criteria_id", snapshot: snapshotCriterion},
+	rubricpb.RubricService_DeleteCriterion_FullMethodName:             {entity: "criterion", field: "criterion_id", snapshot: snapshotCriterion},
+}
+
+// auditEntry — запись журнала изменений
+type auditEntry struct {
+	Identity  *Identity
+	Method    string
+	Entity    string
+	TargetIDs []string
+	Before    json.RawMessage
+	After     json.RawMessage
+	Succeeded bool
+	Code      string
+}
+
+// fieldValues собирает значения поля по пути из сообщения; неизвестное поле даёт пустой
+// список
+func fieldValues(msg interface{}, path string) []string {
+	m, ok := msg.(proto.Message)
+	if !ok || path == "" || m == nil {
+		return nil
+	}
+	var values []string
+	for _, p := range strings.Split(path, ",") {
+		values = append(values, collectField(m.ProtoReflect(), strings.Split(p, "."))...)
+	}
+	return values
+}
+
+func collectField(m protoreflect.Message, path []string) []string {
+	fd := m.Descriptor().Fields().ByName(protoreflect.Name(path[0]))
+	if fd == nil {
+		return nil
+	}
+	value := m.Get(fd)
+	var values []protoreflect.Value
+	if fd.IsList() {
+		list := value.List()
+		for i := 0; i < list.Len(); i++ {
+			values = append(values, list.Get(i))
+		}
+	} else {
+		values = []protoreflect.Value{value}
+	}
+
+	var out []string
+	for _, v := range values {
+		if len(path) > 1 {
+			if fd.Message() != nil {
+				out = append(out, collectField(v.Message(), path[1:])...)
+			}
+			continue
+		}
+		// Нулевой id и пустая строка — значение не задано
+		if s := fmt.Sprint(v.Interface()); s != "" && s != "0" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// callFailed сообщает, закончился ли вызов неудачей: по ошибке gRPC или по полям
+// success и error ответа
+func callFailed(resp interface{}, err error) bool {
+	if err != nil {
+		return true
+	}
+	if r, ok := resp.(interface{ GetError() string }); ok && r.GetError() != "" {
+		return true
+	}
+	if r, ok := resp.(interface{ GetSuccess() bool }); ok && !r.GetSuccess() {
+		return true
+	}
+	return false
+}
+
+// takeSnapshot возвращает состояние объектов в виде JSON-массива строк
+func (a *Authenticator) takeSnapshot(ctx context.Context, spec auditSpec, ids []string) json.RawMessage {
+	var snapshot []byte
+	err := a.db.QueryRowContext(context.WithoutCancel(ctx),
+		`SELECT COALESCE(json_agg(row_to_json(s)), '[]'::json) FROM (`+spec.snapshot+`) s`, pq.Array(ids)).Scan(&snapshot)
+	if err != nil {
+		log.Printf("Failed to snapshot %s %v: %v", spec.entity, ids, err)
+		return nil
+	}
+	return snapshot
+}
+
+// recordAuditEntry дописывает запись в audit_log. Ошибка записи не отменяет вызов
+func (a *Authenticator) recordAuditEntry(ctx context.Context, e *auditEntry) {
+	var impersonator interface{}
+	if e.Identity.ImpersonatorID > 0 {
+		impersonator = e.Identity.ImpersonatorID
+	}
+	var before, after interface{}
+	if e.Before != nil {
+		before = []byte(e.Before)
+	}
+	if e.After != nil {
+		after = []byte(e.After)
+	}
+	_, err := a.db.ExecContext(context.WithoutCancel(ctx), `
+		INSERT INTO audit_log (actor_id, actor_role, impersonator_id, method, entity, target_ids, before, after, succeeded, status_code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		e.Identity.UserID, e.Identity.Role, impersonator, e.Method, e.Entity, pq.Array(e.TargetIDs),
+		before, after, e.Succeeded, e.Code)
+	if err != nil {
+		log.Printf("Failed to record audit entry for %s by %d: %v", e.Method, e.Identity.UserID, err)
+	}
+}
+
+// boolField возвращает логическое поле сообщения по имени; ok = false, если такого
+// поля нет
+func boolField(msg interface{}, name string) (value, ok bool) {
+	m, isMsg := msg.(proto.Message)
+	if !isMsg || m == nil {
+		return false, false
+	}
+	r := m.ProtoReflect()
+	fd := r.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if fd == nil || fd.Kind() != protoreflect.BoolKind || fd.IsList() {
+		return false, false
+	}
+	return r.Get(fd).Bool(), true
+}
+
+// nothingWritten сообщает, что успешный вызов ничего не изменил: это пробный запуск
+// (dry_run в запросе или ответе) или ответ сообщает applied/committed = false.
+// Неудачные вызовы в журнал попадают как обычно
+func nothingWritten(req, resp interface{}, failed bool) bool {
+	if dry, _ := boolField(req, "dry_run"); dry {
+		return true
+	}
+	if dry, _ := boolField(resp, "dry_run"); dry {
+		return true
+	}
+	if failed {
+		return false
+	}
+	for _, name := range []string{"applied", "committed"} {
+		if done, ok := boolField(resp, name); ok && !done {
+			return true
+		}
+	}
+	return false
+}
+
+// audited оборачивает вызов изменяющего метода: снимает объект до вызова, выполняет
+// его и пишет в журнал запись с состоянием после. Пробные запуски не журналируются
+func (a *Authenticator) audited(ctx context.Context, fullMethod string, req interface{}, call func() (interface{}, error)) (interface{}, error) {
+	spec, ok := auditedMethods[fullMethod]
+	id, authenticated := FromContext(ctx)
+	if !ok || !authenticated {
+		return call()
+	}
+	if dry, _ := boolField(req, "dry_run"); dry {
+		return call()
+	}
+	snapshot := func(ids []string) json.RawMessage {
+		if spec.snapshot == "" || len(ids) == 0 {
+			return nil
+		}
+		return a.snapshot(ctx, spec, ids)
+	}
+	targets := fieldValues(req, spec.field)
+	before := snapshot(targets)
+	resp, err := call()
+	failed := callFailed(resp, err)
+	if nothingWritten(req, resp, failed) {
+		return resp, err
+	}
+	if !failed {
+		targets = append(targets, fieldValues(resp, spec.created)...)
+	}
+	a.recordAudit(ctx, &auditEntry{
+		Identity:  id,
+		Method:    fullMethod,
+		Entity:    spec.entity,
+		TargetIDs: targets,
+		Before:    before,
+		After:     snapshot(targets),
+		Succeeded: !failed,
+		Code:      status.Code(err).String(),
+	})
+	return resp, err
+}
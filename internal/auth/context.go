@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Identity — вызывающий пользователь, извлечённый из проверенного токена
+type Identity struct {
+	UserID    int
+	Role      string
+	SessionID int
+	// MFAPending — пароль проверен, но второй фактор ещё нет
+	MFAPending bool
+	// ImpersonatorID — суперпользователь, действующий от имени UserID, или 0
+	ImpersonatorID int
+	// PersonalTokenID — токен для скриптов, по которому пришёл вызов, или 0
+	PersonalTokenID int
+}
+
+type identityKey struct{}
+
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext возвращает личность вызывающего, положенную интерцептором
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok
+}
+
+// ClientIP возвращает IP-адрес клиента gRPC-вызова
+func ClientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// UserAgent возвращает user-agent клиента из метаданных вызова
+func UserAgent(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if values := md.Get("user-agent"); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
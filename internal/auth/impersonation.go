@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/status"
+)
+
+// checkImpersonation проверяет, что имперсонация не завершена, не истекла и выдана
+// тем же суперпользователем для того же пользователя и что оба они не деактивированы
+func (a *Authenticator) checkImpersonation(ctx context.Context, impersonationID, userID, impersonatorID int) (bool, error) {
+	var active bool
+	err := a.db.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM impersonations i
+			JOIN users u ON u.id = i.user_id AND u.deactivated_at IS NULL
+			JOIN users su ON su.id = i.superaccount_id AND su.deactivated_at IS NULL
+			WHERE i.id = $1 AND i.user_id = $2 AND i.superaccount_id = $3
+			  AND i.ended_at IS NULL AND i.expires_at > now()
+		)`, impersonationID, userID, impersonatorID).Scan(&active)
+	return active, err
+}
+
+// recordImpersonatedCall пишет в impersonation_actions вызов, сделанный под имперсонацией,
+// с обеими личностями и кодом результата. Ошибка записи не отменяет вызов.
+func (a *Authenticator) recordImpersonatedCall(ctx context.Context, id *Identity, fullMethod string, err error) {
+	_, dbErr := a.db.ExecContext(context.WithoutCancel(ctx), `
+		INSERT INTO impersonation_actions (impersonation_id, superaccount_id, user_id, method, status_code)
+		VALUES ($1, $2, $3, $4, $5)`,
+		id.SessionID, id.ImpersonatorID, id.UserID, fullMethod, status.Code(err).String())
+	if dbErr != nil {
+		log.Printf("Failed to record impersonated call %s by %d as %d: %v", fullMethod, id.ImpersonatorID, id.UserID, dbErr)
+	}
+}
@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Authenticator проверяет JWT из метаданных каждого входящего вызова
+// и пропускает вызов дальше, только если сессия не отозвана, а роль
+// разрешена политикой
+type Authenticator struct {
+	tokens *TokenManager
+	db     *sql.DB
+	// Обращения к БД вынесены в поля, чтобы тесты могли обойтись без неё
+	sessionActive       func(ctx context.Context, sessionID, userID int) (bool, error)
+	impersonationActive func(ctx context.Context, impersonationID, userID, impersonatorID int) (bool, error)
+	recordImpersonated  func(ctx context.Context, id *Identity, fullMethod string, err error)
+	personalToken       func(ctx context.Context, hash string) (*personalToken, error)
+	snapshot            func(ctx context.Context, spec auditSpec, ids []string) json.RawMessage
+	recordAudit         func(ctx context.Context, e *auditEntry)
+}
+
+func NewAuthenticator(tokens *TokenManager, db *sql.DB) *Authenticator {
+	a := &Authenticator{tokens: tokens, db: db}
+	a.sessionActive = a.checkSession
+	a.impersonationActive = a.checkImpersonation
+	a.recordImpersonated = a.recordImpersonatedCall
+	a.personalToken = a.lookupPersonalToken
+	a.snapshot = a.takeSnapshot
+	a.recordAudit = a.recordAuditEntry
+	return a
+}
+
+// checkSession проверяет, что сессия токена не отозвана и не истекла,
+// а пользователь не деактивирован
+func (a *Authenticator) checkSession(ctx context.Context, sessionID, userID int) (bool, error) {
+	var active bool
+	err := a.db.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM sessions s
+			JOIN users u ON u.id = s.user_id
+			WHERE s.id = $1 AND s.user_id = $2 AND s.revoked_at IS NULL AND s.expires_at > now()
+			  AND u.deactivated_at IS NULL
+		)`, sessionID, userID).Scan(&active)
+	return active, err
+}
+
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if IsPublic(fullMethod) {
+		return ctx, nil
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+	tokenString := strings.TrimSpace(strings.TrimPrefix(values[0], "Bearer "))
+	if tokenString == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+	if strings.HasPrefix(tokenString, PersonalTokenPrefix) {
+		return a.authenticatePersonalToken(ctx, fullMethod, tokenString)
+	}
+
+	claims, err := a.tokens.Verify(tokenString)
+	if err != nil {
+		log.Printf("Rejected token for %s: %v", fullMethod, err)
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	// Промежуточный токен годится только для второго шага входа
+	if claims.MFAPending && !mfaPendingMethods[fullMethod] {
+		return nil, status.Error(codes.PermissionDenied, "second factor required")
+	}
+	if claims.ImpersonatorID > 0 && sessionOnlyMethods[fullMethod] {
+		return nil, status.Error(codes.PermissionDenied, "not allowed during impersonation")
+	}
+	// У внутренних вызовов и промежуточных токенов нет пользовательской сессии
+	if claims.Role != RoleService && !claims.MFAPending {
+		var active bool
+		if claims.ImpersonatorID > 0 {
+			active, err = a.impersonationActive(ctx, claims.SessionID, claims.UserID, claims.ImpersonatorID)
+		} else {
+			active, err = a.sessionActive(ctx, claims.SessionID, claims.UserID)
+		}
+		if err != nil {
+			log.Printf("Failed to check session %d: %v", claims.SessionID, err)
+			return nil, status.Error(codes.Internal, "failed to check session")
+		}
+		if !active {
+			return nil, status.Error(codes.Unauthenticated, "session has been revoked or expired")
+		}
+	}
+	if err := Authorize(fullMethod, claims.Role); err != nil {
+		log.Printf("Denied %s for user %d (%s)", fullMethod, claims.UserID, claims.Role)
+		return nil, err
+	}
+	return NewContext(ctx, &Identity{
+		UserID:         claims.UserID,
+		Role:           claims.Role,
+		SessionID:      claims.SessionID,
+		MFAPending:     claims.MFAPending,
+		ImpersonatorID: claims.ImpersonatorID,
+	}), nil
+}
+
+// authenticatePersonalToken пропускает вызов по токену для скриптов: метод должен
+// входить и в области токена, и в права текущей роли владельца
+func (a *Authenticator) authenticatePersonalToken(ctx context.Context, fullMethod, tokenString string) (context.Context, error) {
+	if sessionOnlyMethods[fullMethod] {
+		return nil, status.Error(codes.PermissionDenied, "not allowed with a personal access token")
+	}
+	pat, err := a.personalToken(ctx, HashOpaqueToken(tokenString))
+	if err != nil {
+		log.Printf("Failed to look up personal access token: %v", err)
+		return nil, status.Error(codes.Internal, "failed to check token")
+	}
+	if pat == nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid, expired or revoked personal access token")
+	}
+	if !scopeAllows(pat.Scopes, fullMethod) {
+		return nil, status.Errorf(codes.PermissionDenied, "token scopes do not include %s", methodScope(fullMethod))
+	}
+	if err := Authorize(fullMethod, pat.Role); err != nil {
+		log.Printf("Denied %s for user %d (%s) with personal token %d", fullMethod, pat.UserID, pat.Role, pat.ID)
+		return nil, err
+	}
+	return NewContext(ctx, &Identity{
+		UserID:          pat.UserID,
+		Role:            pat.Role,
+		PersonalTokenID: pat.ID,
+	}), nil
+}
+
+// recordIfImpersonated пишет вызов в журнал, если он сделан под имперсонацией
+func (a *Authenticator) recordIfImpersonated(ctx context.Context, fullMethod string, err error) {
+	if id, ok := FromContext(ctx); ok && id.ImpersonatorID > 0 {
+		a.recordImpersonated(ctx, id, fullMethod, err)
+	}
+}
+
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := a.audited(ctx, info.FullMethod, req, func() (interface{}, error) {
+			return handler(ctx, req)
+		})
+		a.recordIfImpersonated(ctx, info.FullMethod, err)
+		return resp, err
+	}
+}
+
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		stream := &authStream{ServerStream: ss, ctx: ctx}
+		_, err = a.audited(ctx, info.FullMethod, nil, func() (interface{}, error) {
+			err := handler(srv, stream)
+			return stream.sent, err
+		})
+		a.recordIfImpersonated(ctx, info.FullMethod, err)
+		return err
+	}
+}
+
+// authStream подменяет контекст потока контекстом с личностью вызывающего и
+// запоминает последнее отправленное сообщение — ответ для журнала
+type authStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent interface{}
+}
+
+func (s *authStream) SendMsg(m interface{}) error {
+	s.sent = m
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// KeySet — набор ключей подписи JWT. Токены подписываются активным ключом,
+// а проверяются любым ключом из набора, что позволяет менять ключ без
+// разлогинивания пользователей: новый ключ добавляется, становится активным,
+// а старый удаляется после истечения выданных им токенов.
+type KeySet struct {
+	keys   map[string][]byte
+	active string
+}
+
+func NewKeySet(keys map[string][]byte, active string) (*KeySet, error) {
+	if _, ok := keys[active]; !ok {
+		return nil, fmt.Errorf("active key %q is not in the key set", active)
+	}
+	for kid, key := range keys {
+		if len(key) < 16 {
+			return nil, fmt.Errorf("key %q is shorter than 16 bytes", kid)
+		}
+	}
+	return &KeySet{keys: keys, active: active}, nil
+}
+
+// KeySetFromEnv читает ключи из JWT_KEYS ("kid1:secret1,kid2:secret2")
+// и идентификатор активного ключа из JWT_ACTIVE_KID
+func KeySetFromEnv() (*KeySet, error) {
+	raw := os.Getenv("JWT_KEYS")
+	if raw == "" {
+		return nil, fmt.Errorf("JWT_KEYS is not set")
+	}
+	keys := make(map[string][]byte)
+	for _, pair := range strings.Split(raw, ",") {
+		kid, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || kid == "" || secret == "" {
+			return nil, fmt.Errorf("malformed JWT_KEYS entry %q", pair)
+		}
+		keys[kid] = []byte(secret)
+	}
+	active := os.Getenv("JWT_ACTIVE_KID")
+	if active == "" && len(keys) == 1 {
+		for kid := range keys {
+			active = kid
+		}
+	}
+	return NewKeySet(keys, active)
+}
@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"strconv"
+
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Querier — общий интерфейс *sql.DB и *sql.Tx для проверок владения
+type Querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+var errAccessDenied = status.Error(codes.PermissionDenied, "access to this object is denied")
+
+// Роли лектора в дисциплине, как в типе lecturer_role
+const (
+	LecturerOwner    = "owner"
+	LecturerCo       = "co_lecturer"
+	LecturerReadOnly = "read_only"
+)
+
+// Роли лектора, которым разрешено читать дисциплину, изменять её задания и критерии
+// и выполнять операции владельца
+var (
+	lecturerReaders = pq.Array([]string{LecturerOwner, LecturerCo, LecturerReadOnly})
+	lecturerEditors = pq.Array([]string{LecturerOwner, LecturerCo})
+	lecturerOwners  = pq.Array([]string{LecturerOwner})
+)
+
+// caller возвращает личность вызывающего; суперпользователю доступно всё
+func caller(ctx context.Context) (*Identity, bool, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return nil, false, status.Error(codes.Unauthenticated, "no caller identity")
+	}
+	return id, id.Role == RoleSuperaccount, nil
+}
+
+func exists(ctx context.Context, q Querier, query string, args ...interface{}) error {
+	var ok bool
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&ok); err != nil {
+		log.Printf("Ownership check failed: %v", err)
+		return status.Error(codes.Internal, "ownership check failed")
+	}
+	if !ok {
+		return errAccessDenied
+	}
+	return nil
+}
+
+// CheckSelf разрешает запрос только о самом вызывающем
+func CheckSelf(ctx context.Context, userID int64) error {
+	id, super, err := caller(ctx)
+	if err != nil {
+		return err
+	}
+	if super || int64(id.UserID) == userID {
+		return nil
+	}
+	return errAccessDenied
+}
+
+// CheckSelfString — CheckSelf для идентификаторов, передаваемых строкой
+func CheckSelfString(ctx context.Context, userID string) error {
+	n, err := strconv.ParseInt(userID, 10, 64)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	return CheckSelf(ctx, n)
+}
+
+// CheckWorkAccess: студент видит только свои работы, ассистент и семинарист —
+// назначенные им, лектор — работы по заданиям своих дисциплин
+func CheckWorkAccess(ctx context.Context, q Querier, workID int64) error {
+	return checkWork(ctx, q, workID, lecturerReaders)
+}
+
+// CheckWorkEditable — CheckWorkAccess для изменения работы: работы архивного семестра
+// не меняет никто, а лектор с доступом только на чтение — никакие
+func CheckWorkEditable(ctx context.Context, q Querier, workID int64) error {
+	if err := CheckWorkWritable(ctx, q, workID); err != nil {
+		return err
+	}
+	return checkWork(ctx, q, workID, lecturerEditors)
+}
+
+func checkWork(ctx context.Context, q Querier, workID int64, lecturerRoles interface{}) error {
+	id, super, err := caller(ctx)
+	if err != nil || super {
+		return err
+	}
+	switch id.Role {
+	case RoleStudent:
+		return exists(ctx, q, `SELECT EXISTS(SELECT 1 FROM student_works WHERE id = $1 AND student_id = $2)`, workID, id.UserID)
+	case RoleAssistant:
+		return exists(ctx, q, `SELECT EXISTS(SELECT 1 FROM student_works WHERE id = $1 AND assistant_id = $2)`, workID, id.UserID)
+	case RoleSeminarist:
+		return exists(ctx, q, `SELECT EXISTS(SELECT 1 FROM student_works WHERE id = $1 AND seminarist_id = $2)`, workID, id.UserID)
+	case RoleLecturer:
+		return exists(ctx, q, `
+			SELECT EXISTS(
+				SELECT 1 FROM student_works sw
+				JOIN tasks t ON sw.task_id = t.id
+				JOIN discipline_lecturers dl ON dl.discipline_id = t.discipline_id
+				WHERE sw.id = $1 AND dl.user_id = $2 AND dl.role = ANY($3::lecturer_role[])
+			)`, workID, id.UserID, lecturerRoles)
+	}
+	return errAccessDenied
+}
+
+// CheckDisciplineOwner разрешает изменять дисциплину её владельцу и соавторам курса;
+// дисциплины архивного семестра не изменяет никто
+func CheckDisciplineOwner(ctx context.Context, q Querier, disciplineID int64) error {
+	if err := CheckDisciplineWritable(ctx, q, disciplineID); err != nil {
+		return err
+	}
+	return checkDisciplineRole(ctx, q, disciplineID, lecturerEditors)
+}
+
+// CheckDisciplineLector пропускает любого лектора дисциплины в любом семестре, в том
+// числе архивном, — для операций, которые дисциплину только читают
+func CheckDisciplineLector(ctx context.Context, q Querier, disciplineID int64) error {
+	return checkDisciplineRole(ctx, q, disciplineID, lecturerReaders)
+}
+
+// CheckDisciplineHead пропускает только владельца дисциплины — для операций, которые
+// порождают новые дисциплины или удаляют задания
+func CheckDisciplineHead(ctx context.Context, q Querier, disciplineID int64) error {
+	return checkDisciplineRole(ctx, q, disciplineID, lecturerOwners)
+}
+
+func checkDisciplineRole(ctx context.Context, q Querier, disciplineID int64, roles interface{}) error {
+	id, super, err := caller(ctx)
+	if err != nil || super {
+		return err
+	}
+	return exists(ctx, q, `
+		SELECT EXISTS(
+			SELECT 1 FROM discipline_lecturers
+			WHERE discipline_id = $1 AND user_id = $2 AND role = ANY($3::lecturer_role[])
+		)`, disciplineID, id.UserID, roles)
+}
+
+// CheckTaskOwner разрешает изменять задание владельцу и соавторам его дисциплины
+func CheckTaskOwner(ctx context.Context, q Querier, taskID int64) error {
+	if err := CheckTaskWritable(ctx, q, taskID); err != nil {
+		return err
+	}
+	return checkTaskRole(ctx, q, taskID, lecturerEditors)
+}
+
+// CheckTaskHead — CheckTaskOwner для операций только владельца дисциплины
+func CheckTaskHead(ctx context.Context, q Querier, taskID int64) error {
+	if err := CheckTaskWritable(ctx, q, taskID); err != nil {
+		return err
+	}
+	return checkTaskRole(ctx, q, taskID, lecturerOwners)
+}
+
+func checkTaskRole(ctx context.Context, q Querier, taskID int64, roles interface{}) error {
+	id, super, err := caller(ctx)
+	if err != nil || super {
+		return err
+	}
+	return exists(ctx, q, `
+		SELECT EXISTS(
+			SELECT 1 FROM tasks t
+			JOIN discipline_lecturers dl ON dl.discipline_id = t.discipline_id
+			WHERE t.id = $1 AND dl.user_id = $2 AND dl.role = ANY($3::lecturer_role[])
+		)`, taskID, id.UserID, roles)
+}
+
+// CheckTaskAccess разрешает читать задание и работы по нему: лектору — через роль в
+// дисциплине задания, семинаристу и ассистенту — через состав группы задания по
+// этой дисциплине или назначенные им работы по заданию, студенту — если задание
+// выдано его группе или у него есть работа по заданию
+func CheckTaskAccess(ctx context.Context, q Querier, taskID int64) error {
+	id, super, err := caller(ctx)
+	if err != nil || super {
+		return err
+	}
+	switch id.Role {
+	case RoleLecturer:
+		return checkTaskRole(ctx, q, taskID, lecturerReaders)
+	case RoleSeminarist, RoleAssistant:
+		return exists(ctx, q, `
+			SELECT EXISTS(
+				SELECT 1 FROM tasks t
+				JOIN groups_in_disciplines gd ON gd.group_id = t.group_id AND gd.discipline_id = t.discipline_id
+				JOIN discipline_staff ds ON ds.group_discipline_id = gd.id
+				WHERE t.id = $1 AND ds.user_id = $2 AND ds.role::text = $3
+			) OR EXISTS(
+				SELECT 1 FROM student_works sw
+				WHERE sw.task_id = $1 AND CASE $3 WHEN 'seminarist' THEN sw.seminarist_id ELSE sw.assistant_id END = $2
+			)`, taskID, id.UserID, id.Role)
+	case RoleStudent:
+		return exists(ctx, q, `
+			SELECT EXISTS(
+				SELECT 1 FROM tasks t
+				JOIN users_in_groups ug ON ug.group_id = t.group_id
+				WHERE t.id = $1 AND ug.user_id = $2
+			) OR EXISTS(
+				SELECT 1 FROM student_works sw WHERE sw.task_id = $1 AND sw.student_id = $2
+			)`, taskID, id.UserID)
+	}
+	return errAccessDenied
+}
+
+// CheckGroupDisciplineAccess разрешает читать состав группы по дисциплине её лекторам
+// и семинаристам и ассистентам этой группы по дисциплине
+func CheckGroupDisciplineAccess(ctx context.Context, q Querier, groupID, disciplineID int64) error {
+	id, super, err := caller(ctx)
+	if err != nil || super {
+		return err
+	}
+	switch id.Role {
+	case RoleLecturer:
+		return checkDisciplineRole(ctx, q, disciplineID, lecturerReaders)
+	case RoleSeminarist, RoleAssistant:
+		return exists(ctx, q, `
+			SELECT EXISTS(
+				SELECT 1 FROM groups_in_disciplines gd
+				JOIN discipline_staff ds ON ds.group_discipline_id = gd.id
+				WHERE gd.group_id = $1 AND gd.discipline_id = $2 AND ds.user_id = $3 AND ds.role::text = $4
+			)`, groupID, disciplineID, id.UserID, id.Role)
+	}
+	return errAccessDenied
+}
+
+// CheckCriteriaGroupOwner — CheckTaskOwner для задания, которому принадлежит группа критериев
+func CheckCriteriaGroupOwner(ctx context.Context, q Querier, groupID int64) error {
+	if err := CheckCriteriaGroupWritable(ctx, q, groupID); err != nil {
+		return err
+	}
+	id, super, err := caller(ctx)
+	if err != nil || super {
+		return err
+	}
+	return exists(ctx, q, `
+		SELECT EXISTS(
+			SELECT 1 FROM criteria_groups cg
+			JOIN tasks t ON cg.task_id = t.id
+			JOIN discipline_lecturers dl ON dl.discipline_id = t.discipline_id
+			WHERE cg.id = $1 AND dl.user_id = $2 AND dl.role = ANY($3::lecturer_role[])
+		)`, groupID, id.UserID, lecturerEditors)
+}
+
+// CheckCriterionOwner — CheckTaskOwner для задания, которому принадлежит критерий
+func CheckCriterionOwner(ctx context.Context, q Querier, criterionID int64) error {
+	if err := CheckCriterionWritable(ctx, q, criterionID); err != nil {
+		return err
+	}
+	id, super, err := caller(ctx)
+	if err != nil || super {
+		return err
+	}
+	return exists(ctx, q, `
+		SELECT EXISTS(
+			SELECT 1 FROM criteria c
+			JOIN criteria_groups cg ON c.criteria_group_id = cg.id
+			JOIN tasks t ON cg.task_id = t.id
+			JOIN discipline_lecturers dl ON dl.discipline_id = t.discipline_id
+			WHERE c.id = $1 AND dl.user_id = $2 AND dl.role = ANY($3::lecturer_role[])
+		)`, criterionID, id.UserID, lecturerEditors)
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PersonalTokenPrefix отличает токен для скриптов от JWT сессии
+const PersonalTokenPrefix = "rubr_pat_"
+
+// Область токена — "<пакет сервиса>:read" или "<пакет сервиса>:write", например "grade:write".
+// write включает read. Права токена — пересечение его областей с правами роли владельца.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// readPrefixes — методы, которые ничего не меняют и доступны по области read
+var readPrefixes = []string{"Get", "List", "Load", "Query", "Export", "Check", "GenerateDownloadURL"}
+
+// personalToken — владелец и области токена, найденного по хешу
+type personalToken struct {
+	ID     int
+	UserID int
+	Role   string
+	Scopes []string
+}
+
+// NewPersonalToken генерирует токен для скриптов и его хеш для хранения в БД
+func NewPersonalToken() (token string, hash string, err error) {
+	raw, _, err := NewOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	token = PersonalTokenPrefix + raw
+	return token, HashOpaqueToken(token), nil
+}
+
+// splitMethod разбирает "/grade.GradingService/SetMainCriteriaMark" на пакет и имя метода
+func splitMethod(fullMethod string) (pkg, method string) {
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+	if len(parts) != 2 {
+		return "", ""
+	}
+	pkg, _, _ = strings.Cut(parts[0], ".")
+	return pkg, parts[1]
+}
+
+func isReadMethod(method string) bool {
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// methodScope возвращает область, которой достаточно для вызова метода
+func methodScope(fullMethod string) string {
+	pkg, method := splitMethod(fullMethod)
+	if isReadMethod(method) {
+		return pkg + ":" + ScopeRead
+	}
+	return pkg + ":" + ScopeWrite
+}
+
+// scopeAllows сообщает, покрывают ли области токена вызов метода
+func scopeAllows(scopes []string, fullMethod string) bool {
+	need := methodScope(fullMethod)
+	pkg, _ := splitMethod(fullMethod)
+	for _, scope := range scopes {
+		if scope == need || scope == pkg+":"+ScopeWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// AvailableScopes перечисляет области, в которых роли разрешён хотя бы один метод.
+// Методы, требующие интерактивной сессии, в расчёт не идут.
+func AvailableScopes(role string) []string {
+	seen := make(map[string]bool)
+	for method := range policy {
+		if sessionOnlyMethods[method] || Authorize(method, role) != nil {
+			continue
+		}
+		seen[methodScope(method)] = true
+	}
+	scopes := make([]string, 0, len(seen))
+	for scope := range seen {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// ValidateScopes проверяет, что каждая область существует и доступна роли владельца
+func ValidateScopes(role string, scopes []string) error {
+	if len(scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	available := make(map[string]bool)
+	for _, scope := range AvailableScopes(role) {
+		available[scope] = true
+	}
+	for _, scope := range scopes {
+		if !available[scope] {
+			return fmt.Errorf("scope %q is not available for role %s", scope, role)
+		}
+	}
+	return nil
+}
+
+// lookupPersonalToken находит действующий токен активного пользователя и отмечает его использование
+func (a *Authenticator) lookupPersonalToken(ctx context.Context, hash string) (*personalToken, error) {
+	var pat personalToken
+	var scopes string
+	err := a.db.QueryRowContext(ctx, `
+		UPDATE personal_access_tokens t SET last_used_at = now()
+		FROM users u
+		WHERE t.token_hash = $1 AND t.revoked_at IS NULL
+		  AND (t.expires_at IS NULL OR t.expires_at > now())
+		  AND u.id = t.user_id AND u.deactivated_at IS NULL
+		RETURNING t.id, t.user_id, u.role, t.scopes`, hash).Scan(&pat.ID, &pat.UserID, &pat.Role, &scopes)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	pat.Scopes = strings.Fields(scopes)
+	return &pat, nil
+}
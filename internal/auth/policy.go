@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gradepb "rubr/proto/grade"
+	notifypb "rubr/proto/notification"
+	rubricpb "rubr/proto/rubric"
+	superaccpb "rubr/proto/superacc"
+	userpb "rubr/proto/user"
+	workpb "rubr/proto/work"
+	workassignmentpb "rubr/proto/workassignment"
+)
+
+// Роли пользователей, совпадают с ENUM user_role в schema.sql
+const (
+	RoleStudent      = "student"
+	RoleAssistant    = "assistant"
+	RoleSeminarist   = "seminarist"
+	RoleLecturer     = "lecturer"
+	RoleSuperaccount = "superaccount"
+)
+
+var AllRoles = []string{RoleStudent, RoleAssistant, RoleSeminarist, RoleLecturer, RoleSuperaccount}
+
+// RoleService — роль внутренних вызовов между сервисами; пользователю не выдаётся
+const RoleService = "service"
+
+var (
+	students     = []string{RoleStudent}
+	assistants   = []string{RoleAssistant}
+	seminarists  = []string{RoleSeminarist}
+	lecturers    = []string{RoleLecturer}
+	superaccs    = []string{RoleSuperaccount}
+	graders      = []string{RoleAssistant, RoleSeminarist}
+	teachers     = []string{RoleSeminarist, RoleLecturer}
+	participants = []string{RoleStudent, RoleAssistant, RoleSeminarist, RoleLecturer}
+	everyone     = AllRoles
+	staff        = []string{RoleSeminarist, RoleLecturer, RoleSuperaccount}
+	services     = []string{RoleService}
+)
+
+// publicMethods вызываются без токена: вход, регистрация с подтверждением почты,
+// принятие приглашения, обновление токена и сброс пароля
+var publicMethods = map[string]bool{
+	userpb.UserService_RegisterUser_FullMethodName:         true,
+	userpb.UserService_Login_FullMethodName:                true,
+	userpb.UserService_VerifyEmail_FullMethodName:          true,
+	userpb.UserService_AcceptInvitation_FullMethodName:     true,
+	userpb.UserService_ResendVerification_FullMethodName:   true,
+	userpb.UserService_RequestPasswordReset_FullMethodName: true,
+	userpb.UserService_ConfirmPasswordReset_FullMethodName: true,
+	userpb.UserService_RefreshToken_FullMethodName:         true,
+}
+
+// mfaPendingMethods — методы, доступные с промежуточным токеном до ввода TOTP
+var mfaPendingMethods = map[string]bool{
+	userpb.UserService_VerifyTotp_FullMethodName:            true,
+	userpb.UserService_BeginTotpEnrollment_FullMethodName:   true,
+	userpb.UserService_ConfirmTotpEnrollment_FullMethodName: true,
+}
+
+// sessionOnlyMethods требуют собственной интерактивной сессии пользователя и недоступны
+// под имперсонацией и с токеном для скриптов: ими меняют пароль, профиль, сессии,
+// второй фактор и сами токены
+var sessionOnlyMethods = map[string]bool{
+	userpb.UserService_Logout_FullMethodName:                   true,
+	userpb.UserService_ListSessions_FullMethodName:             true,
+	userpb.UserService_RevokeSession_FullMethodName:            true,
+	userpb.UserService_UpdateMyProfile_FullMethodName:          true,
+	userpb.UserService_ChangePassword_FullMethodName:           true,
+	userpb.UserService_VerifyTotp_FullMethodName:               true,
+	userpb.UserService_BeginTotpEnrollment_FullMethodName:      true,
+	userpb.UserService_ConfirmTotpEnrollment_FullMethodName:    true,
+	userpb.UserService_DisableTotp_FullMethodName:              true,
+	userpb.UserService_CreatePersonalToken_FullMethodName:      true,
+	userpb.UserService_ListPersonalTokens_FullMethodName:       true,
+	userpb.UserService_RevokePersonalToken_FullMethodName:      true,
+	superaccpb.SuperAccService_Impersonate_FullMethodName:      true,
+	superaccpb.SuperAccService_EndImpersonation_FullMethodName: true,
+}
+
+// policy сопоставляет полное имя метода с ролями, которым он разрешён.
+// Метод, которого нет ни здесь, ни в publicMethods, запрещён всем.
+var policy = map[string][]string{
+	// UserService: сессии текущего пользователя
+	userpb.UserService_Logout_FullMethodName:        everyone,
+	userpb.UserService_ListSessions_FullMethodName:  everyone,
+	userpb.UserService_RevokeSession_FullMethodName: everyone,
+
+	// UserService: профиль текущего пользователя
+	userpb.UserService_GetMyProfile_FullMethodName:    everyone,
+	userpb.UserService_UpdateMyProfile_FullMethodName: everyone,
+	userpb.UserService_ChangePassword_FullMethodName:  everyone,
+
+	// UserService: токены для скриптов
+	userpb.UserService_CreatePersonalToken_FullMethodName: everyone,
+	userpb.UserService_ListPersonalTokens_FullMethodName:  everyone,
+	userpb.UserService_RevokePersonalToken_FullMethodName: everyone,
+
+	// UserService: второй фактор для сотрудников, меняющих оценки и составы групп
+	userpb.UserService_VerifyTotp_FullMethodName:            staff,
+	userpb.UserService_BeginTotpEnrollment_FullMethodName:   staff,
+	userpb.UserService_ConfirmTotpEnrollment_FullMethodName: staff,
+	userpb.UserService_DisableTotp_FullMethodName:           staff,
+
+	// WorkService: задания лектора
+	workpb.WorkService_GetTasksForLector_FullMethodName:            lecturers,
+	workpb.WorkService_DeleteTask_FullMethodName:                   lecturers,
+	workpb.WorkService_SetTaskTitle_FullMethodName:                 lecturers,
+	workpb.WorkService_SetTaskDescription_FullMethodName:           lecturers,
+	workpb.WorkService_SetTaskDeadline_FullMethodName:              lecturers,
+	workpb.WorkService_CreateWork_FullMethodName:                   lecturers,
+	workpb.WorkService_UpdateTaskGroupAndDiscipline_FullMethodName: lecturers,
+	workpb.WorkService_GetGroups_FullMethodName:                    lecturers,
+	workpb.WorkService_GetDisciplines_FullMethodName:               lecturers,
+	workpb.WorkService_GetTerms_FullMethodName:                     participants,
+	workpb.WorkService_CloneDiscipline_FullMethodName:              lecturers,
+	workpb.WorkService_LoadTaskName_FullMethodName:                 participants,
+	workpb.WorkService_LoadTaskDescription_FullMethodName:          participants,
+	workpb.WorkService_LoadTaskDeadline_FullMethodName:             participants,
+	workpb.WorkService_GetTaskDetails_FullMethodName:               participants,
+	// WorkService: студент
+	workpb.WorkService_ListTasksForStudent_FullMethodName:         students,
+	workpb.WorkService_ListWorksForStudent_FullMethodName:         students,
+	workpb.WorkService_GetStudentDisciplines_FullMethodName:       students,
+	workpb.WorkService_GetStudentWorksByDiscipline_FullMethodName: students,
+	// WorkService: семинарист
+	workpb.WorkService_GetStudentWorksForSeminarist_FullMethodName:    seminarists,
+	workpb.WorkService_GetTasksForSeminarist_FullMethodName:           seminarists,
+	workpb.WorkService_GetStudentWorksByTask_FullMethodName:           teachers,
+	workpb.WorkService_GetAssistantsByDiscipline_FullMethodName:       teachers,
+	workpb.WorkService_GetStudentsByGroupAndDiscipline_FullMethodName: teachers,
+	workpb.WorkService_AssignAssistantsToWorks_FullMethodName:         seminarists,
+	workpb.WorkService_UpdateWork_FullMethodName:                      seminarists,
+
+	// WorkAssignmentService
+	workassignmentpb.WorkAssignmentService_GetWorksForAssistant_FullMethodName: assistants,
+	workassignmentpb.WorkAssignmentService_GetWorkDetails_FullMethodName:       participants,
+	workassignmentpb.WorkAssignmentService_GetTaskDetails_FullMethodName:       participants,
+	workassignmentpb.WorkAssignmentService_GenerateDownloadURL_FullMethodName:  participants,
+	workassignmentpb.WorkAssignmentService_SubmitWork_FullMethodName:           students,
+	workassignmentpb.WorkAssignmentService_CreateWork_FullMethodName:           students,
+	workassignmentpb.WorkAssignmentService_CheckExistingWork_FullMethodName:    students,
+	workassignmentpb.WorkAssignmentService_GenerateUploadURL_FullMethodName:    students,
+
+	// GradingService
+	gradepb.GradingService_SetBlockingCriteriaMark_FullMethodName: graders,
+	gradepb.GradingService_SetMainCriteriaMark_FullMethodName:     graders,
+	gradepb.GradingService_UpdateWorkStatus_FullMethodName:        graders,
+	gradepb.GradingService_GetCriteriaMarks_FullMethodName:        participants,
+	gradepb.GradingService_ListSubjects_FullMethodName:            students,
+
+	// RubricService
+	rubricpb.RubricService_LoadTaskBlockingCriterias_FullMethodName:   participants,
+	rubricpb.RubricService_LoadTaskMainCriterias_FullMethodName:       participants,
+	rubricpb.RubricService_CreateNewBlockingCriteria_FullMethodName:   lecturers,
+	rubricpb.RubricService_CreateNewCriteriaGroup_FullMethodName:      lecturers,
+	rubricpb.RubricService_CreateNewMainCriteria_FullMethodName:       lecturers,
+	rubricpb.RubricService_CreateCriteriaDescription_FullMethodName:   lecturers,
+	rubricpb.RubricService_SetCriteriaWeight_FullMethodName:           lecturers,
+	rubricpb.RubricService_CreateCriteriaGroup_FullMethodName:         lecturers,
+	rubricpb.RubricService_CreateCriterion_FullMethodName:             lecturers,
+	rubricpb.RubricService_UpdateCriterionWeight_FullMethodName:       lecturers,
+	rubricpb.RubricService_UpdateCriterionComment_FullMethodName:      lecturers,
+	rubricpb.RubricService_DeleteTaskBlockingCriterias_FullMethodName: lecturers,
+	rubricpb.RubricService_DeleteBlockingCriteria_FullMethodName:      lecturers,
+	rubricpb.RubricService_DeleteCriteriaGroup_FullMethodName:         lecturers,
+	rubricpb.RubricService_DeleteCriterion_FullMethodName:             lecturers,
+
+	// SuperAccService
+	superaccpb.SuperAccService_UpdateUserRole_FullMethodName:             superaccs,
+	superaccpb.SuperAccService_ManageGroup_FullMethodName:                superaccs,
+	superaccpb.SuperAccService_ManageDiscipline_FullMethodName:           superaccs,
+	superaccpb.SuperAccService_ListGroups_FullMethodName:                 superaccs,
+	superaccpb.SuperAccService_ManageGroupEntity_FullMethodName:          superaccs,
+	superaccpb.SuperAccService_ListAllUsers_FullMethodName:               superaccs,
+	superaccpb.SuperAccService_ListUsersByGroup_FullMethodName:           superaccs,
+	superaccpb.SuperAccService_RemoveUser_FullMethodName:                 superaccs,
+	superaccpb.SuperAccService_AddUser_FullMethodName:                    superaccs,
+	superaccpb.SuperAccService_ManageDisciplineEntity_FullMethodName:     superaccs,
+	superaccpb.SuperAccService_ListDisciplines_FullMethodName:            superaccs,
+	superaccpb.SuperAccService_CreateDiscipline_FullMethodName:           superaccs,
+	superaccpb.SuperAccService_DeleteDiscipline_FullMethodName:           superaccs,
+	superaccpb.SuperAccService_GetGroupStaff_FullMethodName:              superaccs,
+	superaccpb.SuperAccService_DetachDisciplinesFromGroup_FullMethodName: superaccs,
+	superaccpb.SuperAccService_UnlockUser_FullMethodName:                 superaccs,
+	superaccpb.SuperAccService_ListInvitations_FullMethodName:            superaccs,
+	superaccpb.SuperAccService_ResendInvitation_FullMethodName:           superaccs,
+	superaccpb.SuperAccService_RevokeInvitation_FullMethodName:           superaccs,
+	superaccpb.SuperAccService_DeactivateUser_FullMethodName:             superaccs,
+	superaccpb.SuperAccService_ReactivateUser_FullMethodName:             superaccs,
+	superaccpb.SuperAccService_PurgeUser_FullMethodName:                  superaccs,
+	superaccpb.SuperAccService_Impersonate_FullMethodName:                superaccs,
+	superaccpb.SuperAccService_EndImpersonation_FullMethodName:           superaccs,
+	superaccpb.SuperAccService_ImportUsers_FullMethodName:                superaccs,
+	superaccpb.SuperAccService_ExportDirectory_FullMethodName:            superaccs,
+	superaccpb.SuperAccService_ImportDirectory_FullMethodName:            superaccs,
+	superaccpb.SuperAccService_CreateTerm_FullMethodName:                 superaccs,
+	superaccpb.SuperAccService_ListTerms_FullMethodName:                  superaccs,
+	superaccpb.SuperAccService_SetTermState_FullMethodName:               superaccs,
+	superaccpb.SuperAccService_ListDisciplineStaff_FullMethodName:        superaccs,
+	superaccpb.SuperAccService_ManageDisciplineLecturer_FullMethodName:   superaccs,
+	superaccpb.SuperAccService_ListDisciplineLecturers_FullMethodName:    superaccs,
+	superaccpb.SuperAccService_QueryAuditLog_FullMethodName:              superaccs,
+	superaccpb.SuperAccService_ReconcileRoster_FullMethodName:            superaccs,
+	superaccpb.SuperAccService_MergeUsers_FullMethodName:                 superaccs,
+
+	// NotificationService
+	notifypb.NotificationService_SendTaskNotification_FullMethodName:          lecturers,
+	notifypb.NotificationService_SendRegistrationNotification_FullMethodName:  services,
+	notifypb.NotificationService_SendPasswordResetNotification_FullMethodName: services,
+	notifypb.NotificationService_SendInvitationNotification_FullMethodName:    services,
+}
+
+// IsPublic сообщает, можно ли вызывать метод без токена
+func IsPublic(fullMethod string) bool {
+	return publicMethods[fullMethod]
+}
+
+// Authorize проверяет, разрешён ли метод роли вызывающего
+func Authorize(fullMethod, role string) error {
+	roles, ok := policy[fullMethod]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "method %s is not allowed", fullMethod)
+	}
+	for _, r := range roles {
+		if r == role {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "role %q may not call %s", role, fullMethod)
+}
@@ -0,0 +1,449 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	gradepb "rubr/proto/grade"
+	notifypb "rubr/proto/notification"
+	rubricpb "rubr/proto/rubric"
+	superaccpb "rubr/proto/superacc"
+	userpb "rubr/proto/user"
+	workpb "rubr/proto/work"
+	workassignmentpb "rubr/proto/workassignment"
+)
+
+var serviceDescs = []grpc.ServiceDesc{
+	gradepb.GradingService_ServiceDesc,
+	notifypb.NotificationService_ServiceDesc,
+	rubricpb.RubricService_ServiceDesc,
+	superaccpb.SuperAccService_ServiceDesc,
+	userpb.UserService_ServiceDesc,
+	workpb.WorkService_ServiceDesc,
+	workassignmentpb.WorkAssignmentService_ServiceDesc,
+}
+
+func allMethods() []string {
+	var methods []string
+	for _, sd := range serviceDescs {
+		for _, m := range sd.Methods {
+			methods = append(methods, "/"+sd.ServiceName+"/"+m.MethodName)
+		}
+		for _, st := range sd.Streams {
+			methods = append(methods, "/"+sd.ServiceName+"/"+st.StreamName)
+		}
+	}
+	return methods
+}
+
+func TestEveryMethodIsCovered(t *testing.T) {
+	for _, m := range allMethods() {
+		_, inPolicy := policy[m]
+		if inPolicy == IsPublic(m) {
+			t.Errorf("%s must be either public or listed in the policy exactly once", m)
+		}
+	}
+}
+
+func TestPolicyHasNoUnknownMethods(t *testing.T) {
+	known := make(map[string]bool)
+	for _, m := range allMethods() {
+		known[m] = true
+	}
+	for m, roles := range policy {
+		if !known[m] {
+			t.Errorf("policy lists unknown method %s", m)
+		}
+		for _, r := range roles {
+			if !isRole(r) {
+				t.Errorf("policy for %s lists unknown role %q", m, r)
+			}
+		}
+	}
+}
+
+func isRole(role string) bool {
+	if role == RoleService {
+		return true
+	}
+	for _, r := range AllRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRolesDeniedOutsidePermissions(t *testing.T) {
+	for method, allowed := range policy {
+		for _, role := range AllRoles {
+			want := false
+			for _, r := range allowed {
+				if r == role {
+					want = true
+				}
+			}
+			err := Authorize(method, role)
+			if want && err != nil {
+				t.Errorf("%s: role %s should be allowed, got %v", method, role, err)
+			}
+			if !want && status.Code(err) != codes.PermissionDenied {
+				t.Errorf("%s: role %s should be denied, got %v", method, role, err)
+			}
+		}
+	}
+}
+
+func TestKnownEscalationsDenied(t *testing.T) {
+	cases := []struct {
+		method string
+		role   string
+	}{
+		{gradepb.GradingService_SetMainCriteriaMark_FullMethodName, RoleStudent},
+		{gradepb.GradingService_SetBlockingCriteriaMark_FullMethodName, RoleStudent},
+		{superaccpb.SuperAccService_UpdateUserRole_FullMethodName, RoleStudent},
+		{superaccpb.SuperAccService_UpdateUserRole_FullMethodName, RoleLecturer},
+		{superaccpb.SuperAccService_RemoveUser_FullMethodName, RoleSeminarist},
+		{workpb.WorkService_DeleteTask_FullMethodName, RoleStudent},
+		{workpb.WorkService_DeleteTask_FullMethodName, RoleAssistant},
+		{rubricpb.RubricService_DeleteCriterion_FullMethodName, RoleSeminarist},
+		{workassignmentpb.WorkAssignmentService_SubmitWork_FullMethodName, RoleAssistant},
+		{notifypb.NotificationService_SendPasswordResetNotification_FullMethodName, RoleStudent},
+		{notifypb.NotificationService_SendPasswordResetNotification_FullMethodName, RoleSuperaccount},
+		{userpb.UserService_ListSessions_FullMethodName, RoleService},
+		{"/unknown.Service/Method", RoleSuperaccount},
+	}
+	for _, c := range cases {
+		if err := Authorize(c.method, c.role); status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%s as %s: expected PermissionDenied, got %v", c.method, c.role, err)
+		}
+	}
+}
+
+func TestInterceptorEnforcesPolicy(t *testing.T) {
+	tokens := NewTokenManager(testKeys(t), time.Minute)
+	a := NewAuthenticator(tokens, nil)
+	a.sessionActive = func(ctx context.Context, sessionID, userID int) (bool, error) {
+		return sessionID == 1, nil
+	}
+	a.impersonationActive = func(ctx context.Context, impersonationID, userID, impersonatorID int) (bool, error) {
+		return impersonationID == 3 && impersonatorID == 1, nil
+	}
+	var recorded []string
+	a.recordImpersonated = func(ctx context.Context, id *Identity, fullMethod string, err error) {
+		recorded = append(recorded, fullMethod)
+	}
+	a.personalToken = func(ctx context.Context, hash string) (*personalToken, error) {
+		if hash != HashOpaqueToken(PersonalTokenPrefix+"seminarist") {
+			return nil, nil
+		}
+		return &personalToken{ID: 5, UserID: 8, Role: RoleSeminarist, Scopes: []string{"grade:read", "work:write"}}, nil
+	}
+	interceptor := a.UnaryInterceptor()
+
+	call := func(method, token string) (*Identity, error) {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		var got *Identity
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			got, _ = FromContext(ctx)
+			return nil, nil
+		})
+		return got, err
+	}
+
+	studentToken, err := tokens.Generate(7, RoleStudent, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := call(superaccpb.SuperAccService_UpdateUserRole_FullMethodName, studentToken); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("student calling UpdateUserRole: expected PermissionDenied, got %v", err)
+	}
+	if _, err := call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, ""); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("call without token: expected Unauthenticated, got %v", err)
+	}
+	if _, err := call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, "garbage"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("call with bad token: expected Unauthenticated, got %v", err)
+	}
+	if _, err := call(userpb.UserService_Login_FullMethodName, ""); err != nil {
+		t.Errorf("public method without token: unexpected error %v", err)
+	}
+
+	id, err := call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, studentToken)
+	if err != nil {
+		t.Fatalf("student calling GetCriteriaMarks: unexpected error %v", err)
+	}
+	if id == nil || id.UserID != 7 || id.Role != RoleStudent {
+		t.Errorf("identity not propagated, got %+v", id)
+	}
+
+	expired, err := NewTokenManager(testKeys(t), -time.Minute).Generate(7, RoleStudent, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, expired); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expired token: expected Unauthenticated, got %v", err)
+	}
+
+	revoked, err := tokens.Generate(7, RoleStudent, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, revoked); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("revoked session: expected Unauthenticated, got %v", err)
+	}
+
+	serviceToken, err := tokens.GenerateService("userservice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call(notifypb.NotificationService_SendPasswordResetNotification_FullMethodName, serviceToken); err != nil {
+		t.Errorf("service calling SendPasswordResetNotification: unexpected error %v", err)
+	}
+	if _, err := call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, serviceToken); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("service calling GetCriteriaMarks: expected PermissionDenied, got %v", err)
+	}
+
+	pending, err := tokens.GenerateMFAPending(9, RoleLecturer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, pending); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("MFA-pending token calling GetCriteriaMarks: expected PermissionDenied, got %v", err)
+	}
+	id, err = call(userpb.UserService_VerifyTotp_FullMethodName, pending)
+	if err != nil {
+		t.Fatalf("MFA-pending token calling VerifyTotp: unexpected error %v", err)
+	}
+	if id == nil || !id.MFAPending {
+		t.Errorf("MFA-pending flag not propagated, got %+v", id)
+	}
+	if len(recorded) != 0 {
+		t.Errorf("calls without impersonation were recorded: %v", recorded)
+	}
+
+	impersonated, err := tokens.GenerateImpersonation(7, RoleStudent, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err = call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, impersonated)
+	if err != nil {
+		t.Fatalf("impersonation token calling GetCriteriaMarks: unexpected error %v", err)
+	}
+	if id == nil || id.UserID != 7 || id.ImpersonatorID != 1 {
+		t.Errorf("impersonator not propagated, got %+v", id)
+	}
+	if len(recorded) != 1 || recorded[0] != gradepb.GradingService_GetCriteriaMarks_FullMethodName {
+		t.Errorf("impersonated call not recorded, got %v", recorded)
+	}
+	if _, err := call(userpb.UserService_ChangePassword_FullMethodName, impersonated); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("impersonation token calling ChangePassword: expected PermissionDenied, got %v", err)
+	}
+	ended, err := tokens.GenerateImpersonation(7, RoleStudent, 4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, ended); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("ended impersonation: expected Unauthenticated, got %v", err)
+	}
+
+	pat := PersonalTokenPrefix + "seminarist"
+	id, err = call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, pat)
+	if err != nil {
+		t.Fatalf("personal token with grade:read calling GetCriteriaMarks: unexpected error %v", err)
+	}
+	if id == nil || id.UserID != 8 || id.Role != RoleSeminarist || id.PersonalTokenID != 5 {
+		t.Errorf("personal token identity not propagated, got %+v", id)
+	}
+	if _, err := call(gradepb.GradingService_SetMainCriteriaMark_FullMethodName, pat); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("personal token without grade:write calling SetMainCriteriaMark: expected PermissionDenied, got %v", err)
+	}
+	if _, err := call(userpb.UserService_CreatePersonalToken_FullMethodName, pat); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("personal token calling CreatePersonalToken: expected PermissionDenied, got %v", err)
+	}
+	if _, err := call(gradepb.GradingService_GetCriteriaMarks_FullMethodName, PersonalTokenPrefix+"unknown"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("unknown personal token: expected Unauthenticated, got %v", err)
+	}
+}
+
+func TestPersonalTokenScopesNarrowerThanRole(t *testing.T) {
+	for _, role := range AllRoles {
+		for _, scope := range AvailableScopes(role) {
+			granted := false
+			for method := range policy {
+				if scopeAllows([]string{scope}, method) && Authorize(method, role) == nil {
+					granted = true
+				}
+			}
+			if !granted {
+				t.Errorf("scope %s offered to %s grants nothing", scope, role)
+			}
+		}
+	}
+	if err := ValidateScopes(RoleStudent, []string{"superacc:read"}); err == nil {
+		t.Error("student was allowed a superacc scope")
+	}
+	if err := ValidateScopes(RoleSuperaccount, []string{"superacc:write"}); err != nil {
+		t.Errorf("superaccount denied superacc:write: %v", err)
+	}
+	if err := ValidateScopes(RoleStudent, nil); err == nil {
+		t.Error("token without scopes was accepted")
+	}
+}
+
+func testKeys(t *testing.T) *KeySet {
+	keys, err := NewKeySet(map[string][]byte{"test": []byte("test-secret-0123456789")}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// fieldResolves проверяет, что пути к полям существуют в сообщении
+func fieldResolves(root protoreflect.MessageDescriptor, paths string) bool {
+	for _, path := range strings.Split(paths, ",") {
+		md := root
+		for _, name := range strings.Split(path, ".") {
+			if md == nil {
+				return false
+			}
+			fd := md.Fields().ByName(protoreflect.Name(name))
+			if fd == nil {
+				return false
+			}
+			md = fd.Message()
+		}
+	}
+	return true
+}
+
+func TestAuditedMethodsResolve(t *testing.T) {
+	for method, spec := range auditedMethods {
+		if _, ok := policy[method]; !ok {
+			t.Errorf("%s is audited but missing from policy", method)
+		}
+		if spec.entity == "" {
+			t.Errorf("%s: audit entity is empty", method)
+		}
+		d, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(method, "/"), "/", ".")))
+		if err != nil {
+			t.Errorf("%s: %v", method, err)
+			continue
+		}
+		md := d.(protoreflect.MethodDescriptor)
+		if spec.field != "" && !fieldResolves(md.Input(), spec.field) {
+			t.Errorf("%s: request has no field %q", method, spec.field)
+		}
+		if spec.created != "" && !fieldResolves(md.Output(), spec.created) {
+			t.Errorf("%s: response has no field %q", method, spec.created)
+		}
+	}
+}
+
+func TestInterceptorRecordsAudit(t *testing.T) {
+	tokens := NewTokenManager(testKeys(t), time.Minute)
+	a := NewAuthenticator(tokens, nil)
+	a.sessionActive = func(ctx context.Context, sessionID, userID int) (bool, error) {
+		return true, nil
+	}
+	a.snapshot = func(ctx context.Context, spec auditSpec, ids []string) json.RawMessage {
+		return json.RawMessage(`["` + strings.Join(ids, ",") + `"]`)
+	}
+	var entries []*auditEntry
+	a.recordAudit = func(ctx context.Context, e *auditEntry) {
+		entries = append(entries, e)
+	}
+	interceptor := a.UnaryInterceptor()
+
+	superToken, err := tokens.Generate(1, RoleSuperaccount, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+superToken))
+	call := func(method string, req interface{}, resp interface{}) {
+		_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return resp, nil
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", method, err)
+		}
+	}
+
+	call(superaccpb.SuperAccService_ListTerms_FullMethodName, &superaccpb.ListTermsRequest{}, &superaccpb.ListTermsResponse{})
+	if len(entries) != 0 {
+		t.Fatalf("read-only call was audited: %+v", entries[0])
+	}
+
+	call(superaccpb.SuperAccService_UpdateUserRole_FullMethodName,
+		&superaccpb.UpdateRoleRequest{UserId: 42, Role: superaccpb.UserRole_USER_ROLE_LECTURER}, &superaccpb.UpdateRoleResponse{Success: true})
+	call(superaccpb.SuperAccService_DeleteDiscipline_FullMethodName,
+		&superaccpb.DeleteDisciplineRequest{DisciplineIds: []int32{3, 4}}, &superaccpb.DeleteDisciplineResponse{Success: false})
+	call(superaccpb.SuperAccService_CreateTerm_FullMethodName,
+		&superaccpb.CreateTermRequest{}, &superaccpb.CreateTermResponse{Success: true, TermId: 9})
+	call(superaccpb.SuperAccService_MergeUsers_FullMethodName,
+		&superaccpb.MergeUsersRequest{SourceUserId: 5, TargetUserId: 6}, &superaccpb.MergeUsersResponse{Success: true, Applied: true})
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 audit entries, got %d", len(entries))
+	}
+
+	e := entries[0]
+	if e.Identity.UserID != 1 || e.Entity != "user" || len(e.TargetIDs) != 1 || e.TargetIDs[0] != "42" || !e.Succeeded {
+		t.Errorf("UpdateUserRole recorded as %+v", e)
+	}
+	if string(e.Before) != `["42"]` || string(e.After) != `["42"]` {
+		t.Errorf("UpdateUserRole snapshots: before %s, after %s", e.Before, e.After)
+	}
+	if e := entries[1]; e.Succeeded || strings.Join(e.TargetIDs, ",") != "3,4" {
+		t.Errorf("failed DeleteDiscipline recorded as %+v", e)
+	}
+	if e := entries[2]; e.Before != nil || strings.Join(e.TargetIDs, ",") != "9" || string(e.After) != `["9"]` {
+		t.Errorf("CreateTerm recorded as %+v", e)
+	}
+	if e := entries[3]; strings.Join(e.TargetIDs, ",") != "5,6" {
+		t.Errorf("MergeUsers recorded as %+v", e)
+	}
+}
+
+func TestAuditSkipsDryRuns(t *testing.T) {
+	cases := []struct {
+		name     string
+		req      interface{}
+		resp     interface{}
+		failed   bool
+		recorded bool
+	}{
+		{"merge dry run", &superaccpb.MergeUsersRequest{DryRun: true}, &superaccpb.MergeUsersResponse{Success: true}, false, false},
+		{"merge applied", &superaccpb.MergeUsersRequest{}, &superaccpb.MergeUsersResponse{Success: true, Applied: true}, false, true},
+		{"merge not applied", &superaccpb.MergeUsersRequest{}, &superaccpb.MergeUsersResponse{Success: true}, false, false},
+		{"failed merge", &superaccpb.MergeUsersRequest{}, &superaccpb.MergeUsersResponse{}, true, true},
+		{"failed merge dry run", &superaccpb.MergeUsersRequest{DryRun: true}, &superaccpb.MergeUsersResponse{}, true, false},
+		{"roster dry run", &superaccpb.ReconcileRosterRequest{DryRun: true}, &superaccpb.ReconcileRosterResponse{Success: true}, false, false},
+		{"roster applied", &superaccpb.ReconcileRosterRequest{}, &superaccpb.ReconcileRosterResponse{Success: true, Applied: true}, false, true},
+		// У потоковых методов запроса нет, пробный запуск виден по ответу
+		{"import dry run", nil, &superaccpb.ImportUsersResponse{Success: true, DryRun: true}, false, false},
+		{"import committed", nil, &superaccpb.ImportUsersResponse{Success: true, Committed: true}, false, true},
+		{"import rolled back", nil, &superaccpb.ImportUsersResponse{}, true, true},
+		{"directory dry run", nil, &superaccpb.ImportDirectoryResponse{Success: true, DryRun: true}, false, false},
+		{"directory committed", nil, &superaccpb.ImportDirectoryResponse{Success: true, Committed: true}, false, true},
+		{"stream failed without response", nil, nil, true, true},
+		{"method without these fields", &superaccpb.UpdateRoleRequest{UserId: 1}, &superaccpb.UpdateRoleResponse{Success: true}, false, true},
+	}
+	for _, c := range cases {
+		if got := !nothingWritten(c.req, c.resp, c.failed); got != c.recorded {
+			t.Errorf("%s: recorded = %v, want %v", c.name, got, c.recorded)
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// serviceAuth подписывает каждый исходящий вызов свежим сервисным токеном
+type serviceAuth struct {
+	tokens  *TokenManager
+	service string
+}
+
+func (s serviceAuth) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := s.tokens.GenerateService(s.service)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (s serviceAuth) RequireTransportSecurity() bool {
+	return false
+}
+
+// WithServiceAuth — опция Dial для вызовов одного сервиса другим
+func WithServiceAuth(tokens *TokenManager, service string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(serviceAuth{tokens: tokens, service: service})
+}
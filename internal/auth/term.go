@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errTermArchived = status.Error(codes.FailedPrecondition, "the term is archived and read-only")
+
+// notArchived отклоняет запись, если объект относится к архивному семестру; запрос
+// должен вернуть EXISTS по строкам с tm.state = 'archived'. Отсутствующий объект
+// не блокируется — это решают проверки владения и сам запрос
+func notArchived(ctx context.Context, q Querier, query string, args ...interface{}) error {
+	var archived bool
+	if err := q.QueryRowContext(ctx, query, args...).Scan(&archived); err != nil {
+		log.Printf("Term check failed: %v", err)
+		return status.Error(codes.Internal, "term check failed")
+	}
+	if archived {
+		return errTermArchived
+	}
+	return nil
+}
+
+// CheckDisciplineWritable запрещает изменения дисциплины архивного семестра, в том числе
+// суперпользователю
+func CheckDisciplineWritable(ctx context.Context, q Querier, disciplineID int64) error {
+	return notArchived(ctx, q, `
+		SELECT EXISTS(
+			SELECT 1 FROM disciplines d
+			JOIN terms tm ON d.term_id = tm.id
+			WHERE d.id = $1 AND tm.state = 'archived'
+		)`, disciplineID)
+}
+
+// CheckTaskWritable — CheckDisciplineWritable для дисциплины задания
+func CheckTaskWritable(ctx context.Context, q Querier, taskID int64) error {
+	return notArchived(ctx, q, `
+		SELECT EXISTS(
+			SELECT 1 FROM tasks t
+			JOIN disciplines d ON t.discipline_id = d.id
+			JOIN terms tm ON d.term_id = tm.id
+			WHERE t.id = $1 AND tm.state = 'archived'
+		)`, taskID)
+}
+
+// CheckWorkWritable — CheckDisciplineWritable для дисциплины работы студента
+func CheckWorkWritable(ctx context.Context, q Querier, workID int64) error {
+	return notArchived(ctx, q, `
+		SELECT EXISTS(
+			SELECT 1 FROM student_works sw
+			JOIN tasks t ON sw.task_id = t.id
+			JOIN disciplines d ON t.discipline_id = d.id
+			JOIN terms tm ON d.term_id = tm.id
+			WHERE sw.id = $1 AND tm.state = 'archived'
+		)`, workID)
+}
+
+// CheckCriteriaGroupWritable — CheckDisciplineWritable для группы критериев
+func CheckCriteriaGroupWritable(ctx context.Context, q Querier, groupID int64) error {
+	return notArchived(ctx, q, `
+		SELECT EXISTS(
+			SELECT 1 FROM criteria_groups cg
+			JOIN tasks t ON cg.task_id = t.id
+			JOIN disciplines d ON t.discipline_id = d.id
+			JOIN terms tm ON d.term_id = tm.id
+			WHERE cg.id = $1 AND tm.state = 'archived'
+		)`, groupID)
+}
+
+// CheckCriterionWritable — CheckDisciplineWritable для критерия
+func CheckCriterionWritable(ctx context.Context, q Querier, criterionID int64) error {
+	return notArchived(ctx, q, `
+		SELECT EXISTS(
+			SELECT 1 FROM criteria c
+			JOIN criteria_groups cg ON c.criteria_group_id = cg.id
+			JOIN tasks t ON cg.task_id = t.id
+			JOIN disciplines d ON t.discipline_id = d.id
+			JOIN terms tm ON d.term_id = tm.id
+			WHERE c.id = $1 AND tm.state = 'archived'
+		)`, criterionID)
+}
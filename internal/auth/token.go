@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Claims — полезная нагрузка JWT, который выдаёт userservice.Login
+type Claims struct {
+	UserID    int    `json:"user_id"`
+	Role      string `json:"role"`
+	SessionID int    `json:"sid,omitempty"`
+	// MFAPending выставляется в промежуточном токене между паролем и TOTP
+	MFAPending bool `json:"mfa,omitempty"`
+	// ImpersonatorID — суперпользователь, работающий от имени UserID;
+	// SessionID в таком токене указывает на строку impersonations
+	ImpersonatorID int `json:"imp,omitempty"`
+	jwt.StandardClaims
+}
+
+const (
+	// AccessTokenTTL — срок действия токена доступа
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL — срок жизни сессии без обновления
+	RefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// TokenManager подписывает токены активным ключом набора и проверяет их по kid
+type TokenManager struct {
+	keys *KeySet
+	ttl  time.Duration
+}
+
+func NewTokenManager(keys *KeySet, ttl time.Duration) *TokenManager {
+	return &TokenManager{keys: keys, ttl: ttl}
+}
+
+// TTL возвращает срок действия выпускаемых токенов
+func (m *TokenManager) TTL() time.Duration {
+	return m.ttl
+}
+
+// Generate выпускает токен для пользователя в рамках сессии sessionID
+func (m *TokenManager) Generate(userID int, role string, sessionID int) (string, error) {
+	claims := Claims{
+		UserID:    userID,
+		Role:      role,
+		SessionID: sessionID,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(m.ttl).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = m.keys.active
+	return token.SignedString(m.keys.keys[m.keys.active])
+}
+
+// ServiceTokenTTL — срок действия токена внутреннего вызова
+const ServiceTokenTTL = time.Minute
+
+// MFATokenTTL — сколько есть времени на ввод второго фактора после пароля
+const MFATokenTTL = 5 * time.Minute
+
+// GenerateMFAPending выпускает промежуточный токен, с которым можно только
+// пройти второй шаг входа (см. mfaPendingMethods)
+func (m *TokenManager) GenerateMFAPending(userID int, role string) (string, error) {
+	claims := Claims{
+		UserID:     userID,
+		Role:       role,
+		MFAPending: true,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(MFATokenTTL).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = m.keys.active
+	return token.SignedString(m.keys.keys[m.keys.active])
+}
+
+// ImpersonationTTL — срок действия токена имперсонации; обновить его нельзя
+const ImpersonationTTL = 30 * time.Minute
+
+// GenerateImpersonation выпускает токен, с которым суперпользователь impersonatorID
+// видит систему глазами userID. Часть методов с ним недоступна (см. sessionOnlyMethods).
+func (m *TokenManager) GenerateImpersonation(userID int, role string, impersonationID, impersonatorID int) (string, error) {
+	claims := Claims{
+		UserID:         userID,
+		Role:           role,
+		SessionID:      impersonationID,
+		ImpersonatorID: impersonatorID,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(ImpersonationTTL).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = m.keys.active
+	return token.SignedString(m.keys.keys[m.keys.active])
+}
+
+// GenerateService выпускает короткоживущий токен для вызова другого сервиса от имени service
+func (m *TokenManager) GenerateService(service string) (string, error) {
+	claims := Claims{
+		Role: RoleService,
+		StandardClaims: jwt.StandardClaims{
+			Subject:   service,
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(ServiceTokenTTL).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = m.keys.active
+	return token.SignedString(m.keys.keys[m.keys.active])
+}
+
+// Verify проверяет подпись и срок действия токена
+func (m *TokenManager) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		key, ok := m.keys.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	if claims.Role == RoleService {
+		if claims.Subject == "" {
+			return nil, errors.New("service token has no subject")
+		}
+		return claims, nil
+	}
+	if claims.UserID <= 0 || claims.Role == "" {
+		return nil, errors.New("token has no identity")
+	}
+	return claims, nil
+}
+
+// NewOpaqueToken генерирует случайный непрозрачный токен и его хеш для хранения в БД
+func NewOpaqueToken() (token string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashOpaqueToken(token), nil
+}
+
+// HashOpaqueToken — SHA-256 от токена; в БД хранится только он
+func HashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
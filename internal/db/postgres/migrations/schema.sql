@@ -10,7 +10,11 @@ CREATE TABLE users (
     patronymic VARCHAR(32),
     email VARCHAR(255) UNIQUE NOT NULL,
     password TEXT NOT NULL, -- Хешированный пароль с использованием ARGON2ID
-    role user_role DEFAULT 'student' NOT NULL
+    role user_role DEFAULT 'student' NOT NULL,
+    email_verified BOOLEAN DEFAULT FALSE NOT NULL, -- до подтверждения почты вход запрещён
+    deactivated_at TIMESTAMPTZ, -- деактивированный пользователь не может войти, его работы и оценки сохраняются
+    deactivated_by BIGINT,
+    FOREIGN KEY (deactivated_by) REFERENCES users(id) ON DELETE SET NULL
 );
 
 
@@ -30,27 +34,48 @@ CREATE TABLE users_in_groups (
     FOREIGN KEY (group_id) REFERENCES student_groups(id) ON DELETE CASCADE
 );
 
+-- 3a) Terms
+-- Семестр: дисциплины и их назначения группам принадлежат семестру. Активный семестр
+-- не больше одного; архивный доступен только для чтения
+CREATE TYPE term_state AS ENUM ('upcoming', 'active', 'archived');
+
+CREATE TABLE terms (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,
+    state term_state DEFAULT 'upcoming' NOT NULL,
+    CHECK (ends_on > starts_on)
+);
+
+CREATE UNIQUE INDEX terms_single_active_idx ON terms (state) WHERE state = 'active';
+
 -- 4) Disciplines
 CREATE TABLE disciplines (
     id SERIAL PRIMARY KEY,
     name TEXT NOT NULL,
-    lector_id BIGINT,
-    FOREIGN KEY (lector_id) REFERENCES users(id) ON DELETE SET NULL
+    term_id BIGINT NOT NULL,
+    FOREIGN KEY (term_id) REFERENCES terms(id) ON DELETE RESTRICT,
+    CONSTRAINT disciplines_id_term_key UNIQUE (id, term_id)
 );
 
+CREATE INDEX disciplines_term_id_idx ON disciplines(term_id);
+
 -- 5) disciplines_in_groups
+-- Назначение группе всегда в семестре своей дисциплины
 CREATE TABLE groups_in_disciplines (
     id SERIAL PRIMARY KEY,
     group_id BIGINT NOT NULL,
     discipline_id BIGINT NOT NULL,
-	seminarist_id BIGINT NOT NULL, 
-	assistant_id BIGINT NOT NULL,
-	FOREIGN KEY (seminarist_id) REFERENCES users(id) ON DELETE SET NULL,
-	FOREIGN KEY (assistant_id) REFERENCES users(id) ON DELETE SET NULL,
+    term_id BIGINT NOT NULL,
     FOREIGN KEY (group_id) REFERENCES student_groups(id) ON DELETE CASCADE,
-    FOREIGN KEY (discipline_id) REFERENCES disciplines(id) ON DELETE CASCADE
+    FOREIGN KEY (discipline_id) REFERENCES disciplines(id) ON DELETE CASCADE,
+    CONSTRAINT groups_in_disciplines_discipline_term_fkey
+        FOREIGN KEY (discipline_id, term_id) REFERENCES disciplines(id, term_id) ON DELETE CASCADE ON UPDATE CASCADE
 );
 
+CREATE INDEX groups_in_disciplines_term_id_idx ON groups_in_disciplines(term_id);
+
 
 
 -- Таблицы о работах, созданных лекторами
@@ -136,4 +161,222 @@ CREATE TABLE notifications (
     delivered_at TIMESTAMPTZ,
     created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
-);
\ No newline at end of file
+);
+
+-- 13) Sessions
+CREATE TABLE sessions (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    refresh_token_hash TEXT NOT NULL UNIQUE, -- SHA-256 текущего refresh-токена
+    previous_token_hash TEXT, -- предыдущий токен; его повторное предъявление означает кражу
+    user_agent TEXT,
+    ip TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX sessions_user_id_idx ON sessions(user_id);
+CREATE INDEX sessions_previous_token_hash_idx ON sessions(previous_token_hash);
+
+-- 14) Password reset tokens
+CREATE TABLE password_reset_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE, -- SHA-256 кода из письма
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ, -- код одноразовый
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX password_reset_tokens_user_id_idx ON password_reset_tokens(user_id);
+
+-- 15) Email verifications
+CREATE TABLE email_verifications (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE, -- SHA-256 кода из письма
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX email_verifications_user_id_idx ON email_verifications(user_id);
+
+-- 16) Login throttle
+CREATE TABLE login_throttle (
+    scope TEXT NOT NULL, -- 'account' (subject — почта) или 'ip'
+    subject TEXT NOT NULL,
+    failures INT NOT NULL DEFAULT 0,
+    last_failure_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ,
+    PRIMARY KEY (scope, subject)
+);
+
+-- 17) TOTP
+CREATE TABLE user_totp (
+    user_id BIGINT PRIMARY KEY,
+    secret TEXT NOT NULL, -- base32, RFC 6238
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- защита от повторного использования кода
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    confirmed_at TIMESTAMPTZ, -- NULL, пока подключение не подтверждено кодом
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE totp_recovery_codes (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    code_hash TEXT NOT NULL, -- SHA-256 резервного кода
+    used_at TIMESTAMPTZ,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX totp_recovery_codes_user_id_idx ON totp_recovery_codes(user_id);
+
+-- 18) Invitations
+CREATE TABLE invitations (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE, -- SHA-256 кода из письма
+    invited_by BIGINT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (invited_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX invitations_user_id_idx ON invitations(user_id);
+
+-- 19) User purges
+-- Журнал окончательных удалений: сам пользователь и его работы удаляются,
+-- запись о том, кто, кого и почему удалил, остаётся
+CREATE TABLE user_purges (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL, -- без внешнего ключа: пользователя уже нет
+    email VARCHAR(255) NOT NULL,
+    fio TEXT NOT NULL,
+    role user_role NOT NULL,
+    purged_by BIGINT,
+    reason TEXT NOT NULL,
+    deleted_works INT NOT NULL,
+    deleted_marks INT NOT NULL,
+    purged_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (purged_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- 20) Impersonations
+-- Суперпользователь работает от имени другого пользователя по отдельному токену;
+-- каждый вызов под имперсонацией пишется в impersonation_actions
+CREATE TABLE impersonations (
+    id SERIAL PRIMARY KEY,
+    superaccount_id BIGINT,
+    user_id BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    started_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    FOREIGN KEY (superaccount_id) REFERENCES users(id) ON DELETE SET NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE impersonation_actions (
+    id SERIAL PRIMARY KEY,
+    impersonation_id BIGINT NOT NULL,
+    superaccount_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    method TEXT NOT NULL,
+    status_code TEXT NOT NULL,
+    called_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (impersonation_id) REFERENCES impersonations(id) ON DELETE CASCADE
+);
+
+CREATE INDEX impersonation_actions_impersonation_id_idx ON impersonation_actions(impersonation_id);
+
+-- 21) Personal access tokens
+-- Токены для скриптов; хранится только SHA-256, области перечислены через пробел
+CREATE TABLE personal_access_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    scopes TEXT NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX personal_access_tokens_user_id_idx ON personal_access_tokens(user_id);
+
+-- 23) Discipline staff
+-- Семинаристы и ассистенты группы по дисциплине; у пары группа-дисциплина их может быть
+-- несколько
+CREATE TYPE staff_role AS ENUM ('seminarist', 'assistant');
+
+CREATE TABLE discipline_staff (
+    id SERIAL PRIMARY KEY,
+    group_discipline_id BIGINT NOT NULL REFERENCES groups_in_disciplines(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role staff_role NOT NULL,
+    UNIQUE (group_discipline_id, user_id, role)
+);
+
+CREATE INDEX discipline_staff_user_id_idx ON discipline_staff(user_id);
+
+-- 24) Discipline lecturers
+-- Лекторы дисциплины: владелец (ровно один), соавторы курса и лекторы с доступом
+-- только на чтение
+CREATE TYPE lecturer_role AS ENUM ('owner', 'co_lecturer', 'read_only');
+
+CREATE TABLE discipline_lecturers (
+    discipline_id BIGINT NOT NULL REFERENCES disciplines(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role lecturer_role NOT NULL,
+    PRIMARY KEY (discipline_id, user_id)
+);
+
+CREATE UNIQUE INDEX discipline_lecturers_single_owner_idx ON discipline_lecturers(discipline_id) WHERE role = 'owner';
+CREATE INDEX discipline_lecturers_user_id_idx ON discipline_lecturers(user_id);
+
+-- 25) Audit log
+-- Журнал административных изменений и изменений оценок: кто, каким методом, какие
+-- объекты и их состояние до и после. Ссылки на пользователя нет, чтобы записи
+-- переживали удаление учётной записи
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT NOT NULL,
+    actor_role TEXT NOT NULL,
+    impersonator_id BIGINT,
+    method TEXT NOT NULL,
+    entity TEXT NOT NULL,
+    target_ids TEXT[] NOT NULL DEFAULT '{}',
+    before JSONB,
+    after JSONB,
+    succeeded BOOLEAN NOT NULL,
+    status_code TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_log_created_at_idx ON audit_log(created_at);
+CREATE INDEX audit_log_actor_id_idx ON audit_log(actor_id);
+CREATE INDEX audit_log_entity_idx ON audit_log(entity);
+CREATE INDEX audit_log_target_ids_idx ON audit_log USING GIN (target_ids);
+
+-- Журнал только дополняется
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_no_update_delete BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_log_append_only();
@@ -0,0 +1,49 @@
+-- Перевод базы, созданной по прежней версии schema.sql. Новые типы и таблицы берутся
+-- из соответствующих разделов schema.sql; здесь — изменения существующих таблиц
+-- с переносом данных. Разделы выполняются по порядку, каждый один раз
+
+-- Подтверждение почты (раздел 1): вход без подтверждения запрещён, поэтому уже
+-- существующие учётные записи считаются подтверждёнными
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE users SET email_verified = TRUE;
+
+-- Деактивация пользователей (раздел 1): все существующие учётные записи активны
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMPTZ;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_by BIGINT;
+ALTER TABLE users ADD CONSTRAINT users_deactivated_by_fkey
+    FOREIGN KEY (deactivated_by) REFERENCES users(id) ON DELETE SET NULL;
+
+-- Семестры (раздел 3a): существующие дисциплины и назначения попадают в активный
+-- семестр, а если его нет — в созданный для них
+INSERT INTO terms (name, starts_on, ends_on, state)
+SELECT 'Текущий семестр', date_trunc('month', now())::date, (date_trunc('month', now()) + interval '6 months')::date, 'active'
+WHERE NOT EXISTS (SELECT 1 FROM terms WHERE state = 'active');
+
+ALTER TABLE disciplines ADD COLUMN term_id BIGINT REFERENCES terms(id) ON DELETE RESTRICT;
+UPDATE disciplines SET term_id = (SELECT id FROM terms WHERE state = 'active');
+ALTER TABLE disciplines ALTER COLUMN term_id SET NOT NULL;
+ALTER TABLE disciplines ADD CONSTRAINT disciplines_id_term_key UNIQUE (id, term_id);
+CREATE INDEX disciplines_term_id_idx ON disciplines(term_id);
+
+ALTER TABLE groups_in_disciplines ADD COLUMN term_id BIGINT;
+UPDATE groups_in_disciplines gd SET term_id = d.term_id FROM disciplines d WHERE d.id = gd.discipline_id;
+ALTER TABLE groups_in_disciplines ALTER COLUMN term_id SET NOT NULL;
+ALTER TABLE groups_in_disciplines ADD CONSTRAINT groups_in_disciplines_discipline_term_fkey
+    FOREIGN KEY (discipline_id, term_id) REFERENCES disciplines(id, term_id) ON DELETE CASCADE ON UPDATE CASCADE;
+CREATE INDEX groups_in_disciplines_term_id_idx ON groups_in_disciplines(term_id);
+
+-- Преподаватели группы по дисциплине (раздел 23): единственные семинарист и ассистент
+-- назначения переходят в discipline_staff
+INSERT INTO discipline_staff (group_discipline_id, user_id, role)
+SELECT id, seminarist_id, 'seminarist' FROM groups_in_disciplines WHERE seminarist_id IS NOT NULL
+UNION
+SELECT id, assistant_id, 'assistant' FROM groups_in_disciplines WHERE assistant_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE groups_in_disciplines DROP COLUMN seminarist_id, DROP COLUMN assistant_id;
+
+-- Лекторы дисциплины (раздел 24): прежний лектор становится владельцем дисциплины
+INSERT INTO discipline_lecturers (discipline_id, user_id, role)
+SELECT id, lector_id, 'owner' FROM disciplines WHERE lector_id IS NOT NULL;
+
+ALTER TABLE disciplines DROP COLUMN lector_id;
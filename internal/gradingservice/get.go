@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"rubr/internal/auth"
 	Pb "rubr/proto/grade"
 	"strconv"
 	"strings"
 )
 
 func (s *Server) GetCriteriaMarks(ctx context.Context, req *Pb.GetCriteriaMarksRequest) (*Pb.GetCriteriaMarksResponse, error) {
+	if err := auth.CheckWorkAccess(ctx, s.Db, int64(req.WorkId)); err != nil {
+		return nil, err
+	}
 	log.Printf("Получен запрос GetCriteriaMarks для work_id: %d", req.WorkId)
 
 	// Проверка входных данных
@@ -60,6 +64,9 @@ func (s *Server) GetCriteriaMarks(ctx context.Context, req *Pb.GetCriteriaMarksR
 }
 
 func (s *Server) ListSubjects(ctx context.Context, req *Pb.ListSubjectsRequest) (*Pb.ListSubjectsResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.StudentId)); err != nil {
+		return nil, err
+	}
 	query := `SELECT name, grades, average FROM student_subjects WHERE student_id = $1`
 	rows, err := s.Db.QueryContext(ctx, query, req.StudentId)
 	if err != nil {
@@ -7,36 +7,57 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
+	"rubr/internal/auth"
+	"rubr/internal/workstatus"
 	Pb "rubr/proto/grade"
 )
 
 func (s *Server) SetBlockingCriteriaMark(ctx context.Context, req *Pb.SetBlockingCriteriaMarkRequest) (*Pb.SetBlockingCriteriaMarkResponse, error) {
-	query := `
-        INSERT INTO student_criteria_marks (student_work_id, criteria_id, mark, comment)
-        VALUES ($1, $2, $3, $4)
-        ON CONFLICT (student_work_id, criteria_id) DO UPDATE 
-        SET mark = EXCLUDED.mark, comment = EXCLUDED.comment`
-	_, err := s.Db.ExecContext(ctx, query, req.WorkId, req.CriterionId, req.Mark, req.Comment)
-	if err != nil {
+	if err := auth.CheckWorkEditable(ctx, s.Db, int64(req.WorkId)); err != nil {
+		return nil, err
+	}
+	if err := s.setCriterionMark(ctx, req.WorkId, req.CriterionId, req.Mark, req.Comment, true); err != nil {
 		return &Pb.SetBlockingCriteriaMarkResponse{Error: err.Error()}, nil
 	}
 	return &Pb.SetBlockingCriteriaMarkResponse{}, nil
 }
 
 func (s *Server) SetMainCriteriaMark(ctx context.Context, req *Pb.SetMainCriteriaMarkRequest) (*Pb.SetMainCriteriaMarkResponse, error) {
-	query := `
-        INSERT INTO student_criteria_marks (student_work_id, criteria_id, mark, comment)
-        VALUES ($1, $2, $3, $4)
-        ON CONFLICT (student_work_id, criteria_id) DO UPDATE 
-        SET mark = EXCLUDED.mark, comment = EXCLUDED.comment`
-	_, err := s.Db.ExecContext(ctx, query, req.WorkId, req.CriterionId, req.Mark, req.Comment)
-	if err != nil {
+	if err := auth.CheckWorkEditable(ctx, s.Db, int64(req.WorkId)); err != nil {
+		return nil, err
+	}
+	if err := s.setCriterionMark(ctx, req.WorkId, req.CriterionId, req.Mark, req.Comment, false); err != nil {
 		return &Pb.SetMainCriteriaMarkResponse{Error: err.Error()}, nil
 	}
 	return &Pb.SetMainCriteriaMarkResponse{}, nil
 }
 
+// setCriterionMark ставит или меняет оценку работы по критерию. Критерий должен
+// принадлежать заданию этой работы и быть блокирующим или основным, как просили
+func (s *Server) setCriterionMark(ctx context.Context, workID, criterionID int32, mark float32, comment string, blocking bool) error {
+	result, err := s.Db.ExecContext(ctx, `
+        INSERT INTO student_criteria_marks (student_work_id, criteria_id, mark, comment)
+        SELECT sw.id, c.id, $3, $4
+        FROM student_works sw
+        JOIN criteria_groups cg ON cg.task_id = sw.task_id
+        JOIN criteria c ON c.criteria_group_id = cg.id
+        WHERE sw.id = $1 AND c.id = $2 AND cg.block_flag = $5
+        ON CONFLICT (student_work_id, criteria_id) DO UPDATE
+        SET mark = EXCLUDED.mark, comment = EXCLUDED.comment`, workID, criterionID, mark, comment, blocking)
+	if err != nil {
+		log.Printf("Failed to set mark for work %d, criterion %d: %v", workID, criterionID, err)
+		return fmt.Errorf("failed to save mark")
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("criterion %d does not belong to the task of work %d", criterionID, workID)
+	}
+	return nil
+}
+
 func (s *Server) UpdateWorkStatus(ctx context.Context, req *Pb.UpdateWorkStatusRequest) (*Pb.UpdateWorkStatusResponse, error) {
+	if err := auth.CheckWorkEditable(ctx, s.Db, int64(req.WorkId)); err != nil {
+		return nil, err
+	}
 	log.Printf("Получен запрос UpdateWorkStatus для work_id: %d, status: %s", req.WorkId, req.Status)
 
 	// Валидация входных данных
@@ -44,28 +65,44 @@ func (s *Server) UpdateWorkStatus(ctx context.Context, req *Pb.UpdateWorkStatusR
 		log.Printf("Неверный work_id: %d", req.WorkId)
 		return nil, status.Errorf(codes.InvalidArgument, "work_id должен быть положительным")
 	}
-	if req.Status == "" {
-		log.Printf("Пустой статус для work_id: %d", req.WorkId)
-		return nil, status.Errorf(codes.InvalidArgument, "status не должен быть пустым")
+	newStatus, ok := workstatus.Name(int32(req.Status))
+	if !ok {
+		log.Printf("Неизвестный статус %v для work_id: %d", req.Status, req.WorkId)
+		return nil, status.Errorf(codes.InvalidArgument, "status должен быть задан")
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Ошибка начала транзакции для work_id %d: %v", req.WorkId, err)
+		return nil, status.Errorf(codes.Internal, "ошибка базы данных: %v", err)
 	}
+	defer tx.Rollback()
 
-	// SQL-запрос для обновления статуса
-	query := `
-        UPDATE student_works
-        SET status = $1
-        WHERE id = $2
-        RETURNING id`
-	var updatedID int64
-	err := s.Db.QueryRowContext(ctx, query, req.Status, req.WorkId).Scan(&updatedID)
+	var current string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM student_works WHERE id = $1 FOR UPDATE", req.WorkId).Scan(&current)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Printf("Работа с id %d не найдена", req.WorkId)
 			return &Pb.UpdateWorkStatusResponse{Error: fmt.Sprintf("работа с id %d не найдена", req.WorkId)}, nil
 		}
+		log.Printf("Ошибка чтения статуса для work_id %d: %v", req.WorkId, err)
+		return nil, status.Errorf(codes.Internal, "ошибка базы данных: %v", err)
+	}
+	id, _ := auth.FromContext(ctx)
+	if err := workstatus.CheckGraderTransition(id.Role, current, newStatus); err != nil {
+		log.Printf("Недопустимая смена статуса работы %d: %v", req.WorkId, err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE student_works SET status = $1 WHERE id = $2", newStatus, req.WorkId); err != nil {
 		log.Printf("Ошибка обновления статуса для work_id %d: %v", req.WorkId, err)
 		return nil, status.Errorf(codes.Internal, "ошибка базы данных: %v", err)
 	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Ошибка фиксации статуса для work_id %d: %v", req.WorkId, err)
+		return nil, status.Errorf(codes.Internal, "ошибка базы данных: %v", err)
+	}
 
-	log.Printf("Статус работы %d успешно обновлен на %s", req.WorkId, req.Status)
+	log.Printf("Статус работы %d успешно обновлен на %s", req.WorkId, newStatus)
 	return &Pb.UpdateWorkStatusResponse{}, nil
 }
@@ -74,7 +74,7 @@ func (s *Server) SendRegistrationNotification(ctx context.Context, req *pb.Notif
 	return &pb.NotificationResponse{}, nil
 }
 
-// SendPasswordResetNotification отправляет письмо с временным паролем для сброса
+// SendPasswordResetNotification отправляет письмо с кодом сброса пароля; вызывается только userservice
 func (s *Server) SendPasswordResetNotification(ctx context.Context, req *pb.NotificationRequest) (*pb.NotificationResponse, error) {
 	if req.Email == "" || req.Message == "" {
 		return &pb.NotificationResponse{Error: "email and message are required"}, nil
@@ -98,3 +98,28 @@ func (s *Server) SendPasswordResetNotification(ctx context.Context, req *pb.Noti
 	}
 	return &pb.NotificationResponse{}, nil
 }
+
+// SendInvitationNotification отправляет приглашение пользователю, созданному суперакком
+func (s *Server) SendInvitationNotification(ctx context.Context, req *pb.NotificationRequest) (*pb.NotificationResponse, error) {
+	if req.Email == "" || req.Message == "" {
+		return &pb.NotificationResponse{Error: "email and message are required"}, nil
+	}
+
+	auth := smtp.PlainAuth("", smtpUser, smtpPass, smtpHost)
+	err := smtp.SendMail(smtpHost+":"+smtpPort, auth, smtpUser, []string{req.Email}, []byte("Subject: Приглашение в систему оценивания\n\n"+req.Message))
+	if err != nil {
+		log.Printf("Failed to send invitation email to %s: %v", req.Email, err)
+		return &pb.NotificationResponse{Error: fmt.Sprintf("failed to send email: %v", err)}, nil
+	}
+
+	// Логируем отправку
+	_, err = s.Db.ExecContext(ctx, `
+		INSERT INTO notifications (user_id, message, created_at)
+		VALUES ($1, $2, $3)`,
+		req.UserId, "Приглашение", req.CreatedAt)
+	if err != nil {
+		log.Printf("Failed to log notification for user %d: %v", req.UserId, err)
+		return &pb.NotificationResponse{Error: fmt.Sprintf("failed to log notification: %v", err)}, nil
+	}
+	return &pb.NotificationResponse{}, nil
+}
@@ -0,0 +1,95 @@
+// Package password хеширует пароли Argon2id в формате PHC и проверяет
+// как новые хеши, так и унаследованные хеши bcrypt.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Параметры Argon2id (рекомендации OWASP)
+const (
+	argonMemory  = 64 * 1024 // КиБ
+	argonTime    = 3
+	argonThreads = 2
+	saltLen      = 16
+	keyLen       = 32
+)
+
+var errMalformed = errors.New("malformed password hash")
+
+// Hash возвращает хеш пароля вида $argon2id$v=19$m=65536,t=3,p=2$<соль>$<хеш>
+func Hash(password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, keyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, argonMemory, argonTime, argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify сверяет пароль с хешем. needsRehash сообщает, что хеш устарел
+// (bcrypt или Argon2id с другими параметрами) и его стоит пересчитать через Hash.
+func Verify(password, encoded string) (ok bool, needsRehash bool, err error) {
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		return verifyArgon2id(password, encoded)
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, false, nil
+		}
+		if err != nil {
+			return false, false, err
+		}
+		return true, true, nil
+	default:
+		// Открытый текст и прочие форматы не принимаются
+		return false, false, errMalformed
+	}
+}
+
+func verifyArgon2id(password, encoded string) (bool, bool, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", соль, хеш
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return false, false, errMalformed
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return false, false, errMalformed
+	}
+	if version != argon2.Version {
+		return false, false, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	var m, t uint32
+	var p uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &m, &t, &p); err != nil {
+		return false, false, errMalformed
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, errMalformed
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(want) == 0 {
+		return false, false, errMalformed
+	}
+
+	got := argon2.IDKey([]byte(password), salt, t, m, p, uint32(len(want)))
+	if subtle.ConstantTimeCompare(got, want) != 1 {
+		return false, false, nil
+	}
+	needsRehash := m != argonMemory || t != argonTime || p != argonThreads || len(want) != keyLen
+	return true, needsRehash, nil
+}
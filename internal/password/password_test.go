@@ -0,0 +1,94 @@
+package password
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func encodeArgon2id(t *testing.T, password string, m, time uint32, p uint8, keyLen uint32) string {
+	t.Helper()
+	salt := []byte("0123456789abcdef")
+	key := argon2.IDKey([]byte(password), salt, time, m, p, keyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, m, time, p,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+func TestHashProducesPHCString(t *testing.T) {
+	encoded, err := Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$", argon2.Version, argonMemory, argonTime, argonThreads)
+	if !strings.HasPrefix(encoded, want) {
+		t.Fatalf("hash %q does not start with %q", encoded, want)
+	}
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		t.Fatalf("hash %q has %d parts, want 6", encoded, len(parts))
+	}
+	if salt, _ := base64.RawStdEncoding.DecodeString(parts[4]); len(salt) != saltLen {
+		t.Errorf("salt is %d bytes, want %d", len(salt), saltLen)
+	}
+	if key, _ := base64.RawStdEncoding.DecodeString(parts[5]); len(key) != keyLen {
+		t.Errorf("key is %d bytes, want %d", len(key), keyLen)
+	}
+
+	other, err := Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == encoded {
+		t.Error("two hashes of the same password must differ by salt")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	current, err := Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weak := encodeArgon2id(t, "correct horse", 8*1024, 1, 1, keyLen)
+	short := encodeArgon2id(t, "correct horse", argonMemory, argonTime, argonThreads, 16)
+
+	cases := []struct {
+		name        string
+		password    string
+		encoded     string
+		ok          bool
+		needsRehash bool
+		wantErr     bool
+	}{
+		{"argon2id match", "correct horse", current, true, false, false},
+		{"argon2id mismatch", "wrong horse", current, false, false, false},
+		{"bcrypt match", "correct horse", string(legacy), true, true, false},
+		{"bcrypt mismatch", "wrong horse", string(legacy), false, false, false},
+		{"weaker argon2id params", "correct horse", weak, true, true, false},
+		{"shorter argon2id key", "correct horse", short, true, true, false},
+		{"weaker params mismatch", "wrong horse", weak, false, false, false},
+		{"plain text", "correct horse", "correct horse", false, false, true},
+		{"empty hash", "correct horse", "", false, false, true},
+		{"missing parts", "correct horse", "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA", false, false, true},
+		{"bad version", "correct horse", strings.Replace(current, "v=19", "v=16", 1), false, false, true},
+		{"bad params", "correct horse", strings.Replace(current, "m=65536,t=3,p=2", "m=x", 1), false, false, true},
+		{"bad salt", "correct horse", strings.Replace(current, "$"+strings.Split(current, "$")[4]+"$", "$!!$", 1), false, false, true},
+	}
+	for _, c := range cases {
+		ok, needsRehash, err := Verify(c.password, c.encoded)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", c.name, err, c.wantErr)
+			continue
+		}
+		if ok != c.ok || needsRehash != c.needsRehash {
+			t.Errorf("%s: got ok=%v needsRehash=%v, want ok=%v needsRehash=%v", c.name, ok, needsRehash, c.ok, c.needsRehash)
+		}
+	}
+}
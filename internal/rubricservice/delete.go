@@ -3,10 +3,14 @@ package rubricservice
 import (
 	"context"
 	"database/sql"
+	"rubr/internal/auth"
 	Pb "rubr/proto/rubric"
 )
 
 func (s *Server) DeleteCriteriaGroup(ctx context.Context, req *Pb.DeleteCriteriaGroupRequest) (*Pb.DeleteCriteriaGroupResponse, error) {
+	if err := auth.CheckCriteriaGroupOwner(ctx, s.Db, int64(req.GroupId)); err != nil {
+		return nil, err
+	}
 	query := `DELETE FROM criteria_groups WHERE id = $1 AND block_flag = false`
 	result, err := s.Db.ExecContext(ctx, query, req.GroupId)
 	if err != nil {
@@ -23,6 +27,9 @@ func (s *Server) DeleteCriteriaGroup(ctx context.Context, req *Pb.DeleteCriteria
 }
 
 func (s *Server) DeleteCriterion(ctx context.Context, req *Pb.DeleteCriterionRequest) (*Pb.DeleteCriterionResponse, error) {
+	if err := auth.CheckCriterionOwner(ctx, s.Db, int64(req.CriterionId)); err != nil {
+		return nil, err
+	}
 	query := `DELETE FROM criteria WHERE id = $1`
 	result, err := s.Db.ExecContext(ctx, query, req.CriterionId)
 	if err != nil {
@@ -39,6 +46,9 @@ func (s *Server) DeleteCriterion(ctx context.Context, req *Pb.DeleteCriterionReq
 }
 
 func (s *Server) DeleteBlockingCriteria(ctx context.Context, req *Pb.DeleteBlockingCriteriaRequest) (*Pb.DeleteBlockingCriteriaResponse, error) {
+	if err := auth.CheckCriterionOwner(ctx, s.Db, int64(req.CriteriaId)); err != nil {
+		return nil, err
+	}
 	query := `DELETE FROM criteria WHERE id = $1`
 	result, err := s.Db.ExecContext(ctx, query, req.CriteriaId)
 	if err != nil {
@@ -55,6 +65,9 @@ func (s *Server) DeleteBlockingCriteria(ctx context.Context, req *Pb.DeleteBlock
 }
 
 func (s *Server) DeleteTaskBlockingCriterias(ctx context.Context, req *Pb.DeleteTaskBlockingCriteriasRequest) (*Pb.DeleteTaskBlockingCriteriasResponse, error) {
+	if err := auth.CheckTaskOwner(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	// Находим ID группы критериев с block_flag = true и group_name = 'blocking_criterias'
 	var groupID int32
 	queryGroup := `SELECT id FROM criteria_groups WHERE task_id = $1 AND group_name = 'blocking_criterias' AND block_flag = true`
@@ -2,10 +2,15 @@ package rubricservice
 
 import (
 	"context"
+
+	"rubr/internal/auth"
 	Pb "rubr/proto/rubric"
 )
 
 func (s *Server) LoadTaskBlockingCriterias(ctx context.Context, req *Pb.LoadTaskBlockingCriteriasRequest) (*Pb.LoadTaskBlockingCriteriasResponse, error) {
+	if err := auth.CheckTaskAccess(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT c.id, c.name, c.description, c.comment_for_blocking_criteria, c.final_mark_for_blocking_criteria
 		FROM criteria c
@@ -32,6 +37,9 @@ func (s *Server) LoadTaskBlockingCriterias(ctx context.Context, req *Pb.LoadTask
 }
 
 func (s *Server) LoadTaskMainCriterias(ctx context.Context, req *Pb.LoadTaskMainCriteriasRequest) (*Pb.LoadTaskMainCriteriasResponse, error) {
+	if err := auth.CheckTaskAccess(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	// First, get all criteria groups for the task (excluding blocking criteria)
 	queryGroups := `SELECT id, group_name FROM criteria_groups WHERE task_id = $1 AND block_flag = false`
 	rows, err := s.Db.QueryContext(ctx, queryGroups, req.TaskId)
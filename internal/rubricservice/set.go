@@ -4,10 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"rubr/internal/auth"
 	Pb "rubr/proto/rubric"
 )
 
 func (s *Server) UpdateCriterionWeight(ctx context.Context, req *Pb.UpdateCriterionWeightRequest) (*Pb.UpdateCriterionWeightResponse, error) {
+	if err := auth.CheckCriterionOwner(ctx, s.Db, int64(req.CriterionId)); err != nil {
+		return nil, err
+	}
 	query := `UPDATE criteria SET weight = $1 WHERE id = $2`
 	_, err := s.Db.ExecContext(ctx, query, req.Weight, req.CriterionId)
 	if err != nil {
@@ -17,6 +21,9 @@ func (s *Server) UpdateCriterionWeight(ctx context.Context, req *Pb.UpdateCriter
 }
 
 func (s *Server) UpdateCriterionComment(ctx context.Context, req *Pb.UpdateCriterionCommentRequest) (*Pb.UpdateCriterionCommentResponse, error) {
+	if err := auth.CheckCriterionOwner(ctx, s.Db, int64(req.CriterionId)); err != nil {
+		return nil, err
+	}
 	var column string
 	switch req.Mark {
 	case "000":
@@ -41,6 +48,9 @@ func (s *Server) UpdateCriterionComment(ctx context.Context, req *Pb.UpdateCrite
 }
 
 func (s *Server) CreateCriteriaGroup(ctx context.Context, req *Pb.CreateCriteriaGroupRequest) (*Pb.CreateCriteriaGroupResponse, error) {
+	if err := auth.CheckTaskOwner(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `INSERT INTO criteria_groups (task_id, group_name, block_flag) VALUES ($1, $2, false) RETURNING id`
 	var groupID int32
 	err := s.Db.QueryRowContext(ctx, query, req.TaskId, req.GroupName).Scan(&groupID)
@@ -51,6 +61,9 @@ func (s *Server) CreateCriteriaGroup(ctx context.Context, req *Pb.CreateCriteria
 }
 
 func (s *Server) CreateCriterion(ctx context.Context, req *Pb.CreateCriterionRequest) (*Pb.CreateCriterionResponse, error) {
+	if err := auth.CheckCriteriaGroupOwner(ctx, s.Db, int64(req.GroupId)); err != nil {
+		return nil, err
+	}
 	query := `INSERT INTO criteria (name, criteria_group_id, weight) VALUES ($1, $2, 0) RETURNING id`
 	var criterionID int32
 	err := s.Db.QueryRowContext(ctx, query, req.Name, req.GroupId).Scan(&criterionID)
@@ -61,6 +74,9 @@ func (s *Server) CreateCriterion(ctx context.Context, req *Pb.CreateCriterionReq
 }
 
 func (s *Server) CreateNewBlockingCriteria(ctx context.Context, req *Pb.CreateNewBlockingCriteriaRequest) (*Pb.CreateNewBlockingCriteriaResponse, error) {
+	if err := auth.CheckTaskOwner(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	var groupID int32
 	query := `SELECT id FROM criteria_groups WHERE task_id = $1 AND group_name = 'blocking_criterias' AND block_flag = true`
 	err := s.Db.QueryRowContext(ctx, query, req.TaskId).Scan(&groupID)
@@ -85,6 +101,9 @@ func (s *Server) CreateNewBlockingCriteria(ctx context.Context, req *Pb.CreateNe
 }
 
 func (s *Server) CreateNewCriteriaGroup(ctx context.Context, req *Pb.CreateNewCriteriaGroupRequest) (*Pb.CreateNewCriteriaGroupResponse, error) {
+	if err := auth.CheckTaskOwner(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `INSERT INTO criteria_groups (task_id, group_name) VALUES ($1, $2) RETURNING id`
 	var groupID int32
 	err := s.Db.QueryRowContext(ctx, query, req.TaskId, req.GroupName).Scan(&groupID)
@@ -95,6 +114,9 @@ func (s *Server) CreateNewCriteriaGroup(ctx context.Context, req *Pb.CreateNewCr
 }
 
 func (s *Server) CreateNewMainCriteria(ctx context.Context, req *Pb.CreateNewMainCriteriaRequest) (*Pb.CreateNewMainCriteriaResponse, error) {
+	if err := auth.CheckCriteriaGroupOwner(ctx, s.Db, int64(req.CriteriaGroupId)); err != nil {
+		return nil, err
+	}
 	query := `INSERT INTO criteria (name, criteria_group_id, weight) VALUES ($1, $2, 0) RETURNING id`
 	var criteriaID int32
 	err := s.Db.QueryRowContext(ctx, query, req.Name, req.CriteriaGroupId).Scan(&criteriaID)
@@ -105,6 +127,9 @@ func (s *Server) CreateNewMainCriteria(ctx context.Context, req *Pb.CreateNewMai
 }
 
 func (s *Server) CreateCriteriaDescription(ctx context.Context, req *Pb.CreateCriteriaDescriptionRequest) (*Pb.CreateCriteriaDescriptionResponse, error) {
+	if err := auth.CheckCriterionOwner(ctx, s.Db, int64(req.CriteriaId)); err != nil {
+		return nil, err
+	}
 	var column string
 	switch req.Mark {
 	case "000":
@@ -129,6 +154,9 @@ func (s *Server) CreateCriteriaDescription(ctx context.Context, req *Pb.CreateCr
 }
 
 func (s *Server) SetCriteriaWeight(ctx context.Context, req *Pb.SetCriteriaWeightRequest) (*Pb.SetCriteriaWeightResponse, error) {
+	if err := auth.CheckCriterionOwner(ctx, s.Db, int64(req.CriteriaId)); err != nil {
+		return nil, err
+	}
 	query := `UPDATE criteria SET weight = $1 WHERE id = $2`
 	_, err := s.Db.ExecContext(ctx, query, req.Weight, req.CriteriaId)
 	if err != nil {
@@ -0,0 +1,104 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Секрет из тестовых векторов RFC 6238 (приложение B) для HMAC-SHA1
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCodeMatchesRFC6238Vectors(t *testing.T) {
+	// В RFC коды восьмизначные; шестизначный код — их последние шесть цифр
+	cases := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, c := range cases {
+		got, err := Code(rfcSecret, Step(time.Unix(c.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := c.code[len(c.code)-digits:]; got != want {
+			t.Errorf("T=%d: got %s, want %s", c.unix, got, want)
+		}
+	}
+}
+
+func TestCodeAcceptsLowercaseSecret(t *testing.T) {
+	upper, err := Code(rfcSecret, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lower, err := Code(strings.ToLower(rfcSecret), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upper != lower {
+		t.Errorf("lowercase secret gave %s, want %s", lower, upper)
+	}
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Error("expected an error for a malformed secret")
+	}
+}
+
+func TestValidateSkewAndReplay(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+	code := func(step int64) string {
+		c, err := Code(rfcSecret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	cases := []struct {
+		name     string
+		code     string
+		lastStep int64
+		step     int64
+		ok       bool
+	}{
+		{"current step", code(current), 0, current, true},
+		{"previous step within skew", code(current - 1), 0, current - 1, true},
+		{"next step within skew", code(current + 1), 0, current + 1, true},
+		{"two steps behind", code(current - 2), 0, 0, false},
+		{"two steps ahead", code(current + 2), 0, 0, false},
+		{"surrounding spaces", " " + code(current) + " ", 0, current, true},
+		{"replayed step", code(current), current, 0, false},
+		{"older than last accepted", code(current - 1), current - 1, 0, false},
+		{"newer than last accepted", code(current + 1), current, current + 1, true},
+		{"wrong length", code(current)[:digits-1], 0, 0, false},
+		{"wrong code", "000000", 0, 0, false},
+	}
+	for _, c := range cases {
+		step, ok := Validate(rfcSecret, c.code, now, c.lastStep)
+		if ok != c.ok || step != c.step {
+			t.Errorf("%s: got step=%d ok=%v, want step=%d ok=%v", c.name, step, ok, c.step, c.ok)
+		}
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := encoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("secret %q is not base32: %v", secret, err)
+	}
+	if len(key) != 20 {
+		t.Errorf("secret is %d bytes, want 20", len(key))
+	}
+}
@@ -0,0 +1,61 @@
+package userservice
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"rubr/internal/auth"
+	"rubr/internal/password"
+	pb "rubr/proto/user"
+)
+
+// AcceptInvitation активирует аккаунт, созданный суперакком: пользователь
+// предъявляет код из письма-приглашения и задаёт свой пароль.
+// Письмо пришло на адрес аккаунта, поэтому почта считается подтверждённой.
+func (s *Server) AcceptInvitation(ctx context.Context, req *pb.AcceptInvitationRequest) (*pb.AcceptInvitationResponse, error) {
+	if req.Token == "" || req.Password == "" {
+		return &pb.AcceptInvitationResponse{Error: "invitation code and password are required"}, nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &pb.AcceptInvitationResponse{Error: "internal server error"}, nil
+	}
+	defer tx.Rollback()
+
+	var invitationID, userID int
+	err = tx.QueryRowContext(ctx, `
+		SELECT i.id, i.user_id FROM invitations i
+		JOIN users u ON u.id = i.user_id
+		WHERE i.token_hash = $1 AND i.accepted_at IS NULL AND i.revoked_at IS NULL AND i.expires_at > now()
+		  AND u.deactivated_at IS NULL
+		FOR UPDATE OF i`, auth.HashOpaqueToken(req.Token)).Scan(&invitationID, &userID)
+	if err == sql.ErrNoRows {
+		return &pb.AcceptInvitationResponse{Error: "invalid or expired invitation code"}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to look up invitation: %v", err)
+		return &pb.AcceptInvitationResponse{Error: "internal server error"}, nil
+	}
+
+	hashedPassword, err := password.Hash(req.Password)
+	if err != nil {
+		return &pb.AcceptInvitationResponse{Error: "Failed to hash password"}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users SET password = $1, email_verified = TRUE WHERE id = $2`,
+		hashedPassword, userID); err != nil {
+		log.Printf("Failed to set password for invited user %d: %v", userID, err)
+		return &pb.AcceptInvitationResponse{Error: "failed to set password"}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE invitations SET accepted_at = now() WHERE id = $1`, invitationID); err != nil {
+		log.Printf("Failed to mark invitation %d as accepted: %v", invitationID, err)
+		return &pb.AcceptInvitationResponse{Error: "internal server error"}, nil
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Failed to commit invitation %d: %v", invitationID, err)
+		return &pb.AcceptInvitationResponse{Error: "internal server error"}, nil
+	}
+	return &pb.AcceptInvitationResponse{}, nil
+}
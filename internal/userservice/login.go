@@ -3,44 +3,120 @@ package userservice
 import (
 	"context"
 	"database/sql"
-	"github.com/dgrijalva/jwt-go"
-	"golang.org/x/crypto/bcrypt"
+	"log"
+	"rubr/internal/auth"
+	"rubr/internal/password"
 	pb "rubr/proto/user"
 	"strconv"
-	"time"
 )
 
+// errInvalidCredentials — единая ошибка для неизвестной почты и неверного пароля
+const errInvalidCredentials = "Invalid email or password"
+
 func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	if req.Email == "" || req.Password == "" {
 		return &pb.LoginResponse{Error: "Email and password are required"}, nil
 	}
 
+	ip := auth.ClientIP(ctx)
+	locked, err := s.loginLocked(ctx, req.Email, ip)
+	if err != nil {
+		log.Printf("Failed to check login throttle: %v", err)
+		return &pb.LoginResponse{Error: "internal server error"}, nil
+	}
+	if locked {
+		return &pb.LoginResponse{Error: "Too many failed login attempts, try again later"}, nil
+	}
+
 	var id int
-	var hashedPassword []byte
+	var hashedPassword string
 	var role string
-	query := `SELECT id, password, role FROM users WHERE email = $1`
-	err := s.Db.QueryRow(query, req.Email).Scan(&id, &hashedPassword, &role)
+	var emailVerified, active bool
+	query := `SELECT id, password, role, email_verified, deactivated_at IS NULL FROM users WHERE email = $1`
+	err = s.Db.QueryRow(query, req.Email).Scan(&id, &hashedPassword, &role, &emailVerified, &active)
 	if err == sql.ErrNoRows {
-		return &pb.LoginResponse{Error: "User not found"}, nil
+		burnPasswordCheck(req.Password)
+		s.recordLoginFailure(ctx, req.Email, ip)
+		return &pb.LoginResponse{Error: errInvalidCredentials}, nil
 	}
 	if err != nil {
-		return &pb.LoginResponse{Error: err.Error()}, nil
+		log.Printf("Failed to look up user for login: %v", err)
+		return &pb.LoginResponse{Error: "internal server error"}, nil
+	}
+
+	ok, needsRehash, err := password.Verify(req.Password, hashedPassword)
+	if err != nil {
+		log.Printf("Failed to verify password of user %d: %v", id, err)
+	}
+	if !ok {
+		s.recordLoginFailure(ctx, req.Email, ip)
+		return &pb.LoginResponse{Error: errInvalidCredentials}, nil
+	}
+	s.resetLoginFailures(ctx, req.Email)
+	if needsRehash {
+		s.rehashPassword(ctx, id, req.Password, hashedPassword)
+	}
+
+	if !active {
+		return &pb.LoginResponse{Error: "Account is deactivated"}, nil
+	}
+	if !emailVerified {
+		return &pb.LoginResponse{Error: "Email is not verified"}, nil
 	}
 
-	if err := bcrypt.CompareHashAndPassword(hashedPassword, []byte(req.Password)); err != nil {
-		return &pb.LoginResponse{Error: "Invalid password"}, nil
+	// Сотрудникам с подключённым или обязательным TOTP нужен второй шаг
+	if totpRoles[role] {
+		enrolled, err := s.totpEnabled(ctx, id)
+		if err != nil {
+			log.Printf("Failed to check TOTP of user %d: %v", id, err)
+			return &pb.LoginResponse{Error: "internal server error"}, nil
+		}
+		if enrolled || s.TotpRequiredRoles[role] {
+			mfaToken, err := s.Tokens.GenerateMFAPending(id, role)
+			if err != nil {
+				return &pb.LoginResponse{Error: "Failed to generate token"}, nil
+			}
+			return &pb.LoginResponse{
+				UserId:                 strconv.Itoa(id),
+				Role:                   role,
+				MfaRequired:            true,
+				TotpEnrollmentRequired: !enrolled,
+				MfaToken:               mfaToken,
+			}, nil
+		}
 	}
 
-	// Генерация JWT
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": id,
-		"role":    role,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // Токен действителен 24 часа
-	})
-	tokenString, err := token.SignedString([]byte("rubroz")) // safety key
+	return s.completeLogin(ctx, id, role), nil
+}
+
+// completeLogin создаёт сессию и возвращает токены вошедшему пользователю
+func (s *Server) completeLogin(ctx context.Context, id int, role string) *pb.LoginResponse {
+	tokenString, refreshToken, expiresAt, err := s.issueSession(ctx, id, role)
 	if err != nil {
-		return &pb.LoginResponse{Error: "Failed to generate token"}, nil
+		log.Printf("Failed to create session for user %d: %v", id, err)
+		return &pb.LoginResponse{Error: "Failed to generate token"}
 	}
 
-	return &pb.LoginResponse{UserId: strconv.Itoa(id), Token: tokenString, Role: role}, nil
+	return &pb.LoginResponse{
+		UserId:       strconv.Itoa(id),
+		Token:        tokenString,
+		Role:         role,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}
+}
+
+// rehashPassword переводит устаревший хеш (bcrypt) на Argon2id после успешного входа.
+// Ошибка не мешает входу: хеш обновится при следующем.
+func (s *Server) rehashPassword(ctx context.Context, userID int, plain, oldHash string) {
+	newHash, err := password.Hash(plain)
+	if err != nil {
+		log.Printf("Failed to rehash password of user %d: %v", userID, err)
+		return
+	}
+	// Условие на старый хеш защищает от затирания пароля, сменённого параллельно
+	_, err = s.Db.ExecContext(ctx, `UPDATE users SET password = $1 WHERE id = $2 AND password = $3`, newHash, userID, oldHash)
+	if err != nil {
+		log.Printf("Failed to store rehashed password of user %d: %v", userID, err)
+	}
 }
@@ -0,0 +1,146 @@
+package userservice
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"rubr/internal/auth"
+	"rubr/internal/password"
+	notifypb "rubr/proto/notification"
+	pb "rubr/proto/user"
+	"time"
+)
+
+// passwordResetTTL — срок действия кода сброса пароля
+const passwordResetTTL = 30 * time.Minute
+
+// RequestPasswordReset выпускает одноразовый код сброса и отправляет его на почту.
+// Ответ не зависит от того, существует ли пользователь, чтобы по нему нельзя
+// было перебирать адреса: сбои после поиска пользователя, в том числе отправки
+// письма, только пишутся в лог.
+func (s *Server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	if req.Email == "" {
+		return &pb.RequestPasswordResetResponse{Error: "email is required"}, nil
+	}
+
+	var userID int
+	// Приглашённый суперакком пользователь задаёт пароль только через приглашение
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT id FROM users
+		WHERE email = $1 AND deactivated_at IS NULL
+		  AND (NOT EXISTS(SELECT 1 FROM invitations i WHERE i.user_id = users.id)
+		       OR EXISTS(SELECT 1 FROM invitations i WHERE i.user_id = users.id AND i.accepted_at IS NOT NULL))`,
+		req.Email).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return &pb.RequestPasswordResetResponse{}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to look up user for password reset: %v", err)
+		return &pb.RequestPasswordResetResponse{Error: "internal server error"}, nil
+	}
+
+	token, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		log.Printf("Failed to generate password reset token: %v", err)
+		return &pb.RequestPasswordResetResponse{}, nil
+	}
+
+	// Новый код отменяет все ранее выданные
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &pb.RequestPasswordResetResponse{}, nil
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE password_reset_tokens SET used_at = now()
+		WHERE user_id = $1 AND used_at IS NULL`, userID)
+	if err != nil {
+		log.Printf("Failed to invalidate password reset tokens for user %d: %v", userID, err)
+		return &pb.RequestPasswordResetResponse{}, nil
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO password_reset_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)`,
+		userID, tokenHash, time.Now().Add(passwordResetTTL))
+	if err != nil {
+		log.Printf("Failed to store password reset token for user %d: %v", userID, err)
+		return &pb.RequestPasswordResetResponse{}, nil
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Failed to commit password reset token for user %d: %v", userID, err)
+		return &pb.RequestPasswordResetResponse{}, nil
+	}
+
+	resp, err := s.Notify.SendPasswordResetNotification(ctx, &notifypb.NotificationRequest{
+		UserId: int32(userID),
+		Email:  req.Email,
+		Message: fmt.Sprintf("Код для сброса пароля: %s\nВведите его в приложении вместе с новым паролем. Код действует %d минут.",
+			token, int(passwordResetTTL.Minutes())),
+		CreatedAt: time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		log.Printf("Failed to send password reset email to user %d: %v", userID, err)
+		return &pb.RequestPasswordResetResponse{}, nil
+	}
+	if resp.Error != "" {
+		log.Printf("Failed to send password reset email to user %d: %s", userID, resp.Error)
+		return &pb.RequestPasswordResetResponse{}, nil
+	}
+	return &pb.RequestPasswordResetResponse{}, nil
+}
+
+// ConfirmPasswordReset проверяет код сброса, гасит его и устанавливает новый пароль.
+// Все сессии пользователя при этом отзываются.
+func (s *Server) ConfirmPasswordReset(ctx context.Context, req *pb.ConfirmPasswordResetRequest) (*pb.ConfirmPasswordResetResponse, error) {
+	if req.Token == "" || req.NewPassword == "" {
+		return &pb.ConfirmPasswordResetResponse{Error: "token and new password are required"}, nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &pb.ConfirmPasswordResetResponse{Error: "internal server error"}, nil
+	}
+	defer tx.Rollback()
+
+	var tokenID, userID int
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, user_id FROM password_reset_tokens
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+		FOR UPDATE`, auth.HashOpaqueToken(req.Token)).Scan(&tokenID, &userID)
+	if err == sql.ErrNoRows {
+		return &pb.ConfirmPasswordResetResponse{Error: "invalid or expired reset code"}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to look up password reset token: %v", err)
+		return &pb.ConfirmPasswordResetResponse{Error: "internal server error"}, nil
+	}
+
+	hashedPassword, err := password.Hash(req.NewPassword)
+	if err != nil {
+		return &pb.ConfirmPasswordResetResponse{Error: "Failed to hash password"}, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET password = $1 WHERE id = $2`, hashedPassword, userID); err != nil {
+		log.Printf("Failed to update password for user %d: %v", userID, err)
+		return &pb.ConfirmPasswordResetResponse{Error: "failed to update password"}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE password_reset_tokens SET used_at = now() WHERE id = $1`, tokenID); err != nil {
+		log.Printf("Failed to mark password reset token %d as used: %v", tokenID, err)
+		return &pb.ConfirmPasswordResetResponse{Error: "internal server error"}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL`, userID); err != nil {
+		log.Printf("Failed to revoke sessions for user %d: %v", userID, err)
+		return &pb.ConfirmPasswordResetResponse{Error: "internal server error"}, nil
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Failed to commit password reset for user %d: %v", userID, err)
+		return &pb.ConfirmPasswordResetResponse{Error: "internal server error"}, nil
+	}
+	return &pb.ConfirmPasswordResetResponse{}, nil
+}
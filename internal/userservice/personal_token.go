@@ -0,0 +1,151 @@
+package userservice
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"rubr/internal/auth"
+	pb "rubr/proto/user"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// defaultTokenDays и maxTokenDays — срок действия токена для скриптов
+	defaultTokenDays = 90
+	maxTokenDays     = 365
+	maxTokenName     = 64
+	// maxActiveTokens ограничивает число действующих токенов у одного пользователя
+	maxActiveTokens = 20
+)
+
+// CreatePersonalToken выпускает именованный токен для скриптов. Токен показывается
+// один раз, в БД хранится только его хеш; области не шире прав роли владельца.
+func (s *Server) CreatePersonalToken(ctx context.Context, req *pb.CreatePersonalTokenRequest) (*pb.CreatePersonalTokenResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return &pb.CreatePersonalTokenResponse{Error: "token name is required"}, nil
+	}
+	if utf8.RuneCountInString(name) > maxTokenName {
+		return &pb.CreatePersonalTokenResponse{Error: "token name is too long"}, nil
+	}
+	days := int(req.ExpiresInDays)
+	if days == 0 {
+		days = defaultTokenDays
+	}
+	if days < 0 || days > maxTokenDays {
+		return &pb.CreatePersonalTokenResponse{Error: "token lifetime must be between 1 and 365 days"}, nil
+	}
+	scopes := uniqueScopes(req.Scopes)
+	if err := auth.ValidateScopes(id.Role, scopes); err != nil {
+		return &pb.CreatePersonalTokenResponse{Error: err.Error()}, nil
+	}
+
+	var active int
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM personal_access_tokens
+		WHERE user_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > now())`,
+		id.UserID).Scan(&active)
+	if err != nil {
+		log.Printf("Failed to count personal tokens of user %d: %v", id.UserID, err)
+		return &pb.CreatePersonalTokenResponse{Error: "internal server error"}, nil
+	}
+	if active >= maxActiveTokens {
+		return &pb.CreatePersonalTokenResponse{Error: "too many active tokens, revoke unused ones first"}, nil
+	}
+
+	token, hash, err := auth.NewPersonalToken()
+	if err != nil {
+		log.Printf("Failed to generate personal token: %v", err)
+		return &pb.CreatePersonalTokenResponse{Error: "internal server error"}, nil
+	}
+	var pt pb.PersonalToken
+	var createdAt, expiresAt time.Time
+	err = s.Db.QueryRowContext(ctx, `
+		INSERT INTO personal_access_tokens (user_id, name, token_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, expires_at`,
+		id.UserID, name, hash, strings.Join(scopes, " "), time.Now().AddDate(0, 0, days)).Scan(&pt.Id, &createdAt, &expiresAt)
+	if err != nil {
+		log.Printf("Failed to store personal token of user %d: %v", id.UserID, err)
+		return &pb.CreatePersonalTokenResponse{Error: "internal server error"}, nil
+	}
+	pt.Name = name
+	pt.Scopes = scopes
+	pt.CreatedAt = createdAt.Format(time.RFC3339)
+	pt.ExpiresAt = expiresAt.Format(time.RFC3339)
+	return &pb.CreatePersonalTokenResponse{Token: token, PersonalToken: &pt}, nil
+}
+
+// ListPersonalTokens перечисляет токены текущего пользователя без самих значений
+func (s *Server) ListPersonalTokens(ctx context.Context, req *pb.ListPersonalTokensRequest) (*pb.ListPersonalTokensResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	rows, err := s.Db.QueryContext(ctx, `
+		SELECT id, name, scopes, created_at, expires_at, last_used_at, revoked_at IS NOT NULL
+		FROM personal_access_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC`, id.UserID)
+	if err != nil {
+		log.Printf("Failed to list personal tokens of user %d: %v", id.UserID, err)
+		return &pb.ListPersonalTokensResponse{Error: "internal server error"}, nil
+	}
+	defer rows.Close()
+
+	var tokens []*pb.PersonalToken
+	for rows.Next() {
+		var pt pb.PersonalToken
+		var scopes string
+		var createdAt time.Time
+		var expiresAt, lastUsedAt sql.NullTime
+		if err := rows.Scan(&pt.Id, &pt.Name, &scopes, &createdAt, &expiresAt, &lastUsedAt, &pt.Revoked); err != nil {
+			log.Printf("Failed to scan personal token: %v", err)
+			return &pb.ListPersonalTokensResponse{Error: "internal server error"}, nil
+		}
+		pt.Scopes = strings.Fields(scopes)
+		pt.CreatedAt = createdAt.Format(time.RFC3339)
+		if expiresAt.Valid {
+			pt.ExpiresAt = expiresAt.Time.Format(time.RFC3339)
+		}
+		if lastUsedAt.Valid {
+			pt.LastUsedAt = lastUsedAt.Time.Format(time.RFC3339)
+		}
+		tokens = append(tokens, &pt)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to list personal tokens of user %d: %v", id.UserID, err)
+		return &pb.ListPersonalTokensResponse{Error: "internal server error"}, nil
+	}
+	return &pb.ListPersonalTokensResponse{Tokens: tokens, AvailableScopes: auth.AvailableScopes(id.Role)}, nil
+}
+
+// RevokePersonalToken отзывает токен текущего пользователя
+func (s *Server) RevokePersonalToken(ctx context.Context, req *pb.RevokePersonalTokenRequest) (*pb.RevokePersonalTokenResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	result, err := s.Db.ExecContext(ctx, `
+		UPDATE personal_access_tokens SET revoked_at = now()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`, req.TokenId, id.UserID)
+	if err != nil {
+		log.Printf("Failed to revoke personal token %d: %v", req.TokenId, err)
+		return &pb.RevokePersonalTokenResponse{Error: "internal server error"}, nil
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return &pb.RevokePersonalTokenResponse{Error: "token not found"}, nil
+	}
+	return &pb.RevokePersonalTokenResponse{}, nil
+}
+
+func uniqueScopes(scopes []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		result = append(result, scope)
+	}
+	return result
+}
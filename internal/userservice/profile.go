@@ -0,0 +1,163 @@
+package userservice
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"rubr/internal/auth"
+	"rubr/internal/password"
+	pb "rubr/proto/user"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxNameLength совпадает с VARCHAR(32) у name, surname и patronymic в таблице users
+const maxNameLength = 32
+
+// validateName проверяет одну часть ФИО; пустое значение допустимо только для необязательных полей
+func validateName(field, value string, required bool) error {
+	if value == "" {
+		if required {
+			return fmt.Errorf("%s must not be empty", field)
+		}
+		return nil
+	}
+	if utf8.RuneCountInString(value) > maxNameLength {
+		return fmt.Errorf("%s must be at most %d characters", field, maxNameLength)
+	}
+	return nil
+}
+
+// loadProfile читает данные пользователя для страницы профиля
+func (s *Server) loadProfile(ctx context.Context, userID int) (*pb.User, error) {
+	var user pb.User
+	var patronymic sql.NullString
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT id, name, surname, patronymic, email, role FROM users WHERE id = $1`,
+		userID).Scan(&user.Id, &user.Name, &user.Surname, &patronymic, &user.Email, &user.Role)
+	if err != nil {
+		return nil, err
+	}
+	user.Patronymic = patronymic.String
+	return &user, nil
+}
+
+// GetMyProfile возвращает профиль текущего пользователя
+func (s *Server) GetMyProfile(ctx context.Context, req *pb.GetMyProfileRequest) (*pb.GetMyProfileResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	user, err := s.loadProfile(ctx, id.UserID)
+	if err == sql.ErrNoRows {
+		return &pb.GetMyProfileResponse{Error: "User not found"}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to load profile of user %d: %v", id.UserID, err)
+		return &pb.GetMyProfileResponse{Error: "internal server error"}, nil
+	}
+	enabled, err := s.totpEnabled(ctx, id.UserID)
+	if err != nil {
+		log.Printf("Failed to check TOTP of user %d: %v", id.UserID, err)
+		return &pb.GetMyProfileResponse{Error: "internal server error"}, nil
+	}
+	return &pb.GetMyProfileResponse{User: user, TotpEnabled: enabled}, nil
+}
+
+// UpdateMyProfile меняет ФИО текущего пользователя; почта и роль здесь не меняются
+func (s *Server) UpdateMyProfile(ctx context.Context, req *pb.UpdateMyProfileRequest) (*pb.UpdateMyProfileResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	name := strings.TrimSpace(req.Name)
+	surname := strings.TrimSpace(req.Surname)
+	patronymic := strings.TrimSpace(req.Patronymic)
+	for _, err := range []error{
+		validateName("name", name, true),
+		validateName("surname", surname, true),
+		validateName("patronymic", patronymic, false),
+	} {
+		if err != nil {
+			return &pb.UpdateMyProfileResponse{Error: err.Error()}, nil
+		}
+	}
+
+	_, err := s.Db.ExecContext(ctx, `
+		UPDATE users SET name = $1, surname = $2, patronymic = NULLIF($3, '') WHERE id = $4`,
+		name, surname, patronymic, id.UserID)
+	if err != nil {
+		log.Printf("Failed to update profile of user %d: %v", id.UserID, err)
+		return &pb.UpdateMyProfileResponse{Error: "failed to update profile"}, nil
+	}
+	user, err := s.loadProfile(ctx, id.UserID)
+	if err != nil {
+		log.Printf("Failed to load profile of user %d: %v", id.UserID, err)
+		return &pb.UpdateMyProfileResponse{Error: "internal server error"}, nil
+	}
+	return &pb.UpdateMyProfileResponse{User: user}, nil
+}
+
+// ChangePassword меняет пароль по текущему паролю.
+// Неверный текущий пароль учитывается так же, как неудачный вход; остальные сессии
+// и все личные токены отзываются.
+func (s *Server) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		return &pb.ChangePasswordResponse{Error: "current and new password are required"}, nil
+	}
+
+	var email, hash string
+	err := s.Db.QueryRowContext(ctx, `SELECT email, password FROM users WHERE id = $1`, id.UserID).Scan(&email, &hash)
+	if err == sql.ErrNoRows {
+		return &pb.ChangePasswordResponse{Error: "User not found"}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to load password of user %d: %v", id.UserID, err)
+		return &pb.ChangePasswordResponse{Error: "internal server error"}, nil
+	}
+
+	ip := auth.ClientIP(ctx)
+	locked, err := s.loginLocked(ctx, email, ip)
+	if err != nil {
+		log.Printf("Failed to check login throttle: %v", err)
+		return &pb.ChangePasswordResponse{Error: "internal server error"}, nil
+	}
+	if locked {
+		return &pb.ChangePasswordResponse{Error: "Too many failed login attempts, try again later"}, nil
+	}
+	ok, _, err := password.Verify(req.CurrentPassword, hash)
+	if err != nil || !ok {
+		s.recordLoginFailure(ctx, email, ip)
+		return &pb.ChangePasswordResponse{Error: "Invalid current password"}, nil
+	}
+	s.resetLoginFailures(ctx, email)
+
+	hashedPassword, err := password.Hash(req.NewPassword)
+	if err != nil {
+		return &pb.ChangePasswordResponse{Error: "Failed to hash password"}, nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &pb.ChangePasswordResponse{Error: "internal server error"}, nil
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET password = $1 WHERE id = $2`, hashedPassword, id.UserID); err != nil {
+		log.Printf("Failed to update password for user %d: %v", id.UserID, err)
+		return &pb.ChangePasswordResponse{Error: "failed to update password"}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = now()
+		WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL`, id.UserID, id.SessionID); err != nil {
+		log.Printf("Failed to revoke sessions for user %d: %v", id.UserID, err)
+		return &pb.ChangePasswordResponse{Error: "internal server error"}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE personal_access_tokens SET revoked_at = now()
+		WHERE user_id = $1 AND revoked_at IS NULL`, id.UserID); err != nil {
+		log.Printf("Failed to revoke personal tokens for user %d: %v", id.UserID, err)
+		return &pb.ChangePasswordResponse{Error: "internal server error"}, nil
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Failed to commit password change for user %d: %v", id.UserID, err)
+		return &pb.ChangePasswordResponse{Error: "internal server error"}, nil
+	}
+	return &pb.ChangePasswordResponse{}, nil
+}
@@ -3,7 +3,8 @@ package userservice
 import (
 	"context"
 	"github.com/lib/pq"
-	"golang.org/x/crypto/bcrypt"
+	"log"
+	"rubr/internal/password"
 	pb "rubr/proto/user"
 	"strconv"
 )
@@ -15,7 +16,7 @@ func (s *Server) RegisterUser(ctx context.Context, req *pb.RegisterUserRequest)
 	}
 
 	// Хеширование пароля
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	hashedPassword, err := password.Hash(req.Password)
 	if err != nil {
 		return &pb.RegisterUserResponse{Error: "Failed to hash password"}, nil
 	}
@@ -31,5 +32,11 @@ func (s *Server) RegisterUser(ctx context.Context, req *pb.RegisterUserRequest)
 		return &pb.RegisterUserResponse{Error: err.Error()}, nil
 	}
 
+	// Аккаунт остаётся неподтверждённым, пока пользователь не введёт код из письма.
+	// Если письмо не ушло, код можно запросить повторно через ResendVerification.
+	if err := s.sendVerification(ctx, id, req.Email); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", id, err)
+	}
+
 	return &pb.RegisterUserResponse{UserId: strconv.Itoa(id)}, nil
 }
@@ -2,10 +2,16 @@ package userservice
 
 import (
 	"database/sql"
+	"rubr/internal/auth"
+	notifypb "rubr/proto/notification"
 	Pb "rubr/proto/user"
 )
 
 type Server struct {
 	Pb.UnimplementedUserServiceServer
-	Db *sql.DB
+	Db     *sql.DB
+	Tokens *auth.TokenManager
+	Notify notifypb.NotificationServiceClient
+	// TotpRequiredRoles — роли, которым нельзя войти без второго фактора
+	TotpRequiredRoles map[string]bool
 }
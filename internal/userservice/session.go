@@ -0,0 +1,170 @@
+package userservice
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"rubr/internal/auth"
+	pb "rubr/proto/user"
+	"time"
+)
+
+// issueSession создаёт сессию и выпускает для неё токен доступа и refresh-токен
+func (s *Server) issueSession(ctx context.Context, userID int, role string) (access, refresh string, expiresAt int64, err error) {
+	refresh, refreshHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	var sessionID int
+	err = s.Db.QueryRowContext(ctx, `
+		INSERT INTO sessions (user_id, refresh_token_hash, user_agent, ip, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id`,
+		userID, refreshHash, auth.UserAgent(ctx), auth.ClientIP(ctx), time.Now().Add(auth.RefreshTokenTTL)).Scan(&sessionID)
+	if err != nil {
+		return "", "", 0, err
+	}
+
+	access, err = s.Tokens.Generate(userID, role, sessionID)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return access, refresh, time.Now().Add(s.Tokens.TTL()).Unix(), nil
+}
+
+// RefreshToken обменивает refresh-токен на новую пару токенов. Старый
+// refresh-токен при этом перестаёт действовать; его повторное предъявление
+// считается кражей и отзывает всю сессию.
+func (s *Server) RefreshToken(ctx context.Context, req *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return &pb.RefreshTokenResponse{Error: "refresh token is required"}, nil
+	}
+	hash := auth.HashOpaqueToken(req.RefreshToken)
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &pb.RefreshTokenResponse{Error: "internal server error"}, nil
+	}
+	defer tx.Rollback()
+
+	var sessionID, userID int
+	var role string
+	err = tx.QueryRowContext(ctx, `
+		SELECT s.id, s.user_id, u.role
+		FROM sessions s
+		JOIN users u ON u.id = s.user_id
+		WHERE s.refresh_token_hash = $1 AND s.revoked_at IS NULL AND s.expires_at > now()
+		  AND u.deactivated_at IS NULL
+		FOR UPDATE OF s`, hash).Scan(&sessionID, &userID, &role)
+	if err == sql.ErrNoRows {
+		result, err := s.Db.ExecContext(ctx, `
+			UPDATE sessions SET revoked_at = now()
+			WHERE previous_token_hash = $1 AND revoked_at IS NULL`, hash)
+		if err != nil {
+			log.Printf("Failed to revoke session on refresh token reuse: %v", err)
+		} else if n, _ := result.RowsAffected(); n > 0 {
+			log.Printf("Refresh token reuse detected, session revoked")
+		}
+		return &pb.RefreshTokenResponse{Error: "invalid refresh token"}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to look up session: %v", err)
+		return &pb.RefreshTokenResponse{Error: "internal server error"}, nil
+	}
+
+	refresh, refreshHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		log.Printf("Failed to generate refresh token: %v", err)
+		return &pb.RefreshTokenResponse{Error: "internal server error"}, nil
+	}
+	_, err = tx.ExecContext(ctx, `
+		UPDATE sessions
+		SET previous_token_hash = refresh_token_hash, refresh_token_hash = $1,
+		    last_used_at = now(), expires_at = $2
+		WHERE id = $3`,
+		refreshHash, time.Now().Add(auth.RefreshTokenTTL), sessionID)
+	if err != nil {
+		log.Printf("Failed to rotate refresh token for session %d: %v", sessionID, err)
+		return &pb.RefreshTokenResponse{Error: "internal server error"}, nil
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Failed to commit refresh of session %d: %v", sessionID, err)
+		return &pb.RefreshTokenResponse{Error: "internal server error"}, nil
+	}
+
+	access, err := s.Tokens.Generate(userID, role, sessionID)
+	if err != nil {
+		return &pb.RefreshTokenResponse{Error: "Failed to generate token"}, nil
+	}
+	return &pb.RefreshTokenResponse{
+		Token:        access,
+		RefreshToken: refresh,
+		ExpiresAt:    time.Now().Add(s.Tokens.TTL()).Unix(),
+	}, nil
+}
+
+// Logout отзывает текущую сессию
+func (s *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	_, err := s.Db.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = now()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`, id.SessionID, id.UserID)
+	if err != nil {
+		log.Printf("Failed to revoke session %d: %v", id.SessionID, err)
+		return &pb.LogoutResponse{Error: "failed to log out"}, nil
+	}
+	return &pb.LogoutResponse{}, nil
+}
+
+// ListSessions возвращает активные сессии текущего пользователя
+func (s *Server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	rows, err := s.Db.QueryContext(ctx, `
+		SELECT id, created_at, last_used_at, expires_at, COALESCE(user_agent, ''), COALESCE(ip, '')
+		FROM sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > now()
+		ORDER BY last_used_at DESC`, id.UserID)
+	if err != nil {
+		log.Printf("Failed to list sessions for user %d: %v", id.UserID, err)
+		return &pb.ListSessionsResponse{Error: "failed to list sessions"}, nil
+	}
+	defer rows.Close()
+
+	var sessions []*pb.Session
+	for rows.Next() {
+		var session pb.Session
+		var createdAt, lastUsedAt, expiresAt time.Time
+		if err := rows.Scan(&session.Id, &createdAt, &lastUsedAt, &expiresAt, &session.UserAgent, &session.Ip); err != nil {
+			log.Printf("Failed to scan session: %v", err)
+			return &pb.ListSessionsResponse{Error: "failed to list sessions"}, nil
+		}
+		session.CreatedAt = createdAt.Format(time.RFC3339)
+		session.LastUsedAt = lastUsedAt.Format(time.RFC3339)
+		session.ExpiresAt = expiresAt.Format(time.RFC3339)
+		session.Current = int(session.Id) == id.SessionID
+		sessions = append(sessions, &session)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("Failed to iterate sessions: %v", err)
+		return &pb.ListSessionsResponse{Error: "failed to list sessions"}, nil
+	}
+	return &pb.ListSessionsResponse{Sessions: sessions}, nil
+}
+
+// RevokeSession отзывает одну из сессий текущего пользователя
+func (s *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	result, err := s.Db.ExecContext(ctx, `
+		UPDATE sessions SET revoked_at = now()
+		WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`, req.SessionId, id.UserID)
+	if err != nil {
+		log.Printf("Failed to revoke session %d: %v", req.SessionId, err)
+		return &pb.RevokeSessionResponse{Error: "failed to revoke session"}, nil
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return &pb.RevokeSessionResponse{Error: "session not found"}, nil
+	}
+	return &pb.RevokeSessionResponse{}, nil
+}
@@ -0,0 +1,122 @@
+package userservice
+
+import (
+	"context"
+	"log"
+	"rubr/internal/password"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Ограничение попыток входа. Неудачи считаются отдельно по почте (включая
+// несуществующие адреса, чтобы блокировка не выдавала наличие аккаунта) и по IP.
+// После порога каждая следующая неудача удваивает время блокировки.
+const (
+	accountFailureThreshold = 5
+	ipFailureThreshold      = 20
+	baseLockout             = 30 * time.Second
+	maxLockout              = time.Hour
+	// failureWindow — через сколько после последней неудачи счётчик обнуляется
+	failureWindow = time.Hour
+)
+
+const (
+	throttleScopeAccount = "account"
+	throttleScopeIP      = "ip"
+)
+
+// lockoutFor возвращает длительность блокировки после failures неудач подряд
+func lockoutFor(failures, threshold int) time.Duration {
+	if failures < threshold {
+		return 0
+	}
+	lockout := baseLockout
+	for i := threshold; i < failures && lockout < maxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > maxLockout {
+		lockout = maxLockout
+	}
+	return lockout
+}
+
+// loginLocked сообщает, заблокирован ли вход для почты или IP
+func (s *Server) loginLocked(ctx context.Context, email, ip string) (bool, error) {
+	var locked bool
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM login_throttle
+			WHERE ((scope = $1 AND subject = $2) OR (scope = $3 AND subject = $4))
+			  AND locked_until > now()
+		)`, throttleScopeAccount, normalizeEmail(email), throttleScopeIP, ip).Scan(&locked)
+	return locked, err
+}
+
+// recordLoginFailure учитывает неудачную попытку и при необходимости блокирует вход
+func (s *Server) recordLoginFailure(ctx context.Context, email, ip string) {
+	s.recordFailure(ctx, throttleScopeAccount, normalizeEmail(email), accountFailureThreshold)
+	if ip != "" {
+		s.recordFailure(ctx, throttleScopeIP, ip, ipFailureThreshold)
+	}
+}
+
+func (s *Server) recordFailure(ctx context.Context, scope, subject string, threshold int) {
+	var failures int
+	err := s.Db.QueryRowContext(ctx, `
+		INSERT INTO login_throttle (scope, subject, failures, last_failure_at)
+		VALUES ($1, $2, 1, now())
+		ON CONFLICT (scope, subject) DO UPDATE SET
+			failures = CASE
+				WHEN login_throttle.last_failure_at < now() - make_interval(secs => $3) THEN 1
+				ELSE login_throttle.failures + 1
+			END,
+			last_failure_at = now()
+		RETURNING failures`, scope, subject, failureWindow.Seconds()).Scan(&failures)
+	if err != nil {
+		log.Printf("Failed to record login failure for %s %s: %v", scope, subject, err)
+		return
+	}
+
+	lockout := lockoutFor(failures, threshold)
+	if lockout == 0 {
+		return
+	}
+	log.Printf("Login locked for %s %s for %s after %d failures", scope, subject, lockout, failures)
+	_, err = s.Db.ExecContext(ctx, `
+		UPDATE login_throttle SET locked_until = $1
+		WHERE scope = $2 AND subject = $3`, time.Now().Add(lockout), scope, subject)
+	if err != nil {
+		log.Printf("Failed to lock login for %s %s: %v", scope, subject, err)
+	}
+}
+
+// resetLoginFailures сбрасывает счётчик аккаунта после успешного входа.
+// Счётчик IP не сбрасывается, иначе перебор можно было бы чередовать со входом в свой аккаунт.
+func (s *Server) resetLoginFailures(ctx context.Context, email string) {
+	_, err := s.Db.ExecContext(ctx, `
+		DELETE FROM login_throttle WHERE scope = $1 AND subject = $2`,
+		throttleScopeAccount, normalizeEmail(email))
+	if err != nil {
+		log.Printf("Failed to reset login failures: %v", err)
+	}
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// burnPasswordCheck тратит на несуществующий аккаунт столько же времени,
+// сколько на проверку настоящего пароля, чтобы аккаунты нельзя было
+// перебирать по времени ответа
+func burnPasswordCheck(plain string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = password.Hash("dummy password")
+	})
+	password.Verify(plain, dummyHash)
+}
@@ -0,0 +1,65 @@
+package userservice
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockoutFor(t *testing.T) {
+	cases := []struct {
+		failures  int
+		threshold int
+		want      time.Duration
+	}{
+		{0, accountFailureThreshold, 0},
+		{accountFailureThreshold - 1, accountFailureThreshold, 0},
+		{accountFailureThreshold, accountFailureThreshold, baseLockout},
+		{accountFailureThreshold + 1, accountFailureThreshold, 2 * baseLockout},
+		{accountFailureThreshold + 3, accountFailureThreshold, 8 * baseLockout},
+		{accountFailureThreshold + 6, accountFailureThreshold, 64 * baseLockout},
+		{accountFailureThreshold + 7, accountFailureThreshold, maxLockout},
+		{accountFailureThreshold + 1000, accountFailureThreshold, maxLockout},
+		{ipFailureThreshold - 1, ipFailureThreshold, 0},
+		{ipFailureThreshold, ipFailureThreshold, baseLockout},
+		{ipFailureThreshold + 2, ipFailureThreshold, 4 * baseLockout},
+	}
+	for _, c := range cases {
+		if got := lockoutFor(c.failures, c.threshold); got != c.want {
+			t.Errorf("lockoutFor(%d, %d) = %s, want %s", c.failures, c.threshold, got, c.want)
+		}
+	}
+}
+
+func TestLockoutGrowsMonotonically(t *testing.T) {
+	prev := time.Duration(0)
+	for failures := 0; failures < 100; failures++ {
+		got := lockoutFor(failures, accountFailureThreshold)
+		if got < prev {
+			t.Fatalf("lockout shrank from %s to %s at %d failures", prev, got, failures)
+		}
+		if got > maxLockout {
+			t.Fatalf("lockout %s exceeds the cap %s at %d failures", got, maxLockout, failures)
+		}
+		prev = got
+	}
+}
+
+func TestFailureWindowOutlastsLockout(t *testing.T) {
+	// Если окно короче блокировки, счётчик обнулится до её конца и удвоение не сработает
+	if failureWindow < maxLockout {
+		t.Errorf("failure window %s is shorter than the longest lockout %s", failureWindow, maxLockout)
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	cases := map[string]string{
+		"user@example.com":      "user@example.com",
+		"  User@Example.COM \t": "user@example.com",
+		"":                      "",
+	}
+	for in, want := range cases {
+		if got := normalizeEmail(in); got != want {
+			t.Errorf("normalizeEmail(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package userservice
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"fmt"
+	"log"
+	"rubr/internal/auth"
+	"rubr/internal/totp"
+	pb "rubr/proto/user"
+	"strings"
+	"time"
+)
+
+const (
+	totpIssuer        = "rubr"
+	recoveryCodeCount = 10
+)
+
+// totpRoles — роли, которым доступен второй фактор: они меняют оценки и составы групп
+var totpRoles = map[string]bool{
+	auth.RoleSeminarist:   true,
+	auth.RoleLecturer:     true,
+	auth.RoleSuperaccount: true,
+}
+
+// ParseTotpRequiredRoles разбирает список ролей через запятую, для которых TOTP обязателен
+func ParseTotpRequiredRoles(value string) (map[string]bool, error) {
+	roles := make(map[string]bool)
+	for _, role := range strings.Split(value, ",") {
+		role = strings.TrimSpace(role)
+		if role == "" {
+			continue
+		}
+		if !totpRoles[role] {
+			return nil, fmt.Errorf("TOTP is not available for role %q", role)
+		}
+		roles[role] = true
+	}
+	return roles, nil
+}
+
+// totpEnabled сообщает, подключён ли у пользователя второй фактор
+func (s *Server) totpEnabled(ctx context.Context, userID int) (bool, error) {
+	var enabled bool
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT EXISTS(SELECT 1 FROM user_totp WHERE user_id = $1 AND confirmed_at IS NOT NULL)`,
+		userID).Scan(&enabled)
+	return enabled, err
+}
+
+// checkSecondFactor принимает код из приложения или неиспользованный резервный код
+func (s *Server) checkSecondFactor(ctx context.Context, userID int, code string) (bool, error) {
+	var secret string
+	var lastStep int64
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT secret, last_used_step FROM user_totp
+		WHERE user_id = $1 AND confirmed_at IS NOT NULL`, userID).Scan(&secret, &lastStep)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if step, ok := totp.Validate(secret, code, time.Now(), lastStep); ok {
+		// Условие на шаг не даёт принять один код дважды при параллельных запросах
+		result, err := s.Db.ExecContext(ctx, `
+			UPDATE user_totp SET last_used_step = $1
+			WHERE user_id = $2 AND last_used_step < $1`, step, userID)
+		if err != nil {
+			return false, err
+		}
+		n, _ := result.RowsAffected()
+		return n == 1, nil
+	}
+
+	result, err := s.Db.ExecContext(ctx, `
+		UPDATE totp_recovery_codes SET used_at = now()
+		WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`,
+		userID, auth.HashOpaqueToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		return false, err
+	}
+	n, _ := result.RowsAffected()
+	if n == 1 {
+		log.Printf("User %d logged in with a recovery code", userID)
+	}
+	return n == 1, nil
+}
+
+// VerifyTotp — второй шаг входа: по промежуточному токену и коду выдаёт сессию
+func (s *Server) VerifyTotp(ctx context.Context, req *pb.VerifyTotpRequest) (*pb.LoginResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	if !id.MFAPending {
+		return &pb.LoginResponse{Error: "no login is waiting for a second factor"}, nil
+	}
+	if req.Code == "" {
+		return &pb.LoginResponse{Error: "code is required"}, nil
+	}
+
+	var email string
+	var active bool
+	if err := s.Db.QueryRowContext(ctx, `SELECT email, deactivated_at IS NULL FROM users WHERE id = $1`, id.UserID).Scan(&email, &active); err != nil {
+		log.Printf("Failed to look up user %d: %v", id.UserID, err)
+		return &pb.LoginResponse{Error: "internal server error"}, nil
+	}
+	if !active {
+		return &pb.LoginResponse{Error: "Account is deactivated"}, nil
+	}
+
+	// Подбор кода ограничивается так же, как подбор пароля
+	ip := auth.ClientIP(ctx)
+	locked, err := s.loginLocked(ctx, email, ip)
+	if err != nil {
+		log.Printf("Failed to check login throttle: %v", err)
+		return &pb.LoginResponse{Error: "internal server error"}, nil
+	}
+	if locked {
+		return &pb.LoginResponse{Error: "Too many failed login attempts, try again later"}, nil
+	}
+
+	ok, err := s.checkSecondFactor(ctx, id.UserID, req.Code)
+	if err != nil {
+		log.Printf("Failed to check second factor of user %d: %v", id.UserID, err)
+		return &pb.LoginResponse{Error: "internal server error"}, nil
+	}
+	if !ok {
+		s.recordLoginFailure(ctx, email, ip)
+		return &pb.LoginResponse{Error: "Invalid code"}, nil
+	}
+	s.resetLoginFailures(ctx, email)
+	return s.completeLogin(ctx, id.UserID, id.Role), nil
+}
+
+// BeginTotpEnrollment создаёт новый секрет; он начнёт действовать после ConfirmTotpEnrollment
+func (s *Server) BeginTotpEnrollment(ctx context.Context, req *pb.BeginTotpEnrollmentRequest) (*pb.BeginTotpEnrollmentResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	enabled, err := s.totpEnabled(ctx, id.UserID)
+	if err != nil {
+		log.Printf("Failed to check TOTP of user %d: %v", id.UserID, err)
+		return &pb.BeginTotpEnrollmentResponse{Error: "internal server error"}, nil
+	}
+	if enabled {
+		return &pb.BeginTotpEnrollmentResponse{Error: "two-factor authentication is already enabled"}, nil
+	}
+
+	var email string
+	if err := s.Db.QueryRowContext(ctx, `SELECT email FROM users WHERE id = $1`, id.UserID).Scan(&email); err != nil {
+		log.Printf("Failed to look up user %d: %v", id.UserID, err)
+		return &pb.BeginTotpEnrollmentResponse{Error: "internal server error"}, nil
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Printf("Failed to generate TOTP secret: %v", err)
+		return &pb.BeginTotpEnrollmentResponse{Error: "internal server error"}, nil
+	}
+	_, err = s.Db.ExecContext(ctx, `
+		INSERT INTO user_totp (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0, created_at = now()`,
+		id.UserID, secret)
+	if err != nil {
+		log.Printf("Failed to store TOTP secret of user %d: %v", id.UserID, err)
+		return &pb.BeginTotpEnrollmentResponse{Error: "internal server error"}, nil
+	}
+
+	return &pb.BeginTotpEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningUri: totp.ProvisioningURI(totpIssuer, email, secret),
+	}, nil
+}
+
+// ConfirmTotpEnrollment включает второй фактор после проверки первого кода
+// и выдаёт резервные коды. Если подключение было обязательным шагом входа,
+// сразу выдаётся и сессия.
+func (s *Server) ConfirmTotpEnrollment(ctx context.Context, req *pb.ConfirmTotpEnrollmentRequest) (*pb.ConfirmTotpEnrollmentResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	if req.Code == "" {
+		return &pb.ConfirmTotpEnrollmentResponse{Error: "code is required"}, nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &pb.ConfirmTotpEnrollmentResponse{Error: "internal server error"}, nil
+	}
+	defer tx.Rollback()
+
+	var secret string
+	err = tx.QueryRowContext(ctx, `
+		SELECT secret FROM user_totp
+		WHERE user_id = $1 AND confirmed_at IS NULL
+		FOR UPDATE`, id.UserID).Scan(&secret)
+	if err == sql.ErrNoRows {
+		return &pb.ConfirmTotpEnrollmentResponse{Error: "enrollment has not been started"}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to load TOTP secret of user %d: %v", id.UserID, err)
+		return &pb.ConfirmTotpEnrollmentResponse{Error: "internal server error"}, nil
+	}
+
+	step, ok := totp.Validate(secret, req.Code, time.Now(), 0)
+	if !ok {
+		return &pb.ConfirmTotpEnrollmentResponse{Error: "Invalid code"}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE user_totp SET confirmed_at = now(), last_used_step = $1
+		WHERE user_id = $2`, step, id.UserID); err != nil {
+		log.Printf("Failed to confirm TOTP of user %d: %v", id.UserID, err)
+		return &pb.ConfirmTotpEnrollmentResponse{Error: "internal server error"}, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, id.UserID); err != nil {
+		log.Printf("Failed to delete recovery codes of user %d: %v", id.UserID, err)
+		return &pb.ConfirmTotpEnrollmentResponse{Error: "internal server error"}, nil
+	}
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := newRecoveryCode()
+		if err != nil {
+			log.Printf("Failed to generate recovery code: %v", err)
+			return &pb.ConfirmTotpEnrollmentResponse{Error: "internal server error"}, nil
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO totp_recovery_codes (user_id, code_hash) VALUES ($1, $2)`,
+			id.UserID, auth.HashOpaqueToken(normalizeRecoveryCode(code))); err != nil {
+			log.Printf("Failed to store recovery code of user %d: %v", id.UserID, err)
+			return &pb.ConfirmTotpEnrollmentResponse{Error: "internal server error"}, nil
+		}
+		codes = append(codes, code)
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Failed to commit TOTP enrollment of user %d: %v", id.UserID, err)
+		return &pb.ConfirmTotpEnrollmentResponse{Error: "internal server error"}, nil
+	}
+
+	resp := &pb.ConfirmTotpEnrollmentResponse{RecoveryCodes: codes}
+	if id.MFAPending {
+		resp.Login = s.completeLogin(ctx, id.UserID, id.Role)
+	}
+	return resp, nil
+}
+
+// DisableTotp отключает второй фактор, если он не обязателен для роли
+func (s *Server) DisableTotp(ctx context.Context, req *pb.DisableTotpRequest) (*pb.DisableTotpResponse, error) {
+	id, _ := auth.FromContext(ctx)
+	if s.TotpRequiredRoles[id.Role] {
+		return &pb.DisableTotpResponse{Error: "two-factor authentication is mandatory for your role"}, nil
+	}
+	ok, err := s.checkSecondFactor(ctx, id.UserID, req.Code)
+	if err != nil {
+		log.Printf("Failed to check second factor of user %d: %v", id.UserID, err)
+		return &pb.DisableTotpResponse{Error: "internal server error"}, nil
+	}
+	if !ok {
+		return &pb.DisableTotpResponse{Error: "Invalid code"}, nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &pb.DisableTotpResponse{Error: "internal server error"}, nil
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM user_totp WHERE user_id = $1`, id.UserID); err != nil {
+		log.Printf("Failed to disable TOTP of user %d: %v", id.UserID, err)
+		return &pb.DisableTotpResponse{Error: "internal server error"}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM totp_recovery_codes WHERE user_id = $1`, id.UserID); err != nil {
+		log.Printf("Failed to delete recovery codes of user %d: %v", id.UserID, err)
+		return &pb.DisableTotpResponse{Error: "internal server error"}, nil
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Failed to commit TOTP removal of user %d: %v", id.UserID, err)
+		return &pb.DisableTotpResponse{Error: "internal server error"}, nil
+	}
+	return &pb.DisableTotpResponse{}, nil
+}
+
+// newRecoveryCode возвращает резервный код вида xxxxx-xxxxx
+func newRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	raw := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:10]
+	return raw[:5] + "-" + raw[5:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
@@ -0,0 +1,130 @@
+package userservice
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"rubr/internal/auth"
+	notifypb "rubr/proto/notification"
+	pb "rubr/proto/user"
+	"time"
+)
+
+// emailVerificationTTL — срок действия кода подтверждения почты
+const emailVerificationTTL = 24 * time.Hour
+
+// sendVerification выпускает новый код подтверждения почты, отменяя прежние,
+// и отправляет его пользователю
+func (s *Server) sendVerification(ctx context.Context, userID int, email string) error {
+	token, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE email_verifications SET used_at = now()
+		WHERE user_id = $1 AND used_at IS NULL`, userID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO email_verifications (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)`,
+		userID, tokenHash, time.Now().Add(emailVerificationTTL))
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	resp, err := s.Notify.SendRegistrationNotification(ctx, &notifypb.NotificationRequest{
+		UserId: int32(userID),
+		Email:  email,
+		Message: fmt.Sprintf("Аккаунт создан. Код подтверждения почты: %s\nВведите его в приложении, чтобы войти. Код действует %d часа.",
+			token, int(emailVerificationTTL.Hours())),
+		CreatedAt: time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.Error != "" {
+		return fmt.Errorf("notification service: %s", resp.Error)
+	}
+	return nil
+}
+
+// VerifyEmail подтверждает почту по коду из письма
+func (s *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	if req.Token == "" {
+		return &pb.VerifyEmailResponse{Error: "verification code is required"}, nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &pb.VerifyEmailResponse{Error: "internal server error"}, nil
+	}
+	defer tx.Rollback()
+
+	var verificationID, userID int
+	err = tx.QueryRowContext(ctx, `
+		SELECT id, user_id FROM email_verifications
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > now()
+		FOR UPDATE`, auth.HashOpaqueToken(req.Token)).Scan(&verificationID, &userID)
+	if err == sql.ErrNoRows {
+		return &pb.VerifyEmailResponse{Error: "invalid or expired verification code"}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to look up email verification: %v", err)
+		return &pb.VerifyEmailResponse{Error: "internal server error"}, nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET email_verified = TRUE WHERE id = $1`, userID); err != nil {
+		log.Printf("Failed to mark email verified for user %d: %v", userID, err)
+		return &pb.VerifyEmailResponse{Error: "internal server error"}, nil
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE email_verifications SET used_at = now() WHERE id = $1`, verificationID); err != nil {
+		log.Printf("Failed to mark email verification %d as used: %v", verificationID, err)
+		return &pb.VerifyEmailResponse{Error: "internal server error"}, nil
+	}
+	if err := tx.Commit(); err != nil {
+		log.Printf("Failed to commit email verification for user %d: %v", userID, err)
+		return &pb.VerifyEmailResponse{Error: "internal server error"}, nil
+	}
+	return &pb.VerifyEmailResponse{}, nil
+}
+
+// ResendVerification повторно отправляет код подтверждения. Как и при сбросе
+// пароля, ответ не выдаёт, зарегистрирован ли адрес: сбой отправки письма только
+// пишется в лог. Приглашённые суперакком подтверждают почту приглашением.
+func (s *Server) ResendVerification(ctx context.Context, req *pb.ResendVerificationRequest) (*pb.ResendVerificationResponse, error) {
+	if req.Email == "" {
+		return &pb.ResendVerificationResponse{Error: "email is required"}, nil
+	}
+
+	var userID int
+	err := s.Db.QueryRowContext(ctx, `
+		SELECT id FROM users
+		WHERE email = $1 AND NOT email_verified AND deactivated_at IS NULL
+		  AND NOT EXISTS(SELECT 1 FROM invitations i WHERE i.user_id = users.id)`, req.Email).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return &pb.ResendVerificationResponse{}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to look up user for verification resend: %v", err)
+		return &pb.ResendVerificationResponse{Error: "internal server error"}, nil
+	}
+
+	if err := s.sendVerification(ctx, userID, req.Email); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", userID, err)
+	}
+	return &pb.ResendVerificationResponse{}, nil
+}
@@ -6,11 +6,15 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
+	"rubr/internal/auth"
 	Pb "rubr/proto/workassignment"
 	"time"
 )
 
 func (s *Server) GetWorksForAssistant(ctx context.Context, req *Pb.GetWorksForAssistantRequest) (*Pb.GetWorksForAssistantResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.AssistantId)); err != nil {
+		return nil, err
+	}
 	assistantID := req.AssistantId
 
 	query := `
@@ -64,6 +68,9 @@ func (s *Server) GetWorksForAssistant(ctx context.Context, req *Pb.GetWorksForAs
 }
 
 func (s *Server) GetWorkDetails(ctx context.Context, req *Pb.GetWorkDetailsRequest) (*Pb.GetWorkDetailsResponse, error) {
+	if err := auth.CheckWorkAccess(ctx, s.Db, int64(req.WorkId)); err != nil {
+		return nil, err
+	}
 	workID := req.WorkId
 
 	query := `
@@ -106,6 +113,10 @@ func (s *Server) GetTaskDetails(ctx context.Context, req *Pb.GetTaskDetailsReque
 		return nil, status.Errorf(codes.Canceled, "Request canceled: %v", ctx.Err())
 	}
 
+	if err := auth.CheckTaskAccess(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
+
 	query := `
         SELECT 
             t.id AS task_id,
@@ -173,6 +184,9 @@ func (s *Server) GetTaskDetails(ctx context.Context, req *Pb.GetTaskDetailsReque
 }
 
 func (s *Server) CheckExistingWork(ctx context.Context, req *Pb.CheckExistingWorkRequest) (*Pb.CheckExistingWorkResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.StudentId)); err != nil {
+		return nil, err
+	}
 	if ctx.Err() != nil {
 		return &Pb.CheckExistingWorkResponse{Error: "Request canceled"}, nil
 	}
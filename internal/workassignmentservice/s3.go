@@ -9,6 +9,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"log"
+	"rubr/internal/auth"
 	Pb "rubr/proto/workassignment"
 	"time"
 )
@@ -43,6 +44,9 @@ func InitS3Client() {
 }
 
 func (s *Server) GenerateUploadURL(ctx context.Context, req *Pb.GenerateUploadURLRequest) (*Pb.GenerateUploadURLResponse, error) {
+	if err := auth.CheckWorkEditable(ctx, s.Db, int64(req.WorkId)); err != nil {
+		return nil, err
+	}
 	// Генерация уникального ключа для файла в S3
 	key := fmt.Sprintf("works/%d/%s", req.WorkId, req.FileName)
 
@@ -77,6 +81,9 @@ func (s *Server) GenerateUploadURL(ctx context.Context, req *Pb.GenerateUploadUR
 }
 
 func (s *Server) GenerateDownloadURL(ctx context.Context, req *Pb.GenerateDownloadURLRequest) (*Pb.GenerateDownloadURLResponse, error) {
+	if err := auth.CheckWorkAccess(ctx, s.Db, int64(req.WorkId)); err != nil {
+		return nil, err
+	}
 	// Получение content_url из базы данных
 	var contentURL string
 	err := s.Db.QueryRowContext(ctx, `
@@ -5,10 +5,14 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"rubr/internal/auth"
 	Pb "rubr/proto/workassignment"
 )
 
 func (s *Server) SubmitWork(ctx context.Context, req *Pb.SubmitWorkRequest) (*Pb.SubmitWorkResponse, error) {
+	if err := auth.CheckWorkEditable(ctx, s.Db, int64(req.WorkId)); err != nil {
+		return nil, err
+	}
 	query := `UPDATE student_works SET status = 'submitted', content_url = $1, created_at = CURRENT_TIMESTAMP WHERE id = $2`
 	result, err := s.Db.ExecContext(ctx, query, req.FilePath, req.WorkId)
 	if err != nil {
@@ -25,6 +29,12 @@ func (s *Server) SubmitWork(ctx context.Context, req *Pb.SubmitWorkRequest) (*Pb
 }
 
 func (s *Server) CreateWork(ctx context.Context, req *Pb.CreateWorkRequest) (*Pb.CreateWorkResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.StudentId)); err != nil {
+		return nil, err
+	}
+	if err := auth.CheckTaskWritable(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	if ctx.Err() != nil {
 		return &Pb.CreateWorkResponse{Error: "Request canceled"}, nil
 	}
@@ -56,25 +66,43 @@ func (s *Server) CreateWork(ctx context.Context, req *Pb.CreateWorkRequest) (*Pb
 		return &Pb.CreateWorkResponse{Error: "Задание не найдено"}, nil
 	}
 
-	// Извлечение group_id из tasks
-	var groupID int64
+	// Сдать работу можно только по заданию своей группы
+	var inGroup bool
 	err = tx.QueryRowContext(ctx, `
-        SELECT group_id FROM tasks WHERE id = $1`, req.TaskId).Scan(&groupID)
+        SELECT EXISTS(
+            SELECT 1 FROM tasks t
+            JOIN users_in_groups ug ON ug.group_id = t.group_id
+            WHERE t.id = $1 AND ug.user_id = $2
+        )`, req.TaskId, req.StudentId).Scan(&inGroup)
 	if err != nil {
-		log.Printf("Ошибка получения group_id для task_id %d: %v", req.TaskId, err)
+		log.Printf("Ошибка проверки группы студента %d для task_id %d: %v", req.StudentId, req.TaskId, err)
 		return &Pb.CreateWorkResponse{Error: "Ошибка сервера"}, nil
 	}
+	if !inGroup {
+		return &Pb.CreateWorkResponse{Error: "Задание выдано не вашей группе"}, nil
+	}
 
-	// Извлечение seminarist_id из groups_in_disciplines
+	// Выбор семинариста среди назначенных группе по дисциплине задания: при повторной
+	// сдаче работа остаётся за прежним семинаристом, если он ещё в составе, иначе
+	// достаётся тому, у кого меньше работ по этому заданию
 	var seminaristID int64
 	err = tx.QueryRowContext(ctx, `
-        SELECT seminarist_id FROM groups_in_disciplines WHERE group_id = $1`, groupID).Scan(&seminaristID)
+        SELECT ds.user_id
+        FROM tasks t
+        JOIN groups_in_disciplines gd ON gd.group_id = t.group_id AND gd.discipline_id = t.discipline_id
+        JOIN discipline_staff ds ON ds.group_discipline_id = gd.id AND ds.role = 'seminarist'
+        WHERE t.id = $1
+        ORDER BY EXISTS(SELECT 1 FROM student_works sw
+                        WHERE sw.task_id = t.id AND sw.student_id = $2 AND sw.seminarist_id = ds.user_id) DESC,
+                 (SELECT COUNT(*) FROM student_works sw WHERE sw.task_id = t.id AND sw.seminarist_id = ds.user_id),
+                 ds.user_id
+        LIMIT 1`, req.TaskId, req.StudentId).Scan(&seminaristID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Printf("Не найден seminarist_id для group_id %d", groupID)
+			log.Printf("Не найден семинарист для task_id %d", req.TaskId)
 			return &Pb.CreateWorkResponse{Error: "Семинарист не назначен для группы"}, nil
 		}
-		log.Printf("Ошибка получения seminarist_id для group_id %d: %v", groupID, err)
+		log.Printf("Ошибка выбора семинариста для task_id %d: %v", req.TaskId, err)
 		return &Pb.CreateWorkResponse{Error: "Ошибка сервера"}, nil
 	}
 
@@ -0,0 +1,287 @@
+package workservice
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"log"
+	"rubr/internal/auth"
+	Pb "rubr/proto/work"
+	"sort"
+)
+
+// CloneDiscipline копирует дисциплину в целевой семестр в одной транзакции: сначала
+// дисциплину, затем задания, группы критериев и критерии, запоминая новые id
+func (s *Server) CloneDiscipline(ctx context.Context, req *Pb.CloneDisciplineRequest) (*Pb.CloneDisciplineResponse, error) {
+	// Исходная дисциплина может быть в архивном семестре — она только читается
+	if err := auth.CheckDisciplineHead(ctx, s.Db, int64(req.DisciplineId)); err != nil {
+		return nil, err
+	}
+	if req.TargetTermId <= 0 {
+		return &Pb.CloneDisciplineResponse{Error: "Не указан целевой семестр"}, nil
+	}
+
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+	}
+	defer tx.Rollback()
+
+	var termState string
+	err = tx.QueryRowContext(ctx, "SELECT state FROM terms WHERE id = $1", req.TargetTermId).Scan(&termState)
+	if err == sql.ErrNoRows {
+		return &Pb.CloneDisciplineResponse{Error: fmt.Sprintf("Семестр с ID %d не найден", req.TargetTermId)}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to load term %d: %v", req.TargetTermId, err)
+		return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+	}
+	if termState == "archived" {
+		return &Pb.CloneDisciplineResponse{Error: "Целевой семестр в архиве, копировать в него нельзя"}, nil
+	}
+
+	// Группы из сопоставления должны существовать, иначе задания и назначения ссылались бы в никуда
+	if targets := mappingTargets(req.GroupMapping); len(targets) > 0 {
+		found, err := queryIDs(ctx, tx, "SELECT id FROM student_groups WHERE id = ANY($1)", pq.Array(targets))
+		if err != nil {
+			log.Printf("Failed to check mapped groups: %v", err)
+			return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+		}
+		exists := make(map[int32]bool)
+		for _, id := range found {
+			exists[id] = true
+		}
+		for _, id := range targets {
+			if !exists[id] {
+				return &Pb.CloneDisciplineResponse{Error: fmt.Sprintf("Группа с ID %d из сопоставления не найдена", id)}, nil
+			}
+		}
+	}
+	resp := &Pb.CloneDisciplineResponse{
+		TaskIds:          make(map[int32]int32),
+		CriteriaGroupIds: make(map[int32]int32),
+		CriteriaIds:      make(map[int32]int32),
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO disciplines (name, term_id)
+		SELECT COALESCE(NULLIF($2, ''), name), $3 FROM disciplines WHERE id = $1
+		RETURNING id`, req.DisciplineId, req.Name, req.TargetTermId).Scan(&resp.DisciplineId)
+	if err != nil {
+		log.Printf("Failed to clone discipline %d: %v", req.DisciplineId, err)
+		return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO discipline_lecturers (discipline_id, user_id, role)
+		SELECT $2, user_id, role FROM discipline_lecturers WHERE discipline_id = $1`, req.DisciplineId, resp.DisciplineId)
+	if err != nil {
+		log.Printf("Failed to copy lecturers of discipline %d: %v", req.DisciplineId, err)
+		return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+	}
+
+	type assignment struct{ id, groupID int32 }
+	var assignments []assignment
+	if req.CopyGroupAssignments {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id, group_id FROM groups_in_disciplines WHERE discipline_id = $1 ORDER BY id`, req.DisciplineId)
+		if err != nil {
+			log.Printf("Failed to query assignments of discipline %d: %v", req.DisciplineId, err)
+			return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+		}
+		for rows.Next() {
+			var a assignment
+			if err := rows.Scan(&a.id, &a.groupID); err != nil {
+				rows.Close()
+				log.Printf("Failed to scan assignment: %v", err)
+				return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+			}
+			assignments = append(assignments, a)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			log.Printf("Row iteration error: %v", err)
+			return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+		}
+	}
+
+	// Задания копируются по одному, чтобы сопоставить старый id с новым
+	type task struct{ id, groupID int32 }
+	var tasks []task
+	rows, err := tx.QueryContext(ctx, "SELECT id, group_id FROM tasks WHERE discipline_id = $1 ORDER BY id", req.DisciplineId)
+	if err != nil {
+		log.Printf("Failed to query tasks of discipline %d: %v", req.DisciplineId, err)
+		return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+	}
+	for rows.Next() {
+		var t task
+		if err := rows.Scan(&t.id, &t.groupID); err != nil {
+			rows.Close()
+			log.Printf("Failed to scan task: %v", err)
+			return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+		}
+		tasks = append(tasks, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Printf("Row iteration error: %v", err)
+		return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+	}
+
+	// Назначения копии: группы перенесённых назначений и группы заданий
+	assignmentGroups := make([]int32, 0, len(assignments))
+	for _, a := range assignments {
+		assignmentGroups = append(assignmentGroups, a.groupID)
+	}
+	taskGroups := make([]int32, 0, len(tasks))
+	for _, t := range tasks {
+		taskGroups = append(taskGroups, t.groupID)
+	}
+	assigned := make(map[int32]int32)
+	for _, groupID := range cloneGroupTargets(req.GroupMapping, assignmentGroups, taskGroups) {
+		var id int32
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO groups_in_disciplines (group_id, discipline_id, term_id)
+			VALUES ($1, $2, $3)
+			RETURNING id`, groupID, resp.DisciplineId, req.TargetTermId).Scan(&id)
+		if err != nil {
+			log.Printf("Failed to assign group %d to discipline %d: %v", groupID, resp.DisciplineId, err)
+			return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+		}
+		assigned[groupID] = id
+	}
+	// Назначение переносится вместе со всеми семинаристами и ассистентами группы; две
+	// старые группы, сопоставленные одной новой, дают ей общий состав
+	for _, a := range assignments {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO discipline_staff (group_discipline_id, user_id, role)
+			SELECT $2, user_id, role FROM discipline_staff WHERE group_discipline_id = $1
+			ON CONFLICT DO NOTHING`, a.id, assigned[cloneGroup(req.GroupMapping, a.groupID)])
+		if err != nil {
+			log.Printf("Failed to copy staff of group %d: %v", a.groupID, err)
+			return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+		}
+	}
+
+	for _, t := range tasks {
+		var newID int32
+		err := tx.QueryRowContext(ctx, `
+			INSERT INTO tasks (lector_id, group_id, title, description, deadline, discipline_id, content_url)
+			SELECT lector_id, $2, title, description, deadline + make_interval(days => $3), $4, content_url
+			FROM tasks WHERE id = $1
+			RETURNING id`, t.id, cloneGroup(req.GroupMapping, t.groupID), req.DeadlineOffsetDays, resp.DisciplineId).Scan(&newID)
+		if err != nil {
+			log.Printf("Failed to clone task %d: %v", t.id, err)
+			return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+		}
+		resp.TaskIds[t.id] = newID
+	}
+
+	for _, t := range tasks {
+		groupIDs, err := queryIDs(ctx, tx, "SELECT id FROM criteria_groups WHERE task_id = $1 ORDER BY id", t.id)
+		if err != nil {
+			log.Printf("Failed to query criteria groups of task %d: %v", t.id, err)
+			return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+		}
+		for _, oldGroupID := range groupIDs {
+			var newGroupID int32
+			err := tx.QueryRowContext(ctx, `
+				INSERT INTO criteria_groups (task_id, group_name, block_flag)
+				SELECT $2, group_name, block_flag FROM criteria_groups WHERE id = $1
+				RETURNING id`, oldGroupID, resp.TaskIds[t.id]).Scan(&newGroupID)
+			if err != nil {
+				log.Printf("Failed to clone criteria group %d: %v", oldGroupID, err)
+				return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+			}
+			resp.CriteriaGroupIds[oldGroupID] = newGroupID
+
+			criterionIDs, err := queryIDs(ctx, tx, "SELECT id FROM criteria WHERE criteria_group_id = $1 ORDER BY id", oldGroupID)
+			if err != nil {
+				log.Printf("Failed to query criteria of group %d: %v", oldGroupID, err)
+				return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+			}
+			for _, oldID := range criterionIDs {
+				var newID int32
+				err := tx.QueryRowContext(ctx, `
+					INSERT INTO criteria (name, description, comment_for_blocking_criteria, final_mark_for_blocking_criteria,
+					                      criteria_group_id, weight, comment_000, comment_025, comment_050, comment_075, comment_100)
+					SELECT name, description, comment_for_blocking_criteria, final_mark_for_blocking_criteria,
+					       $2, weight, comment_000, comment_025, comment_050, comment_075, comment_100
+					FROM criteria WHERE id = $1
+					RETURNING id`, oldID, newGroupID).Scan(&newID)
+				if err != nil {
+					log.Printf("Failed to clone criterion %d: %v", oldID, err)
+					return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+				}
+				resp.CriteriaIds[oldID] = newID
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Printf("Failed to commit transaction: %v", err)
+		return &Pb.CloneDisciplineResponse{Error: "Ошибка сервера"}, nil
+	}
+	log.Printf("Discipline %d cloned into term %d as %d: %d tasks, %d criteria groups, %d criteria",
+		req.DisciplineId, req.TargetTermId, resp.DisciplineId, len(resp.TaskIds), len(resp.CriteriaGroupIds), len(resp.CriteriaIds))
+	return resp, nil
+}
+
+// cloneGroup возвращает группу копии для группы исходной дисциплины
+func cloneGroup(mapping map[int32]int32, groupID int32) int32 {
+	if mapped, ok := mapping[groupID]; ok {
+		return mapped
+	}
+	return groupID
+}
+
+// mappingTargets возвращает новые группы сопоставления по возрастанию, без повторов
+func mappingTargets(mapping map[int32]int32) []int32 {
+	seen := make(map[int32]bool)
+	var targets []int32
+	for _, to := range mapping {
+		if !seen[to] {
+			seen[to] = true
+			targets = append(targets, to)
+		}
+	}
+	sort.Slice(targets, func(i, j int) bool { return targets[i] < targets[j] })
+	return targets
+}
+
+// cloneGroupTargets возвращает группы, которые нужно прикрепить к копии: группы
+// перенесённых назначений и группы заданий после сопоставления, каждую один раз,
+// в порядке первого появления. Без назначения задание ссылалось бы на группу, не
+// прикреплённую к дисциплине
+func cloneGroupTargets(mapping map[int32]int32, assignmentGroups, taskGroups []int32) []int32 {
+	seen := make(map[int32]bool)
+	var targets []int32
+	for _, groups := range [][]int32{assignmentGroups, taskGroups} {
+		for _, g := range groups {
+			if to := cloneGroup(mapping, g); !seen[to] {
+				seen[to] = true
+				targets = append(targets, to)
+			}
+		}
+	}
+	return targets
+}
+
+// queryIDs возвращает первый столбец выборки с одним параметром
+func queryIDs(ctx context.Context, tx *sql.Tx, query string, arg interface{}) ([]int32, error) {
+	rows, err := tx.QueryContext(ctx, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
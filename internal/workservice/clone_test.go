@@ -0,0 +1,65 @@
+package workservice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCloneGroup(t *testing.T) {
+	mapping := map[int32]int32{1: 10, 2: 10}
+	cases := []struct {
+		groupID, want int32
+	}{
+		{1, 10},
+		{2, 10},
+		{3, 3},
+	}
+	for _, c := range cases {
+		if got := cloneGroup(mapping, c.groupID); got != c.want {
+			t.Errorf("cloneGroup(%d) = %d, want %d", c.groupID, got, c.want)
+		}
+	}
+	if got := cloneGroup(nil, 5); got != 5 {
+		t.Errorf("cloneGroup without mapping = %d, want 5", got)
+	}
+}
+
+func TestMappingTargets(t *testing.T) {
+	cases := []struct {
+		name    string
+		mapping map[int32]int32
+		want    []int32
+	}{
+		{"empty", nil, nil},
+		{"sorted", map[int32]int32{1: 30, 2: 10, 3: 20}, []int32{10, 20, 30}},
+		{"merged groups", map[int32]int32{1: 10, 2: 10, 3: 5}, []int32{5, 10}},
+	}
+	for _, c := range cases {
+		if got := mappingTargets(c.mapping); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: mappingTargets = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestCloneGroupTargets(t *testing.T) {
+	cases := []struct {
+		name             string
+		mapping          map[int32]int32
+		assignmentGroups []int32
+		taskGroups       []int32
+		want             []int32
+	}{
+		{"nothing to clone", nil, nil, nil, nil},
+		{"tasks without assignments", nil, nil, []int32{2, 1, 2}, []int32{2, 1}},
+		{"assignments first", nil, []int32{3, 1}, []int32{1, 4}, []int32{3, 1, 4}},
+		{"mapped groups", map[int32]int32{1: 10, 2: 20}, []int32{1}, []int32{2, 3}, []int32{10, 20, 3}},
+		// Две старые группы, сопоставленные одной новой, прикрепляются один раз
+		{"merged groups", map[int32]int32{1: 10, 2: 10}, []int32{1, 2}, []int32{2}, []int32{10}},
+	}
+	for _, c := range cases {
+		got := cloneGroupTargets(c.mapping, c.assignmentGroups, c.taskGroups)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: cloneGroupTargets = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
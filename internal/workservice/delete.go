@@ -2,10 +2,14 @@ package workservice
 
 import (
 	"context"
+	"rubr/internal/auth"
 	Pb "rubr/proto/work"
 )
 
 func (s *Server) DeleteTask(ctx context.Context, req *Pb.DeleteTaskRequest) (*Pb.DeleteTaskResponse, error) {
+	if err := auth.CheckTaskHead(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `DELETE FROM tasks WHERE id = $1`
 	_, err := s.Db.ExecContext(ctx, query, req.TaskId)
 	if err != nil {
@@ -7,11 +7,18 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"log"
+	"rubr/internal/auth"
 	Pb "rubr/proto/work"
 	"time"
 )
 
+// termFilter — семестр списка: term_id из запроса вторым параметром, а при 0 — активный
+const termFilter = "COALESCE(NULLIF($2::int, 0), (SELECT id FROM terms WHERE state = 'active'))"
+
 func (s *Server) GetStudentsByGroupAndDiscipline(ctx context.Context, req *Pb.GetStudentsByGroupAndDisciplineRequest) (*Pb.GetStudentsByGroupAndDisciplineResponse, error) {
+	if err := auth.CheckGroupDisciplineAccess(ctx, s.Db, int64(req.GroupId), int64(req.DisciplineId)); err != nil {
+		return nil, err
+	}
 	resp := &Pb.GetStudentsByGroupAndDisciplineResponse{
 		Students: make([]*Pb.GetStudentsByGroupAndDisciplineResponse_Student, 0),
 	}
@@ -70,6 +77,9 @@ func (s *Server) GetStudentsByGroupAndDiscipline(ctx context.Context, req *Pb.Ge
 }
 
 func (s *Server) GetStudentWorksByTask(ctx context.Context, req *Pb.GetStudentWorksByTaskRequest) (*Pb.GetStudentWorksByTaskResponse, error) {
+	if err := auth.CheckTaskAccess(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT sw.id, u.name, u.surname, u.patronymic, u.email, sw.status, sw.assistant_id,
 		       COALESCE(a.name, '') AS assistant_name, COALESCE(a.surname, '') AS assistant_surname, COALESCE(a.patronymic, '') AS assistant_patronymic,
@@ -112,14 +122,22 @@ func (s *Server) GetStudentWorksByTask(ctx context.Context, req *Pb.GetStudentWo
 }
 
 func (s *Server) GetAssistantsByDiscipline(ctx context.Context, req *Pb.GetAssistantsByDisciplineRequest) (*Pb.GetAssistantsByDisciplineResponse, error) {
+	// Без группы список по всей дисциплине доступен только её лекторам
+	if req.GroupId != 0 {
+		if err := auth.CheckGroupDisciplineAccess(ctx, s.Db, int64(req.GroupId), int64(req.DisciplineId)); err != nil {
+			return nil, err
+		}
+	} else if err := auth.CheckDisciplineLector(ctx, s.Db, int64(req.DisciplineId)); err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT DISTINCT u.id, u.name, u.surname, COALESCE(u.patronymic, '')
 		FROM users u
-		JOIN users_in_groups ug ON u.id = ug.user_id
-		JOIN groups_in_disciplines gd ON ug.group_id = gd.group_id
-		WHERE gd.discipline_id = $1 AND u.role = 'assistant'
+		JOIN discipline_staff ds ON ds.user_id = u.id AND ds.role = 'assistant'
+		JOIN groups_in_disciplines gd ON gd.id = ds.group_discipline_id
+		WHERE gd.discipline_id = $1 AND ($2 = 0 OR gd.group_id = $2)
 	`
-	rows, err := s.Db.QueryContext(ctx, query, req.DisciplineId)
+	rows, err := s.Db.QueryContext(ctx, query, req.DisciplineId, req.GroupId)
 	if err != nil {
 		log.Printf("Failed to query assistants: %v", err)
 		return &Pb.GetAssistantsByDisciplineResponse{
@@ -157,14 +175,18 @@ func (s *Server) GetAssistantsByDiscipline(ctx context.Context, req *Pb.GetAssis
 }
 
 func (s *Server) GetTasksForSeminarist(ctx context.Context, req *Pb.GetTasksForSeminaristRequest) (*Pb.GetTasksForSeminaristResponse, error) {
+	if err := auth.CheckSelfString(ctx, req.SeminaristId); err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT DISTINCT t.id, t.title, t.deadline
 		FROM tasks t
-		JOIN groups_in_disciplines gd ON t.discipline_id = gd.discipline_id
-		JOIN users_in_groups ug ON gd.group_id = ug.group_id
-		WHERE ug.user_id = $1
+		JOIN groups_in_disciplines gd ON gd.discipline_id = t.discipline_id AND gd.group_id = t.group_id
+		JOIN discipline_staff ds ON ds.group_discipline_id = gd.id AND ds.user_id = $1 AND ds.role = 'seminarist'
+		JOIN disciplines d ON t.discipline_id = d.id
+		WHERE d.term_id = ` + termFilter + `
 	`
-	rows, err := s.Db.QueryContext(ctx, query, req.SeminaristId)
+	rows, err := s.Db.QueryContext(ctx, query, req.SeminaristId, req.TermId)
 	if err != nil {
 		log.Printf("Failed to query tasks: %v", err)
 		return &Pb.GetTasksForSeminaristResponse{
@@ -202,6 +224,9 @@ func (s *Server) GetTasksForSeminarist(ctx context.Context, req *Pb.GetTasksForS
 }
 
 func (s *Server) GetStudentWorksForSeminarist(ctx context.Context, req *Pb.GetStudentWorksForSeminaristRequest) (*Pb.GetStudentWorksForSeminaristResponse, error) {
+	if err := auth.CheckSelfString(ctx, req.SeminaristId); err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT sw.id, t.title, sw.created_at, CONCAT(u.name, ' ', u.surname) AS student_name, sw.task_id
 		FROM student_works sw
@@ -251,8 +276,16 @@ func (s *Server) GetStudentWorksForSeminarist(ctx context.Context, req *Pb.GetSt
 
 // возвращает слайс работ из массивов состоящих из id работы, name, deadline
 func (s *Server) GetTasksForLector(ctx context.Context, req *Pb.GetTasksForLectorRequest) (*Pb.GetTasksForLectorResponse, error) {
-	query := `SELECT id, title, deadline FROM tasks WHERE lector_id = $1`
-	rows, err := s.Db.QueryContext(ctx, query, req.LectorId)
+	if err := auth.CheckSelf(ctx, int64(req.LectorId)); err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT t.id, t.title, t.deadline
+		FROM tasks t
+		JOIN disciplines d ON t.discipline_id = d.id
+		JOIN discipline_lecturers dl ON dl.discipline_id = d.id
+		WHERE dl.user_id = $1 AND d.term_id = ` + termFilter
+	rows, err := s.Db.QueryContext(ctx, query, req.LectorId, req.TermId)
 	if err != nil {
 		return &Pb.GetTasksForLectorResponse{Error: err.Error()}, nil
 	}
@@ -272,16 +305,21 @@ func (s *Server) GetTasksForLector(ctx context.Context, req *Pb.GetTasksForLecto
 	return &Pb.GetTasksForLectorResponse{Tasks: tasks}, nil
 }
 
-// получение групп лектора
+// получение групп лектора: группы, к которым прикреплены его дисциплины
 func (s *Server) GetGroups(ctx context.Context, req *Pb.GetGroupsRequest) (*Pb.GetGroupsResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.LectorId)); err != nil {
+		return nil, err
+	}
 	var groups []*Pb.GetGroupsResponse_Group
 	query := `
-        SELECT sg.id, sg.name
+        SELECT DISTINCT sg.id, sg.name
         FROM student_groups sg
-        JOIN users_in_groups uig ON sg.id = uig.group_id
-        WHERE uig.user_id = $1
+        JOIN groups_in_disciplines gd ON gd.group_id = sg.id
+        JOIN discipline_lecturers dl ON dl.discipline_id = gd.discipline_id
+        WHERE dl.user_id = $1 AND gd.term_id = ` + termFilter + `
+        ORDER BY sg.name
     `
-	rows, err := s.Db.Query(query, req.LectorId)
+	rows, err := s.Db.Query(query, req.LectorId, req.TermId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query groups: %v", err)
 	}
@@ -300,13 +338,18 @@ func (s *Server) GetGroups(ctx context.Context, req *Pb.GetGroupsRequest) (*Pb.G
 
 // получение дисциплин лектор
 func (s *Server) GetDisciplines(ctx context.Context, req *Pb.GetDisciplinesRequest) (*Pb.GetDisciplinesResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.LectorId)); err != nil {
+		return nil, err
+	}
 	var disciplines []*Pb.GetDisciplinesResponse_Discipline
 	query := `
-        SELECT id, name
-        FROM disciplines
-        WHERE lector_id = $1
+        SELECT d.id, d.name, dl.role
+        FROM disciplines d
+        JOIN discipline_lecturers dl ON dl.discipline_id = d.id
+        WHERE dl.user_id = $1 AND d.term_id = ` + termFilter + `
+        ORDER BY d.name
     `
-	rows, err := s.Db.Query(query, req.LectorId)
+	rows, err := s.Db.Query(query, req.LectorId, req.TermId)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query disciplines: %v", err)
 	}
@@ -314,7 +357,7 @@ func (s *Server) GetDisciplines(ctx context.Context, req *Pb.GetDisciplinesReque
 
 	for rows.Next() {
 		var discipline Pb.GetDisciplinesResponse_Discipline
-		if err := rows.Scan(&discipline.Id, &discipline.Name); err != nil {
+		if err := rows.Scan(&discipline.Id, &discipline.Name, &discipline.Role); err != nil {
 			return nil, fmt.Errorf("failed to scan discipline: %v", err)
 		}
 		disciplines = append(disciplines, &discipline)
@@ -324,6 +367,9 @@ func (s *Server) GetDisciplines(ctx context.Context, req *Pb.GetDisciplinesReque
 }
 
 func (s *Server) GetStudentDisciplines(ctx context.Context, req *Pb.GetStudentDisciplinesRequest) (*Pb.GetStudentDisciplinesResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.StudentId)); err != nil {
+		return nil, err
+	}
 	if ctx.Err() != nil {
 		return &Pb.GetStudentDisciplinesResponse{Error: "Request canceled"}, nil
 	}
@@ -333,9 +379,9 @@ func (s *Server) GetStudentDisciplines(ctx context.Context, req *Pb.GetStudentDi
 		FROM disciplines d
 		JOIN groups_in_disciplines gd ON d.id = gd.discipline_id
 		JOIN users_in_groups ug ON gd.group_id = ug.group_id
-		WHERE ug.user_id = $1
+		WHERE ug.user_id = $1 AND d.term_id = ` + termFilter + `
 	`
-	rows, err := s.Db.QueryContext(ctx, query, req.StudentId)
+	rows, err := s.Db.QueryContext(ctx, query, req.StudentId, req.TermId)
 	if err != nil {
 		log.Printf("Ошибка получения дисциплин для student_id %d: %v", req.StudentId, err)
 		return &Pb.GetStudentDisciplinesResponse{Error: "Ошибка сервера"}, nil
@@ -360,6 +406,9 @@ func (s *Server) GetStudentDisciplines(ctx context.Context, req *Pb.GetStudentDi
 }
 
 func (s *Server) GetStudentWorksByDiscipline(ctx context.Context, req *Pb.GetStudentWorksByDisciplineRequest) (*Pb.GetStudentWorksByDisciplineResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.StudentId)); err != nil {
+		return nil, err
+	}
 	if ctx.Err() != nil {
 		return &Pb.GetStudentWorksByDisciplineResponse{Error: "Request canceled"}, nil
 	}
@@ -410,6 +459,9 @@ func (s *Server) GetStudentWorksByDiscipline(ctx context.Context, req *Pb.GetStu
 }
 
 func (s *Server) GetTaskDetails(ctx context.Context, req *Pb.GetTaskDetailsRequest) (*Pb.GetTaskDetailsResponse, error) {
+	if err := auth.CheckTaskAccess(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT t.title, t.description, t.deadline, g.name AS group_name, d.name AS discipline_name,
 		       u.name AS lector_name, u.surname AS lector_surname, u.patronymic AS lector_patronymic,
@@ -440,6 +492,9 @@ func (s *Server) GetTaskDetails(ctx context.Context, req *Pb.GetTaskDetailsReque
 }
 
 func (s *Server) ListTasksForStudent(ctx context.Context, req *Pb.ListTasksForStudentRequest) (*Pb.ListTasksForStudentResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.StudentId)); err != nil {
+		return nil, err
+	}
 	// Проверка контекста
 	if ctx.Err() != nil {
 		return nil, status.Errorf(codes.Canceled, "Request canceled: %v", ctx.Err())
@@ -450,9 +505,10 @@ func (s *Server) ListTasksForStudent(ctx context.Context, req *Pb.ListTasksForSt
         FROM tasks t
         JOIN student_groups sg ON t.group_id = sg.id
         JOIN users_in_groups ug ON sg.id = ug.group_id
+        JOIN disciplines d ON t.discipline_id = d.id
         LEFT JOIN student_works w ON t.id = w.task_id AND w.student_id = $1
-        WHERE ug.user_id = $1`
-	rows, err := s.Db.QueryContext(ctx, query, req.StudentId)
+        WHERE ug.user_id = $1 AND d.term_id = ` + termFilter
+	rows, err := s.Db.QueryContext(ctx, query, req.StudentId, req.TermId)
 	if err != nil {
 		log.Printf("Ошибка запроса работ для student_id %d: %v", req.StudentId, err)
 		return nil, status.Errorf(codes.Internal, "Ошибка выполнения запроса: %v", err)
@@ -484,6 +540,9 @@ func (s *Server) ListTasksForStudent(ctx context.Context, req *Pb.ListTasksForSt
 }
 
 func (s *Server) ListWorksForStudent(ctx context.Context, req *Pb.ListWorksForStudentRequest) (*Pb.ListWorksForStudentResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.StudentId)); err != nil {
+		return nil, err
+	}
 	query := `
     SELECT w.id, t.title, t.deadline, w.status
     FROM student_works w
@@ -511,3 +570,29 @@ func (s *Server) ListWorksForStudent(ctx context.Context, req *Pb.ListWorksForSt
 	}
 	return &Pb.ListWorksForStudentResponse{Works: works}, nil
 }
+
+// GetTerms возвращает все семестры, новые первыми; нужен любому участнику для выбора
+// семестра в списках заданий и дисциплин
+func (s *Server) GetTerms(ctx context.Context, req *Pb.GetTermsRequest) (*Pb.GetTermsResponse, error) {
+	rows, err := s.Db.QueryContext(ctx, `
+		SELECT id, name, to_char(starts_on, 'YYYY-MM-DD'), to_char(ends_on, 'YYYY-MM-DD'), state
+		FROM terms ORDER BY starts_on DESC, id DESC`)
+	if err != nil {
+		log.Printf("Failed to query terms: %v", err)
+		return &Pb.GetTermsResponse{Error: err.Error()}, nil
+	}
+	defer rows.Close()
+
+	var terms []*Pb.GetTermsResponse_Term
+	for rows.Next() {
+		var term Pb.GetTermsResponse_Term
+		if err := rows.Scan(&term.Id, &term.Name, &term.StartsOn, &term.EndsOn, &term.State); err != nil {
+			return &Pb.GetTermsResponse{Error: err.Error()}, nil
+		}
+		terms = append(terms, &term)
+	}
+	if err := rows.Err(); err != nil {
+		return &Pb.GetTermsResponse{Error: err.Error()}, nil
+	}
+	return &Pb.GetTermsResponse{Terms: terms}, nil
+}
@@ -2,10 +2,15 @@ package workservice
 
 import (
 	"context"
+
+	"rubr/internal/auth"
 	Pb "rubr/proto/work"
 )
 
 func (s *Server) LoadTaskName(ctx context.Context, req *Pb.LoadTaskNameRequest) (*Pb.LoadTaskNameResponse, error) {
+	if err := auth.CheckTaskAccess(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `SELECT title FROM tasks WHERE id = $1`
 	var title string
 	err := s.Db.QueryRowContext(ctx, query, req.TaskId).Scan(&title)
@@ -16,6 +21,9 @@ func (s *Server) LoadTaskName(ctx context.Context, req *Pb.LoadTaskNameRequest)
 }
 
 func (s *Server) LoadTaskDescription(ctx context.Context, req *Pb.LoadTaskDescriptionRequest) (*Pb.LoadTaskDescriptionResponse, error) {
+	if err := auth.CheckTaskAccess(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `SELECT description FROM tasks WHERE id = $1`
 	var description string
 	err := s.Db.QueryRowContext(ctx, query, req.TaskId).Scan(&description)
@@ -26,6 +34,9 @@ func (s *Server) LoadTaskDescription(ctx context.Context, req *Pb.LoadTaskDescri
 }
 
 func (s *Server) LoadTaskDeadline(ctx context.Context, req *Pb.LoadTaskDeadlineRequest) (*Pb.LoadTaskDeadlineResponse, error) {
+	if err := auth.CheckTaskAccess(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `SELECT deadline FROM tasks WHERE id = $1`
 	var deadline string
 	err := s.Db.QueryRowContext(ctx, query, req.TaskId).Scan(&deadline)
@@ -2,12 +2,21 @@ package workservice
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
+	"rubr/internal/auth"
+	"rubr/internal/workstatus"
 	Pb "rubr/proto/work"
 )
 
 func (s *Server) CreateWork(ctx context.Context, req *Pb.CreateWorkRequest) (*Pb.CreateWorkResponse, error) {
+	if err := auth.CheckSelf(ctx, int64(req.LectorId)); err != nil {
+		return nil, err
+	}
+	if err := auth.CheckDisciplineOwner(ctx, s.Db, int64(req.DisciplineId)); err != nil {
+		return nil, err
+	}
 	query := `INSERT INTO tasks (lector_id, group_id, title, description, deadline, discipline_id, content_url) 
 		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
 	var taskID int32
@@ -19,12 +28,38 @@ func (s *Server) CreateWork(ctx context.Context, req *Pb.CreateWorkRequest) (*Pb
 }
 
 func (s *Server) UpdateWork(ctx context.Context, req *Pb.UpdateWorkRequest) (*Pb.UpdateWorkResponse, error) {
-	_, err := s.Db.ExecContext(ctx, `
-		UPDATE student_works
-		SET status = $1
-		WHERE id = $2
-	`, req.Status, req.WorkId)
+	if err := auth.CheckWorkEditable(ctx, s.Db, int64(req.WorkId)); err != nil {
+		return nil, err
+	}
+	newStatus, ok := workstatus.Name(int32(req.Status))
+	if !ok {
+		return &Pb.UpdateWorkResponse{Error: "status is required"}, nil
+	}
+	tx, err := s.Db.BeginTx(ctx, nil)
 	if err != nil {
+		log.Printf("Failed to begin transaction: %v", err)
+		return &Pb.UpdateWorkResponse{Error: err.Error()}, err
+	}
+	defer tx.Rollback()
+
+	var current string
+	err = tx.QueryRowContext(ctx, "SELECT status FROM student_works WHERE id = $1 FOR UPDATE", req.WorkId).Scan(&current)
+	if err == sql.ErrNoRows {
+		return &Pb.UpdateWorkResponse{Error: fmt.Sprintf("work %d not found", req.WorkId)}, nil
+	}
+	if err != nil {
+		log.Printf("Failed to read status of work %d: %v", req.WorkId, err)
+		return &Pb.UpdateWorkResponse{Error: err.Error()}, err
+	}
+	id, _ := auth.FromContext(ctx)
+	if err := workstatus.CheckGraderTransition(id.Role, current, newStatus); err != nil {
+		return &Pb.UpdateWorkResponse{Error: err.Error()}, nil
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE student_works SET status = $1 WHERE id = $2", newStatus, req.WorkId); err != nil {
+		log.Printf("Failed to update work %d: %v", req.WorkId, err)
+		return &Pb.UpdateWorkResponse{Error: err.Error()}, err
+	}
+	if err := tx.Commit(); err != nil {
 		log.Printf("Failed to update work %d: %v", req.WorkId, err)
 		return &Pb.UpdateWorkResponse{Error: err.Error()}, err
 	}
@@ -32,6 +67,11 @@ func (s *Server) UpdateWork(ctx context.Context, req *Pb.UpdateWorkRequest) (*Pb
 }
 
 func (s *Server) AssignAssistantsToWorks(ctx context.Context, req *Pb.AssignAssistantsToWorksRequest) (*Pb.AssignAssistantsToWorksResponse, error) {
+	for _, assignment := range req.Assignments {
+		if err := auth.CheckWorkEditable(ctx, s.Db, int64(assignment.WorkId)); err != nil {
+			return nil, err
+		}
+	}
 	tx, err := s.Db.BeginTx(ctx, nil)
 	if err != nil {
 		log.Printf("Failed to begin transaction: %v", err)
@@ -41,8 +81,28 @@ func (s *Server) AssignAssistantsToWorks(ctx context.Context, req *Pb.AssignAssi
 	}
 	defer tx.Rollback()
 
+	// Назначить можно только ассистента группы по дисциплине задания
+	check := `
+		SELECT EXISTS(
+			SELECT 1 FROM student_works sw
+			JOIN tasks t ON t.id = sw.task_id
+			JOIN groups_in_disciplines gd ON gd.group_id = t.group_id AND gd.discipline_id = t.discipline_id
+			JOIN discipline_staff ds ON ds.group_discipline_id = gd.id AND ds.role = 'assistant'
+			WHERE sw.id = $1 AND ds.user_id = $2)`
 	query := `UPDATE student_works SET assistant_id = $1 WHERE id = $2`
 	for _, assignment := range req.Assignments {
+		var onStaff bool
+		if err := tx.QueryRowContext(ctx, check, assignment.WorkId, assignment.AssistantId).Scan(&onStaff); err != nil {
+			log.Printf("Failed to check assistant %d for work %d: %v", assignment.AssistantId, assignment.WorkId, err)
+			return &Pb.AssignAssistantsToWorksResponse{
+				Error: fmt.Sprintf("Failed to check assistant for work %d: %v", assignment.WorkId, err),
+			}, nil
+		}
+		if !onStaff {
+			return &Pb.AssignAssistantsToWorksResponse{
+				Error: fmt.Sprintf("User %d is not an assistant of the group for work %d", assignment.AssistantId, assignment.WorkId),
+			}, nil
+		}
 		_, err := tx.ExecContext(ctx, query, assignment.AssistantId, assignment.WorkId)
 		if err != nil {
 			log.Printf("Failed to update assistant_id for work %d: %v", assignment.WorkId, err)
@@ -65,6 +125,9 @@ func (s *Server) AssignAssistantsToWorks(ctx context.Context, req *Pb.AssignAssi
 }
 
 func (s *Server) SetTaskTitle(ctx context.Context, req *Pb.SetTaskTitleRequest) (*Pb.SetTaskTitleResponse, error) {
+	if err := auth.CheckTaskOwner(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `UPDATE tasks SET title = $1 WHERE id = $2`
 	_, err := s.Db.ExecContext(ctx, query, req.Title, req.TaskId)
 	if err != nil {
@@ -74,6 +137,9 @@ func (s *Server) SetTaskTitle(ctx context.Context, req *Pb.SetTaskTitleRequest)
 }
 
 func (s *Server) SetTaskDescription(ctx context.Context, req *Pb.SetTaskDescriptionRequest) (*Pb.SetTaskDescriptionResponse, error) {
+	if err := auth.CheckTaskOwner(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `UPDATE tasks SET description = $1 WHERE id = $2`
 	_, err := s.Db.ExecContext(ctx, query, req.Description, req.TaskId)
 	if err != nil {
@@ -83,6 +149,9 @@ func (s *Server) SetTaskDescription(ctx context.Context, req *Pb.SetTaskDescript
 }
 
 func (s *Server) SetTaskDeadline(ctx context.Context, req *Pb.SetTaskDeadlineRequest) (*Pb.SetTaskDeadlineResponse, error) {
+	if err := auth.CheckTaskOwner(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
 	query := `UPDATE tasks SET deadline = $1 WHERE id = $2`
 	_, err := s.Db.ExecContext(ctx, query, req.Deadline, req.TaskId)
 	if err != nil {
@@ -92,6 +161,12 @@ func (s *Server) SetTaskDeadline(ctx context.Context, req *Pb.SetTaskDeadlineReq
 }
 
 func (s *Server) UpdateTaskGroupAndDiscipline(ctx context.Context, req *Pb.UpdateTaskGroupAndDisciplineRequest) (*Pb.UpdateTaskGroupAndDisciplineResponse, error) {
+	if err := auth.CheckTaskOwner(ctx, s.Db, int64(req.TaskId)); err != nil {
+		return nil, err
+	}
+	if err := auth.CheckDisciplineOwner(ctx, s.Db, int64(req.DisciplineId)); err != nil {
+		return nil, err
+	}
 	query := `UPDATE tasks SET group_id = $1, discipline_id = $2 WHERE id = $3`
 	_, err := s.Db.ExecContext(ctx, query, req.GroupId, req.DisciplineId, req.TaskId)
 	if err != nil {
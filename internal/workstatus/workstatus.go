@@ -0,0 +1,69 @@
+// Package workstatus описывает статусы работ студентов и переходы между ними,
+// которые могут делать проверяющие.
+package workstatus
+
+import (
+	"fmt"
+
+	"rubr/internal/auth"
+)
+
+// Значения student_works.status
+const (
+	Pending            = "pending"
+	Submitted          = "submitted"
+	GradedByAssistant  = "graded by assistant"
+	GradedBySeminarist = "graded by seminarist"
+)
+
+// names — статусы по номерам WorkStatus из work.proto
+var names = map[int32]string{
+	1: Pending,
+	2: Submitted,
+	3: GradedByAssistant,
+	4: GradedBySeminarist,
+}
+
+// Name возвращает статус по номеру WorkStatus; для UNSPECIFIED и неизвестных номеров
+// ok = false
+func Name(status int32) (name string, ok bool) {
+	name, ok = names[status]
+	return name, ok
+}
+
+// graderTransitions — куда проверяющий может перевести работу. Сдаёт работу только
+// студент, а оценка семинариста окончательна для ассистента
+var graderTransitions = map[string][]string{
+	Submitted:          {GradedByAssistant, GradedBySeminarist},
+	GradedByAssistant:  {GradedByAssistant, GradedBySeminarist},
+	GradedBySeminarist: {GradedBySeminarist},
+}
+
+// roleStatuses — какие статусы может ставить каждая роль: ассистент не может выставить
+// окончательную оценку семинариста
+var roleStatuses = map[string][]string{
+	auth.RoleAssistant:  {GradedByAssistant},
+	auth.RoleSeminarist: {GradedByAssistant, GradedBySeminarist},
+	auth.RoleLecturer:   {GradedByAssistant, GradedBySeminarist},
+}
+
+// CheckGraderTransition проверяет, что проверяющий с ролью role может перевести
+// работу из from в to
+func CheckGraderTransition(role, from, to string) error {
+	if !contains(roleStatuses[role], to) {
+		return fmt.Errorf("role %q cannot set work status %q", role, to)
+	}
+	if !contains(graderTransitions[from], to) {
+		return fmt.Errorf("work status cannot change from %q to %q", from, to)
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
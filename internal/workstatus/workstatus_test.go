@@ -0,0 +1,60 @@
+package workstatus
+
+import (
+	"testing"
+
+	"rubr/internal/auth"
+)
+
+func TestName(t *testing.T) {
+	cases := []struct {
+		status int32
+		want   string
+		ok     bool
+	}{
+		{0, "", false},
+		{1, Pending, true},
+		{2, Submitted, true},
+		{3, GradedByAssistant, true},
+		{4, GradedBySeminarist, true},
+		{5, "", false},
+	}
+	for _, c := range cases {
+		got, ok := Name(c.status)
+		if got != c.want || ok != c.ok {
+			t.Errorf("Name(%d) = %q, %v; want %q, %v", c.status, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestCheckGraderTransition(t *testing.T) {
+	cases := []struct {
+		role, from, to string
+		allowed        bool
+	}{
+		{auth.RoleAssistant, Submitted, GradedByAssistant, true},
+		{auth.RoleAssistant, GradedByAssistant, GradedByAssistant, true},
+		{auth.RoleAssistant, Submitted, GradedBySeminarist, false},
+		{auth.RoleAssistant, GradedByAssistant, GradedBySeminarist, false},
+		{auth.RoleAssistant, GradedBySeminarist, GradedByAssistant, false},
+		{auth.RoleSeminarist, Submitted, GradedBySeminarist, true},
+		{auth.RoleSeminarist, GradedByAssistant, GradedBySeminarist, true},
+		{auth.RoleSeminarist, GradedBySeminarist, GradedBySeminarist, true},
+		{auth.RoleSeminarist, GradedBySeminarist, GradedByAssistant, false},
+		{auth.RoleLecturer, Submitted, GradedBySeminarist, true},
+		{auth.RoleLecturer, Submitted, GradedByAssistant, true},
+		// Сдаёт работу только студент
+		{auth.RoleSeminarist, Pending, GradedBySeminarist, false},
+		{auth.RoleSeminarist, GradedByAssistant, Submitted, false},
+		{auth.RoleSeminarist, Submitted, Pending, false},
+		{auth.RoleStudent, Submitted, GradedByAssistant, false},
+		{auth.RoleSuperaccount, Submitted, GradedBySeminarist, false},
+		{"", Submitted, GradedByAssistant, false},
+	}
+	for _, c := range cases {
+		err := CheckGraderTransition(c.role, c.from, c.to)
+		if (err == nil) != c.allowed {
+			t.Errorf("%s: %q -> %q: err = %v, allowed %v", c.role, c.from, c.to, err, c.allowed)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
+	work "rubr/proto/work"
 	sync "sync"
 	unsafe "unsafe"
 )
@@ -24,7 +25,7 @@ const (
 type UpdateWorkStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WorkId        int32                  `protobuf:"varint,1,opt,name=work_id,json=workId,proto3" json:"work_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Status        work.WorkStatus        `protobuf:"varint,3,opt,name=status,proto3,enum=work.WorkStatus" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -66,11 +67,11 @@ func (x *UpdateWorkStatusRequest) GetWorkId() int32 {
 	return 0
 }
 
-func (x *UpdateWorkStatusRequest) GetStatus() string {
+func (x *UpdateWorkStatusRequest) GetStatus() work.WorkStatus {
 	if x != nil {
 		return x.Status
 	}
-	return ""
+	return work.WorkStatus(0)
 }
 
 type UpdateWorkStatusResponse struct {
@@ -657,10 +658,10 @@ var File_proto_grade_grade_proto protoreflect.FileDescriptor
 
 const file_proto_grade_grade_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/grade/grade.proto\x12\x05grade\"J\n" +
+	"\x17proto/grade/grade.proto\x12\x05grade\x1a\x15proto/work/work.proto\"b\n" +
 	"\x17UpdateWorkStatusRequest\x12\x17\n" +
-	"\awork_id\x18\x01 \x01(\x05R\x06workId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"0\n" +
+	"\awork_id\x18\x01 \x01(\x05R\x06workId\x12(\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x10.work.WorkStatusR\x06statusJ\x04\b\x02\x10\x03\"0\n" +
 	"\x18UpdateWorkStatusResponse\x12\x14\n" +
 	"\x05error\x18\x01 \x01(\tR\x05error\"2\n" +
 	"\x17GetCriteriaMarksRequest\x12\x17\n" +
@@ -729,25 +730,27 @@ var file_proto_grade_grade_proto_goTypes = []any{
 	(*ListSubjectsRequest)(nil),             // 9: grade.ListSubjectsRequest
 	(*Subject)(nil),                         // 10: grade.Subject
 	(*ListSubjectsResponse)(nil),            // 11: grade.ListSubjectsResponse
+	(work.WorkStatus)(0),                    // 12: work.WorkStatus
 }
 var file_proto_grade_grade_proto_depIdxs = []int32{
-	4,  // 0: grade.GetCriteriaMarksResponse.marks:type_name -> grade.CriterionMark
-	10, // 1: grade.ListSubjectsResponse.subjects:type_name -> grade.Subject
-	5,  // 2: grade.GradingService.SetBlockingCriteriaMark:input_type -> grade.SetBlockingCriteriaMarkRequest
-	7,  // 3: grade.GradingService.SetMainCriteriaMark:input_type -> grade.SetMainCriteriaMarkRequest
-	2,  // 4: grade.GradingService.GetCriteriaMarks:input_type -> grade.GetCriteriaMarksRequest
-	0,  // 5: grade.GradingService.UpdateWorkStatus:input_type -> grade.UpdateWorkStatusRequest
-	9,  // 6: grade.GradingService.ListSubjects:input_type -> grade.ListSubjectsRequest
-	6,  // 7: grade.GradingService.SetBlockingCriteriaMark:output_type -> grade.SetBlockingCriteriaMarkResponse
-	8,  // 8: grade.GradingService.SetMainCriteriaMark:output_type -> grade.SetMainCriteriaMarkResponse
-	3,  // 9: grade.GradingService.GetCriteriaMarks:output_type -> grade.GetCriteriaMarksResponse
-	1,  // 10: grade.GradingService.UpdateWorkStatus:output_type -> grade.UpdateWorkStatusResponse
-	11, // 11: grade.GradingService.ListSubjects:output_type -> grade.ListSubjectsResponse
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	12, // 0: grade.UpdateWorkStatusRequest.status:type_name -> work.WorkStatus
+	4,  // 1: grade.GetCriteriaMarksResponse.marks:type_name -> grade.CriterionMark
+	10, // 2: grade.ListSubjectsResponse.subjects:type_name -> grade.Subject
+	5,  // 3: grade.GradingService.SetBlockingCriteriaMark:input_type -> grade.SetBlockingCriteriaMarkRequest
+	7,  // 4: grade.GradingService.SetMainCriteriaMark:input_type -> grade.SetMainCriteriaMarkRequest
+	2,  // 5: grade.GradingService.GetCriteriaMarks:input_type -> grade.GetCriteriaMarksRequest
+	0,  // 6: grade.GradingService.UpdateWorkStatus:input_type -> grade.UpdateWorkStatusRequest
+	9,  // 7: grade.GradingService.ListSubjects:input_type -> grade.ListSubjectsRequest
+	6,  // 8: grade.GradingService.SetBlockingCriteriaMark:output_type -> grade.SetBlockingCriteriaMarkResponse
+	8,  // 9: grade.GradingService.SetMainCriteriaMark:output_type -> grade.SetMainCriteriaMarkResponse
+	3,  // 10: grade.GradingService.GetCriteriaMarks:output_type -> grade.GetCriteriaMarksResponse
+	1,  // 11: grade.GradingService.UpdateWorkStatus:output_type -> grade.UpdateWorkStatusResponse
+	11, // 12: grade.GradingService.ListSubjects:output_type -> grade.ListSubjectsResponse
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_grade_grade_proto_init() }
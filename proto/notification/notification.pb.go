@@ -145,11 +145,12 @@ const file_proto_notification_notification_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x04 \x01(\tR\tcreatedAt\",\n" +
 	"\x14NotificationResponse\x12\x14\n" +
-	"\x05error\x18\x01 \x01(\tR\x05error2\xc3\x02\n" +
+	"\x05error\x18\x01 \x01(\tR\x05error2\xa8\x03\n" +
 	"\x13NotificationService\x12]\n" +
 	"\x14SendTaskNotification\x12!.notification.NotificationRequest\x1a\".notification.NotificationResponse\x12e\n" +
 	"\x1cSendRegistrationNotification\x12!.notification.NotificationRequest\x1a\".notification.NotificationResponse\x12f\n" +
-	"\x1dSendPasswordResetNotification\x12!.notification.NotificationRequest\x1a\".notification.NotificationResponseB#Z!./proto/notification;notificationb\x06proto3"
+	"\x1dSendPasswordResetNotification\x12!.notification.NotificationRequest\x1a\".notification.NotificationResponse\x12c\n" +
+	"\x1aSendInvitationNotification\x12!.notification.NotificationRequest\x1a\".notification.NotificationResponseB#Z!./proto/notification;notificationb\x06proto3"
 
 var (
 	file_proto_notification_notification_proto_rawDescOnce sync.Once
@@ -172,11 +173,13 @@ var file_proto_notification_notification_proto_depIdxs = []int32{
 	0, // 0: notification.NotificationService.SendTaskNotification:input_type -> notification.NotificationRequest
 	0, // 1: notification.NotificationService.SendRegistrationNotification:input_type -> notification.NotificationRequest
 	0, // 2: notification.NotificationService.SendPasswordResetNotification:input_type -> notification.NotificationRequest
-	1, // 3: notification.NotificationService.SendTaskNotification:output_type -> notification.NotificationResponse
-	1, // 4: notification.NotificationService.SendRegistrationNotification:output_type -> notification.NotificationResponse
-	1, // 5: notification.NotificationService.SendPasswordResetNotification:output_type -> notification.NotificationResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
+	0, // 3: notification.NotificationService.SendInvitationNotification:input_type -> notification.NotificationRequest
+	1, // 4: notification.NotificationService.SendTaskNotification:output_type -> notification.NotificationResponse
+	1, // 5: notification.NotificationService.SendRegistrationNotification:output_type -> notification.NotificationResponse
+	1, // 6: notification.NotificationService.SendPasswordResetNotification:output_type -> notification.NotificationResponse
+	1, // 7: notification.NotificationService.SendInvitationNotification:output_type -> notification.NotificationResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
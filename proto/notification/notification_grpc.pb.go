@@ -22,6 +22,7 @@ const (
 	NotificationService_SendTaskNotification_FullMethodName          = "/notification.NotificationService/SendTaskNotification"
 	NotificationService_SendRegistrationNotification_FullMethodName  = "/notification.NotificationService/SendRegistrationNotification"
 	NotificationService_SendPasswordResetNotification_FullMethodName = "/notification.NotificationService/SendPasswordResetNotification"
+	NotificationService_SendInvitationNotification_FullMethodName    = "/notification.NotificationService/SendInvitationNotification"
 )
 
 // NotificationServiceClient is the client API for NotificationService service.
@@ -31,6 +32,7 @@ type NotificationServiceClient interface {
 	SendTaskNotification(ctx context.Context, in *NotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error)
 	SendRegistrationNotification(ctx context.Context, in *NotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error)
 	SendPasswordResetNotification(ctx context.Context, in *NotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error)
+	SendInvitationNotification(ctx context.Context, in *NotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error)
 }
 
 type notificationServiceClient struct {
@@ -71,6 +73,16 @@ func (c *notificationServiceClient) SendPasswordResetNotification(ctx context.Co
 	return out, nil
 }
 
+func (c *notificationServiceClient) SendInvitationNotification(ctx context.Context, in *NotificationRequest, opts ...grpc.CallOption) (*NotificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NotificationResponse)
+	err := c.cc.Invoke(ctx, NotificationService_SendInvitationNotification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NotificationServiceServer is the server API for NotificationService service.
 // All implementations must embed UnimplementedNotificationServiceServer
 // for forward compatibility.
@@ -78,6 +90,7 @@ type NotificationServiceServer interface {
 	SendTaskNotification(context.Context, *NotificationRequest) (*NotificationResponse, error)
 	SendRegistrationNotification(context.Context, *NotificationRequest) (*NotificationResponse, error)
 	SendPasswordResetNotification(context.Context, *NotificationRequest) (*NotificationResponse, error)
+	SendInvitationNotification(context.Context, *NotificationRequest) (*NotificationResponse, error)
 	mustEmbedUnimplementedNotificationServiceServer()
 }
 
@@ -97,6 +110,9 @@ func (UnimplementedNotificationServiceServer) SendRegistrationNotification(conte
 func (UnimplementedNotificationServiceServer) SendPasswordResetNotification(context.Context, *NotificationRequest) (*NotificationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendPasswordResetNotification not implemented")
 }
+func (UnimplementedNotificationServiceServer) SendInvitationNotification(context.Context, *NotificationRequest) (*NotificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendInvitationNotification not implemented")
+}
 func (UnimplementedNotificationServiceServer) mustEmbedUnimplementedNotificationServiceServer() {}
 func (UnimplementedNotificationServiceServer) testEmbeddedByValue()                             {}
 
@@ -172,6 +188,24 @@ func _NotificationService_SendPasswordResetNotification_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _NotificationService_SendInvitationNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NotificationServiceServer).SendInvitationNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NotificationService_SendInvitationNotification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NotificationServiceServer).SendInvitationNotification(ctx, req.(*NotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // NotificationService_ServiceDesc is the grpc.ServiceDesc for NotificationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,6 +225,10 @@ var NotificationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendPasswordResetNotification",
 			Handler:    _NotificationService_SendPasswordResetNotification_Handler,
 		},
+		{
+			MethodName: "SendInvitationNotification",
+			Handler:    _NotificationService_SendInvitationNotification_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/notification/notification.proto",
@@ -23,10 +23,123 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// UserRole — роль пользователя; совпадает с типом user_role в базе
+type UserRole int32
+
+const (
+	UserRole_USER_ROLE_UNSPECIFIED  UserRole = 0
+	UserRole_USER_ROLE_STUDENT      UserRole = 1
+	UserRole_USER_ROLE_ASSISTANT    UserRole = 2
+	UserRole_USER_ROLE_SEMINARIST   UserRole = 3
+	UserRole_USER_ROLE_LECTURER     UserRole = 4
+	UserRole_USER_ROLE_SUPERACCOUNT UserRole = 5
+)
+
+// Enum value maps for UserRole.
+var (
+	UserRole_name = map[int32]string{
+		0: "USER_ROLE_UNSPECIFIED",
+		1: "USER_ROLE_STUDENT",
+		2: "USER_ROLE_ASSISTANT",
+		3: "USER_ROLE_SEMINARIST",
+		4: "USER_ROLE_LECTURER",
+		5: "USER_ROLE_SUPERACCOUNT",
+	}
+	UserRole_value = map[string]int32{
+		"USER_ROLE_UNSPECIFIED":  0,
+		"USER_ROLE_STUDENT":      1,
+		"USER_ROLE_ASSISTANT":    2,
+		"USER_ROLE_SEMINARIST":   3,
+		"USER_ROLE_LECTURER":     4,
+		"USER_ROLE_SUPERACCOUNT": 5,
+	}
+)
+
+func (x UserRole) Enum() *UserRole {
+	p := new(UserRole)
+	*p = x
+	return p
+}
+
+func (x UserRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (UserRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_superacc_superacc_proto_enumTypes[0].Descriptor()
+}
+
+func (UserRole) Type() protoreflect.EnumType {
+	return &file_proto_superacc_superacc_proto_enumTypes[0]
+}
+
+func (x UserRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use UserRole.Descriptor instead.
+func (UserRole) EnumDescriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{0}
+}
+
+// MergeConflictPolicy — чья работа остаётся, если у обеих учётных записей есть работы
+// по одному заданию
+type MergeConflictPolicy int32
+
+const (
+	MergeConflictPolicy_MERGE_CONFLICT_POLICY_UNSPECIFIED MergeConflictPolicy = 0 // годится, только если конфликтов нет
+	MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_TARGET MergeConflictPolicy = 1
+	MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_SOURCE MergeConflictPolicy = 2
+	MergeConflictPolicy_MERGE_CONFLICT_POLICY_KEEP_LATEST MergeConflictPolicy = 3 // более поздняя сдача; при равенстве — target
+)
+
+// Enum value maps for MergeConflictPolicy.
+var (
+	MergeConflictPolicy_name = map[int32]string{
+		0: "MERGE_CONFLICT_POLICY_UNSPECIFIED",
+		1: "MERGE_CONFLICT_POLICY_KEEP_TARGET",
+		2: "MERGE_CONFLICT_POLICY_KEEP_SOURCE",
+		3: "MERGE_CONFLICT_POLICY_KEEP_LATEST",
+	}
+	MergeConflictPolicy_value = map[string]int32{
+		"MERGE_CONFLICT_POLICY_UNSPECIFIED": 0,
+		"MERGE_CONFLICT_POLICY_KEEP_TARGET": 1,
+		"MERGE_CONFLICT_POLICY_KEEP_SOURCE": 2,
+		"MERGE_CONFLICT_POLICY_KEEP_LATEST": 3,
+	}
+)
+
+func (x MergeConflictPolicy) Enum() *MergeConflictPolicy {
+	p := new(MergeConflictPolicy)
+	*p = x
+	return p
+}
+
+func (x MergeConflictPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MergeConflictPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_superacc_superacc_proto_enumTypes[1].Descriptor()
+}
+
+func (MergeConflictPolicy) Type() protoreflect.EnumType {
+	return &file_proto_superacc_superacc_proto_enumTypes[1]
+}
+
+func (x MergeConflictPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MergeConflictPolicy.Descriptor instead.
+func (MergeConflictPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{1}
+}
+
 type UpdateRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Role          UserRole               `protobuf:"varint,3,opt,name=role,proto3,enum=superacc.UserRole" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -68,11 +181,11 @@ func (x *UpdateRoleRequest) GetUserId() int32 {
 	return 0
 }
 
-func (x *UpdateRoleRequest) GetRole() string {
+func (x *UpdateRoleRequest) GetRole() UserRole {
 	if x != nil {
 		return x.Role
 	}
-	return ""
+	return UserRole_USER_ROLE_UNSPECIFIED
 }
 
 type UpdateRoleResponse struct {
@@ -132,7 +245,7 @@ type ManageGroupRequest struct {
 	GroupId       int32                  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
 	UserId        int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	Role          UserRole               `protobuf:"varint,5,opt,name=role,proto3,enum=superacc.UserRole" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -188,11 +301,11 @@ func (x *ManageGroupRequest) GetUserId() int32 {
 	return 0
 }
 
-func (x *ManageGroupRequest) GetRole() string {
+func (x *ManageGroupRequest) GetRole() UserRole {
 	if x != nil {
 		return x.Role
 	}
-	return ""
+	return UserRole_USER_ROLE_UNSPECIFIED
 }
 
 type ManageGroupResponse struct {
@@ -247,12 +360,19 @@ func (x *ManageGroupResponse) GetSuccess() bool {
 	return false
 }
 
+// ManageDisciplineRequest меняет состав преподавателей группы по дисциплине.
+// action "add" и "remove" добавляют и убирают user_id с ролью role ("seminarist" или
+// "assistant"); без action семинаристы и ассистенты заменяются на seminarist_id и
+// assistant_id (нулевой id оставляет роль как есть).
 type ManageDisciplineRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DisciplineId  int32                  `protobuf:"varint,1,opt,name=discipline_id,json=disciplineId,proto3" json:"discipline_id,omitempty"`
 	GroupId       int32                  `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
 	SeminaristId  int32                  `protobuf:"varint,3,opt,name=seminarist_id,json=seminaristId,proto3" json:"seminarist_id,omitempty"`
 	AssistantId   int32                  `protobuf:"varint,4,opt,name=assistant_id,json=assistantId,proto3" json:"assistant_id,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	UserId        int32                  `protobuf:"varint,6,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,7,opt,name=role,proto3" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -315,6 +435,27 @@ func (x *ManageDisciplineRequest) GetAssistantId() int32 {
 	return 0
 }
 
+func (x *ManageDisciplineRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ManageDisciplineRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ManageDisciplineRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 type ManageDisciplineResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -659,8 +800,18 @@ func (x *ManageGroupEntityResponse) GetGroupId() int32 {
 	return 0
 }
 
+// ListAllUsersRequest — страница списка пользователей. Следующая страница запрашивается
+// с cursor = next_cursor и теми же фильтрами и сортировкой.
 type ListAllUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 0 — 50, не больше 200
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`                                    // пусто — все роли
+	GroupId       int32                  `protobuf:"varint,4,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`                // 0 — без фильтра
+	DisciplineId  int32                  `protobuf:"varint,5,opt,name=discipline_id,json=disciplineId,proto3" json:"discipline_id,omitempty"` // студенты групп дисциплины, её семинаристы, ассистенты и лектор
+	Search        string                 `protobuf:"bytes,6,opt,name=search,proto3" json:"search,omitempty"`                                  // без учёта регистра по ФИО и почте; каждое слово должно найтись
+	Sort          string                 `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`                                      // "fio" (по умолчанию), "email", "role", "id"
+	Descending    bool                   `protobuf:"varint,8,opt,name=descending,proto3" json:"descending,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -695,6 +846,62 @@ func (*ListAllUsersRequest) Descriptor() ([]byte, []int) {
 	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{11}
 }
 
+func (x *ListAllUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAllUsersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListAllUsersRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *ListAllUsersRequest) GetGroupId() int32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *ListAllUsersRequest) GetDisciplineId() int32 {
+	if x != nil {
+		return x.DisciplineId
+	}
+	return 0
+}
+
+func (x *ListAllUsersRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListAllUsersRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListAllUsersRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
 type User struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -702,6 +909,7 @@ type User struct {
 	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
 	Group         string                 `protobuf:"bytes,4,opt,name=group,proto3" json:"group,omitempty"`
 	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Active        bool                   `protobuf:"varint,6,opt,name=active,proto3" json:"active,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -771,11 +979,20 @@ func (x *User) GetStatus() string {
 	return ""
 }
 
+func (x *User) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
 type ListAllUsersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	Users         []*User                `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`  // пусто на последней странице
+	TotalCount    int32                  `protobuf:"varint,5,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // всего пользователей по фильтрам
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -831,9 +1048,28 @@ func (x *ListAllUsersResponse) GetUsers() []*User {
 	return nil
 }
 
+func (x *ListAllUsersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListAllUsersResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
 type ListUsersByGroupRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 0 — 50, не больше 200
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Search        string                 `protobuf:"bytes,4,opt,name=search,proto3" json:"search,omitempty"`
+	Sort          string                 `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending    bool                   `protobuf:"varint,6,opt,name=descending,proto3" json:"descending,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -875,11 +1111,48 @@ func (x *ListUsersByGroupRequest) GetGroupName() string {
 	return ""
 }
 
+func (x *ListUsersByGroupRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersByGroupRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListUsersByGroupRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListUsersByGroupRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListUsersByGroupRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
 type ListUsersByGroupResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
 	Users         []*User                `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,5,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -935,6 +1208,21 @@ func (x *ListUsersByGroupResponse) GetUsers() []*User {
 	return nil
 }
 
+func (x *ListUsersByGroupResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListUsersByGroupResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// RemoveUser деактивирует пользователя по почте; окончательное удаление — PurgeUser
 type RemoveUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
@@ -1036,7 +1324,7 @@ type AddUserRequest struct {
 	Fio           string                 `protobuf:"bytes,1,opt,name=fio,proto3" json:"fio,omitempty"`
 	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
 	Group         string                 `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Status        UserRole               `protobuf:"varint,5,opt,name=status,proto3,enum=superacc.UserRole" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1092,11 +1380,11 @@ func (x *AddUserRequest) GetGroup() string {
 	return ""
 }
 
-func (x *AddUserRequest) GetStatus() string {
+func (x *AddUserRequest) GetStatus() UserRole {
 	if x != nil {
 		return x.Status
 	}
-	return ""
+	return UserRole_USER_ROLE_UNSPECIFIED
 }
 
 type AddUserResponse struct {
@@ -1163,6 +1451,7 @@ type Discipline struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TermId        int32                  `protobuf:"varint,3,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1211,8 +1500,16 @@ func (x *Discipline) GetName() string {
 	return ""
 }
 
+func (x *Discipline) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
 type ListDisciplinesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	TermId        int32                  `protobuf:"varint,1,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"` // 0 — активный семестр
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1247,6 +1544,13 @@ func (*ListDisciplinesRequest) Descriptor() ([]byte, []int) {
 	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{21}
 }
 
+func (x *ListDisciplinesRequest) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
 type ListDisciplinesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -1315,7 +1619,8 @@ type ManageDisciplineEntityRequest struct {
 	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
 	SeminaristId  int32                  `protobuf:"varint,5,opt,name=seminarist_id,json=seminaristId,proto3" json:"seminarist_id,omitempty"`
 	AssistantId   int32                  `protobuf:"varint,6,opt,name=assistant_id,json=assistantId,proto3" json:"assistant_id,omitempty"`
-	LectorId      int32                  `protobuf:"varint,7,opt,name=lector_id,json=lectorId,proto3" json:"lector_id,omitempty"`
+	LectorId      int32                  `protobuf:"varint,7,opt,name=lector_id,json=lectorId,proto3" json:"lector_id,omitempty"` // для create — владелец дисциплины
+	TermId        int32                  `protobuf:"varint,8,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"`       // для create; 0 — активный семестр
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1399,6 +1704,13 @@ func (x *ManageDisciplineEntityRequest) GetLectorId() int32 {
 	return 0
 }
 
+func (x *ManageDisciplineEntityRequest) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
 type ManageDisciplineEntityResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -1771,106 +2083,3607 @@ func (x *DetachDisciplinesFromGroupResponse) GetSuccess() bool {
 	return false
 }
 
-var File_proto_superacc_superacc_proto protoreflect.FileDescriptor
+type UnlockUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_superacc_superacc_proto_rawDesc = "" +
-	"\n" +
-	"\x1dproto/superacc/superacc.proto\x12\bsuperacc\"@\n" +
-	"\x11UpdateRoleRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x12\n" +
-	"\x04role\x18\x02 \x01(\tR\x04role\"H\n" +
-	"\x12UpdateRoleResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\"t\n" +
-	"\x12ManageGroupRequest\x12\x19\n" +
-	"\bgroup_id\x18\x01 \x01(\x05R\agroupId\x12\x16\n" +
-	"\x06action\x18\x02 \x01(\tR\x06action\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x12\n" +
-	"\x04role\x18\x04 \x01(\tR\x04role\"I\n" +
-	"\x13ManageGroupResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\"\xa1\x01\n" +
-	"\x17ManageDisciplineRequest\x12#\n" +
-	"\rdiscipline_id\x18\x01 \x01(\x05R\fdisciplineId\x12\x19\n" +
-	"\bgroup_id\x18\x02 \x01(\x05R\agroupId\x12#\n" +
-	"\rseminarist_id\x18\x03 \x01(\x05R\fseminaristId\x12!\n" +
-	"\fassistant_id\x18\x04 \x01(\x05R\vassistantId\"N\n" +
-	"\x18ManageDisciplineResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\"o\n" +
-	"\x05Group\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12 \n" +
-	"\vdisciplines\x18\x04 \x03(\tR\vdisciplines\"\x13\n" +
-	"\x11ListGroupsRequest\"q\n" +
-	"\x12ListGroupsResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12'\n" +
-	"\x06groups\x18\x03 \x03(\v2\x0f.superacc.GroupR\x06groups\"\x83\x01\n" +
-	"\x18ManageGroupEntityRequest\x12\x19\n" +
-	"\bgroup_id\x18\x01 \x01(\x05R\agroupId\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
-	"\x06action\x18\x04 \x01(\tR\x06action\"j\n" +
-	"\x19ManageGroupEntityResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x19\n" +
-	"\bgroup_id\x18\x03 \x01(\x05R\agroupId\"\x15\n" +
-	"\x13ListAllUsersRequest\"l\n" +
-	"\x04User\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x10\n" +
-	"\x03fio\x18\x02 \x01(\tR\x03fio\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
-	"\x05group\x18\x04 \x01(\tR\x05group\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\"p\n" +
-	"\x14ListAllUsersResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12$\n" +
-	"\x05users\x18\x03 \x03(\v2\x0e.superacc.UserR\x05users\"8\n" +
-	"\x17ListUsersByGroupRequest\x12\x1d\n" +
-	"\n" +
-	"group_name\x18\x01 \x01(\tR\tgroupName\"t\n" +
-	"\x18ListUsersByGroupResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12$\n" +
-	"\x05users\x18\x03 \x03(\v2\x0e.superacc.UserR\x05users\")\n" +
-	"\x11RemoveUserRequest\x12\x14\n" +
-	"\x05email\x18\x01 \x01(\tR\x05email\"H\n" +
-	"\x12RemoveUserResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\"f\n" +
-	"\x0eAddUserRequest\x12\x10\n" +
-	"\x03fio\x18\x01 \x01(\tR\x03fio\x12\x14\n" +
-	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
-	"\x05group\x18\x03 \x01(\tR\x05group\x12\x16\n" +
-	"\x06status\x18\x04 \x01(\tR\x06status\"^\n" +
-	"\x0fAddUserResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\x05R\x06userId\"0\n" +
-	"\n" +
-	"Discipline\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\x18\n" +
-	"\x16ListDisciplinesRequest\"\x85\x01\n" +
-	"\x17ListDisciplinesResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x126\n" +
-	"\vdisciplines\x18\x03 \x03(\v2\x14.superacc.DisciplineR\vdisciplines\"\xf2\x01\n" +
-	"\x1dManageDisciplineEntityRequest\x12\x16\n" +
-	"\x06action\x18\x01 \x01(\tR\x06action\x12\x19\n" +
-	"\bgroup_id\x18\x02 \x01(\x05R\agroupId\x12%\n" +
-	"\x0ediscipline_ids\x18\x03 \x03(\x05R\rdisciplineIds\x12\x12\n" +
-	"\x04name\x18\x04 \x01(\tR\x04name\x12#\n" +
-	"\rseminarist_id\x18\x05 \x01(\x05R\fseminaristId\x12!\n" +
-	"\fassistant_id\x18\x06 \x01(\x05R\vassistantId\x12\x1b\n" +
-	"\tlector_id\x18\a \x01(\x05R\blectorId\"y\n" +
-	"\x1eManageDisciplineEntityResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
-	"\rdiscipline_id\x18\x03 \x01(\x05R\fdisciplineId\"@\n" +
-	"\x17DeleteDisciplineRequest\x12%\n" +
+func (x *UnlockUserRequest) Reset() {
+	*x = UnlockUserRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserRequest) ProtoMessage() {}
+
+func (x *UnlockUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserRequest.ProtoReflect.Descriptor instead.
+func (*UnlockUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UnlockUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type UnlockUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockUserResponse) Reset() {
+	*x = UnlockUserResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockUserResponse) ProtoMessage() {}
+
+func (x *UnlockUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockUserResponse.ProtoReflect.Descriptor instead.
+func (*UnlockUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UnlockUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *UnlockUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type Invitation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Fio           string                 `protobuf:"bytes,3,opt,name=fio,proto3" json:"fio,omitempty"`
+	Email         string                 `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // pending, accepted, expired, revoked
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     string                 `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invitation) Reset() {
+	*x = Invitation{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invitation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invitation) ProtoMessage() {}
+
+func (x *Invitation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invitation.ProtoReflect.Descriptor instead.
+func (*Invitation) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Invitation) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Invitation) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Invitation) GetFio() string {
+	if x != nil {
+		return x.Fio
+	}
+	return ""
+}
+
+func (x *Invitation) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Invitation) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *Invitation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Invitation) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Invitation) GetExpiresAt() string {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return ""
+}
+
+type ListInvitationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PendingOnly   bool                   `protobuf:"varint,1,opt,name=pending_only,json=pendingOnly,proto3" json:"pending_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsRequest) Reset() {
+	*x = ListInvitationsRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsRequest) ProtoMessage() {}
+
+func (x *ListInvitationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsRequest.ProtoReflect.Descriptor instead.
+func (*ListInvitationsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListInvitationsRequest) GetPendingOnly() bool {
+	if x != nil {
+		return x.PendingOnly
+	}
+	return false
+}
+
+type ListInvitationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invitations   []*Invitation          `protobuf:"bytes,1,rep,name=invitations,proto3" json:"invitations,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInvitationsResponse) Reset() {
+	*x = ListInvitationsResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInvitationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInvitationsResponse) ProtoMessage() {}
+
+func (x *ListInvitationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInvitationsResponse.ProtoReflect.Descriptor instead.
+func (*ListInvitationsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListInvitationsResponse) GetInvitations() []*Invitation {
+	if x != nil {
+		return x.Invitations
+	}
+	return nil
+}
+
+func (x *ListInvitationsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListInvitationsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ResendInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendInvitationRequest) Reset() {
+	*x = ResendInvitationRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendInvitationRequest) ProtoMessage() {}
+
+func (x *ResendInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendInvitationRequest.ProtoReflect.Descriptor instead.
+func (*ResendInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ResendInvitationRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ResendInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendInvitationResponse) Reset() {
+	*x = ResendInvitationResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendInvitationResponse) ProtoMessage() {}
+
+func (x *ResendInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendInvitationResponse.ProtoReflect.Descriptor instead.
+func (*ResendInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ResendInvitationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResendInvitationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type RevokeInvitationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InvitationId  int32                  `protobuf:"varint,1,opt,name=invitation_id,json=invitationId,proto3" json:"invitation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInvitationRequest) Reset() {
+	*x = RevokeInvitationRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInvitationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInvitationRequest) ProtoMessage() {}
+
+func (x *RevokeInvitationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInvitationRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInvitationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RevokeInvitationRequest) GetInvitationId() int32 {
+	if x != nil {
+		return x.InvitationId
+	}
+	return 0
+}
+
+type RevokeInvitationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInvitationResponse) Reset() {
+	*x = RevokeInvitationResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInvitationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInvitationResponse) ProtoMessage() {}
+
+func (x *RevokeInvitationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInvitationResponse.ProtoReflect.Descriptor instead.
+func (*RevokeInvitationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RevokeInvitationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RevokeInvitationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type DeactivateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateUserRequest) Reset() {
+	*x = DeactivateUserRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateUserRequest) ProtoMessage() {}
+
+func (x *DeactivateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateUserRequest.ProtoReflect.Descriptor instead.
+func (*DeactivateUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeactivateUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type DeactivateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivateUserResponse) Reset() {
+	*x = DeactivateUserResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivateUserResponse) ProtoMessage() {}
+
+func (x *DeactivateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivateUserResponse.ProtoReflect.Descriptor instead.
+func (*DeactivateUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeactivateUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DeactivateUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ReactivateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateUserRequest) Reset() {
+	*x = ReactivateUserRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateUserRequest) ProtoMessage() {}
+
+func (x *ReactivateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateUserRequest.ProtoReflect.Descriptor instead.
+func (*ReactivateUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ReactivateUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ReactivateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactivateUserResponse) Reset() {
+	*x = ReactivateUserResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactivateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactivateUserResponse) ProtoMessage() {}
+
+func (x *ReactivateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactivateUserResponse.ProtoReflect.Descriptor instead.
+func (*ReactivateUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ReactivateUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReactivateUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// PurgeUser окончательно удаляет деактивированного пользователя вместе с его работами и оценками
+type PurgeUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	ConfirmEmail  string                 `protobuf:"bytes,3,opt,name=confirm_email,json=confirmEmail,proto3" json:"confirm_email,omitempty"` // должна совпадать с почтой удаляемого пользователя
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeUserRequest) Reset() {
+	*x = PurgeUserRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeUserRequest) ProtoMessage() {}
+
+func (x *PurgeUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeUserRequest.ProtoReflect.Descriptor instead.
+func (*PurgeUserRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PurgeUserRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PurgeUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PurgeUserRequest) GetConfirmEmail() string {
+	if x != nil {
+		return x.ConfirmEmail
+	}
+	return ""
+}
+
+type PurgeUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeUserResponse) Reset() {
+	*x = PurgeUserResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeUserResponse) ProtoMessage() {}
+
+func (x *PurgeUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeUserResponse.ProtoReflect.Descriptor instead.
+func (*PurgeUserResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PurgeUserResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PurgeUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ImpersonateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateRequest) Reset() {
+	*x = ImpersonateRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateRequest) ProtoMessage() {}
+
+func (x *ImpersonateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ImpersonateRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImpersonateRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ImpersonateResponse несёт токен от имени пользователя; обновить его нельзя
+type ImpersonateResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Token           string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt       int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ImpersonationId int32                  `protobuf:"varint,3,opt,name=impersonation_id,json=impersonationId,proto3" json:"impersonation_id,omitempty"`
+	UserId          int32                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role            string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	Fio             string                 `protobuf:"bytes,6,opt,name=fio,proto3" json:"fio,omitempty"`
+	Message         string                 `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"`
+	Success         bool                   `protobuf:"varint,8,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImpersonateResponse) Reset() {
+	*x = ImpersonateResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateResponse) ProtoMessage() {}
+
+func (x *ImpersonateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateResponse.ProtoReflect.Descriptor instead.
+func (*ImpersonateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ImpersonateResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ImpersonateResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ImpersonateResponse) GetImpersonationId() int32 {
+	if x != nil {
+		return x.ImpersonationId
+	}
+	return 0
+}
+
+func (x *ImpersonateResponse) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImpersonateResponse) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ImpersonateResponse) GetFio() string {
+	if x != nil {
+		return x.Fio
+	}
+	return ""
+}
+
+func (x *ImpersonateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImpersonateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type EndImpersonationRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ImpersonationId int32                  `protobuf:"varint,1,opt,name=impersonation_id,json=impersonationId,proto3" json:"impersonation_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *EndImpersonationRequest) Reset() {
+	*x = EndImpersonationRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndImpersonationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndImpersonationRequest) ProtoMessage() {}
+
+func (x *EndImpersonationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndImpersonationRequest.ProtoReflect.Descriptor instead.
+func (*EndImpersonationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *EndImpersonationRequest) GetImpersonationId() int32 {
+	if x != nil {
+		return x.ImpersonationId
+	}
+	return 0
+}
+
+type EndImpersonationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndImpersonationResponse) Reset() {
+	*x = EndImpersonationResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndImpersonationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndImpersonationResponse) ProtoMessage() {}
+
+func (x *EndImpersonationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndImpersonationResponse.ProtoReflect.Descriptor instead.
+func (*EndImpersonationResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *EndImpersonationResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *EndImpersonationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ImportUsersOptions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DryRun        bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // только проверить строки, ничего не записывая
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersOptions) Reset() {
+	*x = ImportUsersOptions{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersOptions) ProtoMessage() {}
+
+func (x *ImportUsersOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersOptions.ProtoReflect.Descriptor instead.
+func (*ImportUsersOptions) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ImportUsersOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// ImportUsersRequest: первым сообщением идут options, затем CSV по частям.
+// Колонки: fio, email, group, role; строка заголовка необязательна.
+type ImportUsersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportUsersRequest_Options
+	//	*ImportUsersRequest_CsvChunk
+	Payload       isImportUsersRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersRequest) Reset() {
+	*x = ImportUsersRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersRequest) ProtoMessage() {}
+
+func (x *ImportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ImportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ImportUsersRequest) GetPayload() isImportUsersRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportUsersRequest) GetOptions() *ImportUsersOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportUsersRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportUsersRequest) GetCsvChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportUsersRequest_CsvChunk); ok {
+			return x.CsvChunk
+		}
+	}
+	return nil
+}
+
+type isImportUsersRequest_Payload interface {
+	isImportUsersRequest_Payload()
+}
+
+type ImportUsersRequest_Options struct {
+	Options *ImportUsersOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportUsersRequest_CsvChunk struct {
+	CsvChunk []byte `protobuf:"bytes,2,opt,name=csv_chunk,json=csvChunk,proto3,oneof"`
+}
+
+func (*ImportUsersRequest_Options) isImportUsersRequest_Payload() {}
+
+func (*ImportUsersRequest_CsvChunk) isImportUsersRequest_Payload() {}
+
+type ImportUserResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Line  int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Email string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	// created, already_exists, duplicate_in_file, invalid_group, invalid_role,
+	// invalid_email, malformed_fio, malformed_row, rolled_back
+	Status        string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	UserId        int32  `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // только для created без dry_run
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUserResult) Reset() {
+	*x = ImportUserResult{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUserResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUserResult) ProtoMessage() {}
+
+func (x *ImportUserResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUserResult.ProtoReflect.Descriptor instead.
+func (*ImportUserResult) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ImportUserResult) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportUserResult) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *ImportUserResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportUserResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportUserResult) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ImportUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ImportUserResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created       int32                  `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Skipped       int32                  `protobuf:"varint,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Committed     bool                   `protobuf:"varint,5,opt,name=committed,proto3" json:"committed,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,7,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersResponse) Reset() {
+	*x = ImportUsersResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersResponse) ProtoMessage() {}
+
+func (x *ImportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ImportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ImportUsersResponse) GetResults() []*ImportUserResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *ImportUsersResponse) GetCreated() int32 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *ImportUsersResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportUsersResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *ImportUsersResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportUsersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Справочник — пользователи, группы, состав групп, дисциплины и их назначения группам.
+// Записи ссылаются друг на друга по почте и названиям, а не по id, поэтому выгрузку
+// одной базы можно загрузить в другую.
+type ExportDirectoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // "csv" или "json"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportDirectoryRequest) Reset() {
+	*x = ExportDirectoryRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportDirectoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDirectoryRequest) ProtoMessage() {}
+
+func (x *ExportDirectoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDirectoryRequest.ProtoReflect.Descriptor instead.
+func (*ExportDirectoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ExportDirectoryRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ExportDirectoryChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportDirectoryChunk) Reset() {
+	*x = ExportDirectoryChunk{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportDirectoryChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDirectoryChunk) ProtoMessage() {}
+
+func (x *ExportDirectoryChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDirectoryChunk.ProtoReflect.Descriptor instead.
+func (*ExportDirectoryChunk) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ExportDirectoryChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportDirectoryOptions struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Format          string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`                                           // "csv" или "json"
+	DryRun          bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                            // проверить и посчитать изменения, ничего не записывая
+	UpdateExisting  bool                   `protobuf:"varint,3,opt,name=update_existing,json=updateExisting,proto3" json:"update_existing,omitempty"`    // обновлять ФИО и роли, описания групп, даты семестров, лекторов и назначения; блокировка и состояние семестров не меняются, архивные семестры не трогаются
+	SendInvitations bool                   `protobuf:"varint,4,opt,name=send_invitations,json=sendInvitations,proto3" json:"send_invitations,omitempty"` // отправить приглашения созданным активным пользователям
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImportDirectoryOptions) Reset() {
+	*x = ImportDirectoryOptions{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportDirectoryOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportDirectoryOptions) ProtoMessage() {}
+
+func (x *ImportDirectoryOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportDirectoryOptions.ProtoReflect.Descriptor instead.
+func (*ImportDirectoryOptions) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ImportDirectoryOptions) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportDirectoryOptions) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportDirectoryOptions) GetUpdateExisting() bool {
+	if x != nil {
+		return x.UpdateExisting
+	}
+	return false
+}
+
+func (x *ImportDirectoryOptions) GetSendInvitations() bool {
+	if x != nil {
+		return x.SendInvitations
+	}
+	return false
+}
+
+// ImportDirectoryRequest: первым сообщением идут options, затем выгрузка по частям
+type ImportDirectoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportDirectoryRequest_Options
+	//	*ImportDirectoryRequest_Chunk
+	Payload       isImportDirectoryRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportDirectoryRequest) Reset() {
+	*x = ImportDirectoryRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportDirectoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportDirectoryRequest) ProtoMessage() {}
+
+func (x *ImportDirectoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportDirectoryRequest.ProtoReflect.Descriptor instead.
+func (*ImportDirectoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ImportDirectoryRequest) GetPayload() isImportDirectoryRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportDirectoryRequest) GetOptions() *ImportDirectoryOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportDirectoryRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportDirectoryRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportDirectoryRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportDirectoryRequest_Payload interface {
+	isImportDirectoryRequest_Payload()
+}
+
+type ImportDirectoryRequest_Options struct {
+	Options *ImportDirectoryOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportDirectoryRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportDirectoryRequest_Options) isImportDirectoryRequest_Payload() {}
+
+func (*ImportDirectoryRequest_Chunk) isImportDirectoryRequest_Payload() {}
+
+type ImportDirectoryResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	UsersCreated       int32                  `protobuf:"varint,1,opt,name=users_created,json=usersCreated,proto3" json:"users_created,omitempty"`
+	UsersUpdated       int32                  `protobuf:"varint,2,opt,name=users_updated,json=usersUpdated,proto3" json:"users_updated,omitempty"`
+	GroupsCreated      int32                  `protobuf:"varint,3,opt,name=groups_created,json=groupsCreated,proto3" json:"groups_created,omitempty"`
+	GroupsUpdated      int32                  `protobuf:"varint,4,opt,name=groups_updated,json=groupsUpdated,proto3" json:"groups_updated,omitempty"`
+	MembershipsCreated int32                  `protobuf:"varint,5,opt,name=memberships_created,json=membershipsCreated,proto3" json:"memberships_created,omitempty"`
+	DisciplinesCreated int32                  `protobuf:"varint,6,opt,name=disciplines_created,json=disciplinesCreated,proto3" json:"disciplines_created,omitempty"`
+	DisciplinesUpdated int32                  `protobuf:"varint,7,opt,name=disciplines_updated,json=disciplinesUpdated,proto3" json:"disciplines_updated,omitempty"`
+	AssignmentsCreated int32                  `protobuf:"varint,8,opt,name=assignments_created,json=assignmentsCreated,proto3" json:"assignments_created,omitempty"`
+	AssignmentsUpdated int32                  `protobuf:"varint,9,opt,name=assignments_updated,json=assignmentsUpdated,proto3" json:"assignments_updated,omitempty"`
+	Problems           []string               `protobuf:"bytes,10,rep,name=problems,proto3" json:"problems,omitempty"` // при любой проблеме ничего не записывается
+	DryRun             bool                   `protobuf:"varint,11,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Committed          bool                   `protobuf:"varint,12,opt,name=committed,proto3" json:"committed,omitempty"`
+	Message            string                 `protobuf:"bytes,13,opt,name=message,proto3" json:"message,omitempty"`
+	Success            bool                   `protobuf:"varint,14,opt,name=success,proto3" json:"success,omitempty"`
+	TermsCreated       int32                  `protobuf:"varint,15,opt,name=terms_created,json=termsCreated,proto3" json:"terms_created,omitempty"`
+	TermsUpdated       int32                  `protobuf:"varint,16,opt,name=terms_updated,json=termsUpdated,proto3" json:"terms_updated,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ImportDirectoryResponse) Reset() {
+	*x = ImportDirectoryResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportDirectoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportDirectoryResponse) ProtoMessage() {}
+
+func (x *ImportDirectoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportDirectoryResponse.ProtoReflect.Descriptor instead.
+func (*ImportDirectoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ImportDirectoryResponse) GetUsersCreated() int32 {
+	if x != nil {
+		return x.UsersCreated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetUsersUpdated() int32 {
+	if x != nil {
+		return x.UsersUpdated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetGroupsCreated() int32 {
+	if x != nil {
+		return x.GroupsCreated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetGroupsUpdated() int32 {
+	if x != nil {
+		return x.GroupsUpdated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetMembershipsCreated() int32 {
+	if x != nil {
+		return x.MembershipsCreated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetDisciplinesCreated() int32 {
+	if x != nil {
+		return x.DisciplinesCreated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetDisciplinesUpdated() int32 {
+	if x != nil {
+		return x.DisciplinesUpdated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetAssignmentsCreated() int32 {
+	if x != nil {
+		return x.AssignmentsCreated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetAssignmentsUpdated() int32 {
+	if x != nil {
+		return x.AssignmentsUpdated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetProblems() []string {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+func (x *ImportDirectoryResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ImportDirectoryResponse) GetCommitted() bool {
+	if x != nil {
+		return x.Committed
+	}
+	return false
+}
+
+func (x *ImportDirectoryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ImportDirectoryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ImportDirectoryResponse) GetTermsCreated() int32 {
+	if x != nil {
+		return x.TermsCreated
+	}
+	return 0
+}
+
+func (x *ImportDirectoryResponse) GetTermsUpdated() int32 {
+	if x != nil {
+		return x.TermsUpdated
+	}
+	return 0
+}
+
+// Семестр: дисциплины и назначения групп принадлежат семестру. Даты — YYYY-MM-DD,
+// state — "upcoming", "active" или "archived"; архивный семестр только для чтения.
+type Term struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	StartsOn      string                 `protobuf:"bytes,3,opt,name=starts_on,json=startsOn,proto3" json:"starts_on,omitempty"`
+	EndsOn        string                 `protobuf:"bytes,4,opt,name=ends_on,json=endsOn,proto3" json:"ends_on,omitempty"`
+	State         string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Term) Reset() {
+	*x = Term{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Term) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Term) ProtoMessage() {}
+
+func (x *Term) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Term.ProtoReflect.Descriptor instead.
+func (*Term) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *Term) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Term) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Term) GetStartsOn() string {
+	if x != nil {
+		return x.StartsOn
+	}
+	return ""
+}
+
+func (x *Term) GetEndsOn() string {
+	if x != nil {
+		return x.EndsOn
+	}
+	return ""
+}
+
+func (x *Term) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type CreateTermRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	StartsOn      string                 `protobuf:"bytes,2,opt,name=starts_on,json=startsOn,proto3" json:"starts_on,omitempty"`
+	EndsOn        string                 `protobuf:"bytes,3,opt,name=ends_on,json=endsOn,proto3" json:"ends_on,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTermRequest) Reset() {
+	*x = CreateTermRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTermRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTermRequest) ProtoMessage() {}
+
+func (x *CreateTermRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTermRequest.ProtoReflect.Descriptor instead.
+func (*CreateTermRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CreateTermRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTermRequest) GetStartsOn() string {
+	if x != nil {
+		return x.StartsOn
+	}
+	return ""
+}
+
+func (x *CreateTermRequest) GetEndsOn() string {
+	if x != nil {
+		return x.EndsOn
+	}
+	return ""
+}
+
+type CreateTermResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	TermId        int32                  `protobuf:"varint,3,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTermResponse) Reset() {
+	*x = CreateTermResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTermResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTermResponse) ProtoMessage() {}
+
+func (x *CreateTermResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTermResponse.ProtoReflect.Descriptor instead.
+func (*CreateTermResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CreateTermResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateTermResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateTermResponse) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
+type ListTermsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTermsRequest) Reset() {
+	*x = ListTermsRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTermsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTermsRequest) ProtoMessage() {}
+
+func (x *ListTermsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTermsRequest.ProtoReflect.Descriptor instead.
+func (*ListTermsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{62}
+}
+
+type ListTermsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Terms         []*Term                `protobuf:"bytes,3,rep,name=terms,proto3" json:"terms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTermsResponse) Reset() {
+	*x = ListTermsResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTermsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTermsResponse) ProtoMessage() {}
+
+func (x *ListTermsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTermsResponse.ProtoReflect.Descriptor instead.
+func (*ListTermsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListTermsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListTermsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListTermsResponse) GetTerms() []*Term {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+// SetTermState: активным может быть только один семестр
+type SetTermStateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TermId        int32                  `protobuf:"varint,1,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTermStateRequest) Reset() {
+	*x = SetTermStateRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTermStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTermStateRequest) ProtoMessage() {}
+
+func (x *SetTermStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTermStateRequest.ProtoReflect.Descriptor instead.
+func (*SetTermStateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *SetTermStateRequest) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
+func (x *SetTermStateRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type SetTermStateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTermStateResponse) Reset() {
+	*x = SetTermStateResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTermStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTermStateResponse) ProtoMessage() {}
+
+func (x *SetTermStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTermStateResponse.ProtoReflect.Descriptor instead.
+func (*SetTermStateResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SetTermStateResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SetTermStateResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type StaffMember struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DisciplineId   int32                  `protobuf:"varint,1,opt,name=discipline_id,json=disciplineId,proto3" json:"discipline_id,omitempty"`
+	DisciplineName string                 `protobuf:"bytes,2,opt,name=discipline_name,json=disciplineName,proto3" json:"discipline_name,omitempty"`
+	GroupId        int32                  `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	UserId         int32                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Fio            string                 `protobuf:"bytes,5,opt,name=fio,proto3" json:"fio,omitempty"`
+	Email          string                 `protobuf:"bytes,6,opt,name=email,proto3" json:"email,omitempty"`
+	Role           string                 `protobuf:"bytes,7,opt,name=role,proto3" json:"role,omitempty"` // "seminarist" или "assistant"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StaffMember) Reset() {
+	*x = StaffMember{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StaffMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StaffMember) ProtoMessage() {}
+
+func (x *StaffMember) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StaffMember.ProtoReflect.Descriptor instead.
+func (*StaffMember) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *StaffMember) GetDisciplineId() int32 {
+	if x != nil {
+		return x.DisciplineId
+	}
+	return 0
+}
+
+func (x *StaffMember) GetDisciplineName() string {
+	if x != nil {
+		return x.DisciplineName
+	}
+	return ""
+}
+
+func (x *StaffMember) GetGroupId() int32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *StaffMember) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *StaffMember) GetFio() string {
+	if x != nil {
+		return x.Fio
+	}
+	return ""
+}
+
+func (x *StaffMember) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *StaffMember) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ListDisciplineStaffRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupId       int32                  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	DisciplineId  int32                  `protobuf:"varint,2,opt,name=discipline_id,json=disciplineId,proto3" json:"discipline_id,omitempty"` // 0 — все дисциплины группы
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisciplineStaffRequest) Reset() {
+	*x = ListDisciplineStaffRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisciplineStaffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisciplineStaffRequest) ProtoMessage() {}
+
+func (x *ListDisciplineStaffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisciplineStaffRequest.ProtoReflect.Descriptor instead.
+func (*ListDisciplineStaffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListDisciplineStaffRequest) GetGroupId() int32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
+func (x *ListDisciplineStaffRequest) GetDisciplineId() int32 {
+	if x != nil {
+		return x.DisciplineId
+	}
+	return 0
+}
+
+type ListDisciplineStaffResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Staff         []*StaffMember         `protobuf:"bytes,3,rep,name=staff,proto3" json:"staff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisciplineStaffResponse) Reset() {
+	*x = ListDisciplineStaffResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisciplineStaffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisciplineStaffResponse) ProtoMessage() {}
+
+func (x *ListDisciplineStaffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisciplineStaffResponse.ProtoReflect.Descriptor instead.
+func (*ListDisciplineStaffResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListDisciplineStaffResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListDisciplineStaffResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListDisciplineStaffResponse) GetStaff() []*StaffMember {
+	if x != nil {
+		return x.Staff
+	}
+	return nil
+}
+
+// ManageDisciplineLecturer: action "set" назначает лектору роль role ("owner",
+// "co_lecturer" или "read_only"), "remove" убирает его из дисциплины. Новый владелец
+// становится единственным, прежний — соавтором
+type ManageDisciplineLecturerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisciplineId  int32                  `protobuf:"varint,1,opt,name=discipline_id,json=disciplineId,proto3" json:"discipline_id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	UserId        int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManageDisciplineLecturerRequest) Reset() {
+	*x = ManageDisciplineLecturerRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManageDisciplineLecturerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManageDisciplineLecturerRequest) ProtoMessage() {}
+
+func (x *ManageDisciplineLecturerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManageDisciplineLecturerRequest.ProtoReflect.Descriptor instead.
+func (*ManageDisciplineLecturerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ManageDisciplineLecturerRequest) GetDisciplineId() int32 {
+	if x != nil {
+		return x.DisciplineId
+	}
+	return 0
+}
+
+func (x *ManageDisciplineLecturerRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ManageDisciplineLecturerRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ManageDisciplineLecturerRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ManageDisciplineLecturerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManageDisciplineLecturerResponse) Reset() {
+	*x = ManageDisciplineLecturerResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManageDisciplineLecturerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManageDisciplineLecturerResponse) ProtoMessage() {}
+
+func (x *ManageDisciplineLecturerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManageDisciplineLecturerResponse.ProtoReflect.Descriptor instead.
+func (*ManageDisciplineLecturerResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ManageDisciplineLecturerResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ManageDisciplineLecturerResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DisciplineLecturer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Fio           string                 `protobuf:"bytes,2,opt,name=fio,proto3" json:"fio,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Role          string                 `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisciplineLecturer) Reset() {
+	*x = DisciplineLecturer{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisciplineLecturer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisciplineLecturer) ProtoMessage() {}
+
+func (x *DisciplineLecturer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisciplineLecturer.ProtoReflect.Descriptor instead.
+func (*DisciplineLecturer) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *DisciplineLecturer) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DisciplineLecturer) GetFio() string {
+	if x != nil {
+		return x.Fio
+	}
+	return ""
+}
+
+func (x *DisciplineLecturer) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *DisciplineLecturer) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ListDisciplineLecturersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisciplineId  int32                  `protobuf:"varint,1,opt,name=discipline_id,json=disciplineId,proto3" json:"discipline_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisciplineLecturersRequest) Reset() {
+	*x = ListDisciplineLecturersRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisciplineLecturersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisciplineLecturersRequest) ProtoMessage() {}
+
+func (x *ListDisciplineLecturersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisciplineLecturersRequest.ProtoReflect.Descriptor instead.
+func (*ListDisciplineLecturersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListDisciplineLecturersRequest) GetDisciplineId() int32 {
+	if x != nil {
+		return x.DisciplineId
+	}
+	return 0
+}
+
+type ListDisciplineLecturersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Lecturers     []*DisciplineLecturer  `protobuf:"bytes,3,rep,name=lecturers,proto3" json:"lecturers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisciplineLecturersResponse) Reset() {
+	*x = ListDisciplineLecturersResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisciplineLecturersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisciplineLecturersResponse) ProtoMessage() {}
+
+func (x *ListDisciplineLecturersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisciplineLecturersResponse.ProtoReflect.Descriptor instead.
+func (*ListDisciplineLecturersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListDisciplineLecturersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListDisciplineLecturersResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ListDisciplineLecturersResponse) GetLecturers() []*DisciplineLecturer {
+	if x != nil {
+		return x.Lecturers
+	}
+	return nil
+}
+
+type QueryAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActorId       int32                  `protobuf:"varint,1,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`    // 0 — все
+	Method        string                 `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`                      // подстрока имени метода без учёта регистра
+	Entity        string                 `protobuf:"bytes,3,opt,name=entity,proto3" json:"entity,omitempty"`                      // user, group, group_discipline, discipline, term, task, work, criteria_group, criterion, rubric, invitation, directory
+	TargetId      string                 `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`  // id объекта; для AddUser и RemoveUser — почта, для ReconcileRoster — имя группы
+	Since         string                 `protobuf:"bytes,5,opt,name=since,proto3" json:"since,omitempty"`                        // RFC3339, включительно
+	Until         string                 `protobuf:"bytes,6,opt,name=until,proto3" json:"until,omitempty"`                        // RFC3339, не включительно
+	PageSize      int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"` // 0 — 50, не больше 200
+	BeforeId      int64                  `protobuf:"varint,8,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"` // 0 — с самых новых записей
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditLogRequest) Reset() {
+	*x = QueryAuditLogRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogRequest) ProtoMessage() {}
+
+func (x *QueryAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *QueryAuditLogRequest) GetActorId() int32 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *QueryAuditLogRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *QueryAuditLogRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *QueryAuditLogRequest) GetBeforeId() int64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+type AuditEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId        int32                  `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	ActorEmail     string                 `protobuf:"bytes,3,opt,name=actor_email,json=actorEmail,proto3" json:"actor_email,omitempty"` // пусто, если пользователь удалён
+	ActorRole      string                 `protobuf:"bytes,4,opt,name=actor_role,json=actorRole,proto3" json:"actor_role,omitempty"`
+	ImpersonatorId int32                  `protobuf:"varint,5,opt,name=impersonator_id,json=impersonatorId,proto3" json:"impersonator_id,omitempty"`
+	Method         string                 `protobuf:"bytes,6,opt,name=method,proto3" json:"method,omitempty"`
+	Entity         string                 `protobuf:"bytes,7,opt,name=entity,proto3" json:"entity,omitempty"`
+	TargetIds      []string               `protobuf:"bytes,8,rep,name=target_ids,json=targetIds,proto3" json:"target_ids,omitempty"`
+	BeforeJson     string                 `protobuf:"bytes,9,opt,name=before_json,json=beforeJson,proto3" json:"before_json,omitempty"`
+	AfterJson      string                 `protobuf:"bytes,10,opt,name=after_json,json=afterJson,proto3" json:"after_json,omitempty"`
+	Succeeded      bool                   `protobuf:"varint,11,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	StatusCode     string                 `protobuf:"bytes,12,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *AuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetActorId() int32 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetActorEmail() string {
+	if x != nil {
+		return x.ActorEmail
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetActorRole() string {
+	if x != nil {
+		return x.ActorRole
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetImpersonatorId() int32 {
+	if x != nil {
+		return x.ImpersonatorId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTargetIds() []string {
+	if x != nil {
+		return x.TargetIds
+	}
+	return nil
+}
+
+func (x *AuditEntry) GetBeforeJson() string {
+	if x != nil {
+		return x.BeforeJson
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetAfterJson() string {
+	if x != nil {
+		return x.AfterJson
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
+}
+
+func (x *AuditEntry) GetStatusCode() string {
+	if x != nil {
+		return x.StatusCode
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type QueryAuditLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Entries       []*AuditEntry          `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`                                  // от новых к старым
+	NextBeforeId  int64                  `protobuf:"varint,4,opt,name=next_before_id,json=nextBeforeId,proto3" json:"next_before_id,omitempty"` // 0 на последней странице
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAuditLogResponse) Reset() {
+	*x = QueryAuditLogResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAuditLogResponse) ProtoMessage() {}
+
+func (x *QueryAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*QueryAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *QueryAuditLogResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *QueryAuditLogResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *QueryAuditLogResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *QueryAuditLogResponse) GetNextBeforeId() int64 {
+	if x != nil {
+		return x.NextBeforeId
+	}
+	return 0
+}
+
+// RosterGroup — желаемый состав студентов группы по списку деканата
+type RosterGroup struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GroupName     string                 `protobuf:"bytes,1,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	Emails        []string               `protobuf:"bytes,2,rep,name=emails,proto3" json:"emails,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RosterGroup) Reset() {
+	*x = RosterGroup{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RosterGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RosterGroup) ProtoMessage() {}
+
+func (x *RosterGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RosterGroup.ProtoReflect.Descriptor instead.
+func (*RosterGroup) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *RosterGroup) GetGroupName() string {
+	if x != nil {
+		return x.GroupName
+	}
+	return ""
+}
+
+func (x *RosterGroup) GetEmails() []string {
+	if x != nil {
+		return x.Emails
+	}
+	return nil
+}
+
+// ReconcileRosterRequest приводит студентов перечисленных групп к списку; остальные
+// группы и не-студенты в группах не меняются
+type ReconcileRosterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*RosterGroup         `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileRosterRequest) Reset() {
+	*x = ReconcileRosterRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileRosterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileRosterRequest) ProtoMessage() {}
+
+func (x *ReconcileRosterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileRosterRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileRosterRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ReconcileRosterRequest) GetGroups() []*RosterGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *ReconcileRosterRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type RosterChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"` // add, move, remove
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	FromGroup     string                 `protobuf:"bytes,4,opt,name=from_group,json=fromGroup,proto3" json:"from_group,omitempty"` // для move и remove
+	ToGroup       string                 `protobuf:"bytes,5,opt,name=to_group,json=toGroup,proto3" json:"to_group,omitempty"`       // для add и move
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RosterChange) Reset() {
+	*x = RosterChange{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RosterChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RosterChange) ProtoMessage() {}
+
+func (x *RosterChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RosterChange.ProtoReflect.Descriptor instead.
+func (*RosterChange) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RosterChange) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *RosterChange) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *RosterChange) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RosterChange) GetFromGroup() string {
+	if x != nil {
+		return x.FromGroup
+	}
+	return ""
+}
+
+func (x *RosterChange) GetToGroup() string {
+	if x != nil {
+		return x.ToGroup
+	}
+	return ""
+}
+
+type ReconcileRosterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Changes       []*RosterChange        `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	Problems      []string               `protobuf:"bytes,4,rep,name=problems,proto3" json:"problems,omitempty"` // при любой проблеме ничего не применяется
+	Applied       bool                   `protobuf:"varint,5,opt,name=applied,proto3" json:"applied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileRosterResponse) Reset() {
+	*x = ReconcileRosterResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileRosterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileRosterResponse) ProtoMessage() {}
+
+func (x *ReconcileRosterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileRosterResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileRosterResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ReconcileRosterResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ReconcileRosterResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ReconcileRosterResponse) GetChanges() []*RosterChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *ReconcileRosterResponse) GetProblems() []string {
+	if x != nil {
+		return x.Problems
+	}
+	return nil
+}
+
+func (x *ReconcileRosterResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+// MergeUsersRequest переносит работы, оценки, группы, уведомления и назначения
+// преподавателем с source на target и деактивирует source
+type MergeUsersRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SourceUserId   int32                  `protobuf:"varint,1,opt,name=source_user_id,json=sourceUserId,proto3" json:"source_user_id,omitempty"`
+	TargetUserId   int32                  `protobuf:"varint,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	ConflictPolicy MergeConflictPolicy    `protobuf:"varint,3,opt,name=conflict_policy,json=conflictPolicy,proto3,enum=superacc.MergeConflictPolicy" json:"conflict_policy,omitempty"`
+	DryRun         bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MergeUsersRequest) Reset() {
+	*x = MergeUsersRequest{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeUsersRequest) ProtoMessage() {}
+
+func (x *MergeUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeUsersRequest.ProtoReflect.Descriptor instead.
+func (*MergeUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *MergeUsersRequest) GetSourceUserId() int32 {
+	if x != nil {
+		return x.SourceUserId
+	}
+	return 0
+}
+
+func (x *MergeUsersRequest) GetTargetUserId() int32 {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return 0
+}
+
+func (x *MergeUsersRequest) GetConflictPolicy() MergeConflictPolicy {
+	if x != nil {
+		return x.ConflictPolicy
+	}
+	return MergeConflictPolicy_MERGE_CONFLICT_POLICY_UNSPECIFIED
+}
+
+func (x *MergeUsersRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type MergeConflict struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TaskId           int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	TaskTitle        string                 `protobuf:"bytes,2,opt,name=task_title,json=taskTitle,proto3" json:"task_title,omitempty"`
+	KeptWorkIds      []int32                `protobuf:"varint,3,rep,packed,name=kept_work_ids,json=keptWorkIds,proto3" json:"kept_work_ids,omitempty"` // пусто, пока политика не выбрана
+	DiscardedWorkIds []int32                `protobuf:"varint,4,rep,packed,name=discarded_work_ids,json=discardedWorkIds,proto3" json:"discarded_work_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MergeConflict) Reset() {
+	*x = MergeConflict{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeConflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeConflict) ProtoMessage() {}
+
+func (x *MergeConflict) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeConflict.ProtoReflect.Descriptor instead.
+func (*MergeConflict) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *MergeConflict) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *MergeConflict) GetTaskTitle() string {
+	if x != nil {
+		return x.TaskTitle
+	}
+	return ""
+}
+
+func (x *MergeConflict) GetKeptWorkIds() []int32 {
+	if x != nil {
+		return x.KeptWorkIds
+	}
+	return nil
+}
+
+func (x *MergeConflict) GetDiscardedWorkIds() []int32 {
+	if x != nil {
+		return x.DiscardedWorkIds
+	}
+	return nil
+}
+
+type MergeUsersResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Success               bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message               string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Conflicts             []*MergeConflict       `protobuf:"bytes,3,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	WorksMoved            int32                  `protobuf:"varint,4,opt,name=works_moved,json=worksMoved,proto3" json:"works_moved,omitempty"`
+	MarksMoved            int32                  `protobuf:"varint,5,opt,name=marks_moved,json=marksMoved,proto3" json:"marks_moved,omitempty"`
+	WorksDiscarded        int32                  `protobuf:"varint,6,opt,name=works_discarded,json=worksDiscarded,proto3" json:"works_discarded,omitempty"`
+	GroupsMoved           int32                  `protobuf:"varint,7,opt,name=groups_moved,json=groupsMoved,proto3" json:"groups_moved,omitempty"`
+	NotificationsMoved    int32                  `protobuf:"varint,8,opt,name=notifications_moved,json=notificationsMoved,proto3" json:"notifications_moved,omitempty"`
+	StaffAssignmentsMoved int32                  `protobuf:"varint,9,opt,name=staff_assignments_moved,json=staffAssignmentsMoved,proto3" json:"staff_assignments_moved,omitempty"` // группы, дисциплины, задания и проверка работ
+	Applied               bool                   `protobuf:"varint,10,opt,name=applied,proto3" json:"applied,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *MergeUsersResponse) Reset() {
+	*x = MergeUsersResponse{}
+	mi := &file_proto_superacc_superacc_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeUsersResponse) ProtoMessage() {}
+
+func (x *MergeUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_superacc_superacc_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeUsersResponse.ProtoReflect.Descriptor instead.
+func (*MergeUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_superacc_superacc_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *MergeUsersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *MergeUsersResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MergeUsersResponse) GetConflicts() []*MergeConflict {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+func (x *MergeUsersResponse) GetWorksMoved() int32 {
+	if x != nil {
+		return x.WorksMoved
+	}
+	return 0
+}
+
+func (x *MergeUsersResponse) GetMarksMoved() int32 {
+	if x != nil {
+		return x.MarksMoved
+	}
+	return 0
+}
+
+func (x *MergeUsersResponse) GetWorksDiscarded() int32 {
+	if x != nil {
+		return x.WorksDiscarded
+	}
+	return 0
+}
+
+func (x *MergeUsersResponse) GetGroupsMoved() int32 {
+	if x != nil {
+		return x.GroupsMoved
+	}
+	return 0
+}
+
+func (x *MergeUsersResponse) GetNotificationsMoved() int32 {
+	if x != nil {
+		return x.NotificationsMoved
+	}
+	return 0
+}
+
+func (x *MergeUsersResponse) GetStaffAssignmentsMoved() int32 {
+	if x != nil {
+		return x.StaffAssignmentsMoved
+	}
+	return 0
+}
+
+func (x *MergeUsersResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+var File_proto_superacc_superacc_proto protoreflect.FileDescriptor
+
+const file_proto_superacc_superacc_proto_rawDesc = "" +
+	"\n" +
+	"\x1dproto/superacc/superacc.proto\x12\bsuperacc\"Z\n" +
+	"\x11UpdateRoleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12&\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x12.superacc.UserRoleR\x04roleJ\x04\b\x02\x10\x03\"H\n" +
+	"\x12UpdateRoleResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"\x8e\x01\n" +
+	"\x12ManageGroupRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\x05R\agroupId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12&\n" +
+	"\x04role\x18\x05 \x01(\x0e2\x12.superacc.UserRoleR\x04roleJ\x04\b\x04\x10\x05\"I\n" +
+	"\x13ManageGroupResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"\xe6\x01\n" +
+	"\x17ManageDisciplineRequest\x12#\n" +
+	"\rdiscipline_id\x18\x01 \x01(\x05R\fdisciplineId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\x05R\agroupId\x12#\n" +
+	"\rseminarist_id\x18\x03 \x01(\x05R\fseminaristId\x12!\n" +
+	"\fassistant_id\x18\x04 \x01(\x05R\vassistantId\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x17\n" +
+	"\auser_id\x18\x06 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04role\x18\a \x01(\tR\x04role\"N\n" +
+	"\x18ManageDisciplineResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"o\n" +
+	"\x05Group\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12 \n" +
+	"\vdisciplines\x18\x04 \x03(\tR\vdisciplines\"\x13\n" +
+	"\x11ListGroupsRequest\"q\n" +
+	"\x12ListGroupsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12'\n" +
+	"\x06groups\x18\x03 \x03(\v2\x0f.superacc.GroupR\x06groups\"\x83\x01\n" +
+	"\x18ManageGroupEntityRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\x05R\agroupId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\"j\n" +
+	"\x19ManageGroupEntityResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\x05R\agroupId\"\xec\x01\n" +
+	"\x13ListAllUsersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x12\x19\n" +
+	"\bgroup_id\x18\x04 \x01(\x05R\agroupId\x12#\n" +
+	"\rdiscipline_id\x18\x05 \x01(\x05R\fdisciplineId\x12\x16\n" +
+	"\x06search\x18\x06 \x01(\tR\x06search\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\b \x01(\bR\n" +
+	"descending\"\x84\x01\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x10\n" +
+	"\x03fio\x18\x02 \x01(\tR\x03fio\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x14\n" +
+	"\x05group\x18\x04 \x01(\tR\x05group\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x16\n" +
+	"\x06active\x18\x06 \x01(\bR\x06active\"\xb2\x01\n" +
+	"\x14ListAllUsersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12$\n" +
+	"\x05users\x18\x03 \x03(\v2\x0e.superacc.UserR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vtotal_count\x18\x05 \x01(\x05R\n" +
+	"totalCount\"\xb9\x01\n" +
+	"\x17ListUsersByGroupRequest\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x01 \x01(\tR\tgroupName\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x16\n" +
+	"\x06search\x18\x04 \x01(\tR\x06search\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x06 \x01(\bR\n" +
+	"descending\"\xb6\x01\n" +
+	"\x18ListUsersByGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12$\n" +
+	"\x05users\x18\x03 \x03(\v2\x0e.superacc.UserR\x05users\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vtotal_count\x18\x05 \x01(\x05R\n" +
+	"totalCount\")\n" +
+	"\x11RemoveUserRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"H\n" +
+	"\x12RemoveUserResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"\x80\x01\n" +
+	"\x0eAddUserRequest\x12\x10\n" +
+	"\x03fio\x18\x01 \x01(\tR\x03fio\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05group\x18\x03 \x01(\tR\x05group\x12*\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x12.superacc.UserRoleR\x06statusJ\x04\b\x04\x10\x05\"^\n" +
+	"\x0fAddUserResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\"I\n" +
+	"\n" +
+	"Discipline\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
+	"\aterm_id\x18\x03 \x01(\x05R\x06termId\"1\n" +
+	"\x16ListDisciplinesRequest\x12\x17\n" +
+	"\aterm_id\x18\x01 \x01(\x05R\x06termId\"\x85\x01\n" +
+	"\x17ListDisciplinesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x126\n" +
+	"\vdisciplines\x18\x03 \x03(\v2\x14.superacc.DisciplineR\vdisciplines\"\x8b\x02\n" +
+	"\x1dManageDisciplineEntityRequest\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\x05R\agroupId\x12%\n" +
+	"\x0ediscipline_ids\x18\x03 \x03(\x05R\rdisciplineIds\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12#\n" +
+	"\rseminarist_id\x18\x05 \x01(\x05R\fseminaristId\x12!\n" +
+	"\fassistant_id\x18\x06 \x01(\x05R\vassistantId\x12\x1b\n" +
+	"\tlector_id\x18\a \x01(\x05R\blectorId\x12\x17\n" +
+	"\aterm_id\x18\b \x01(\x05R\x06termId\"y\n" +
+	"\x1eManageDisciplineEntityResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12#\n" +
+	"\rdiscipline_id\x18\x03 \x01(\x05R\fdisciplineId\"@\n" +
+	"\x17DeleteDisciplineRequest\x12%\n" +
 	"\x0ediscipline_ids\x18\x01 \x03(\x05R\rdisciplineIds\"N\n" +
 	"\x18DeleteDisciplineResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
@@ -1887,8 +5700,279 @@ const file_proto_superacc_superacc_proto_rawDesc = "" +
 	"\x0ediscipline_ids\x18\x02 \x03(\x05R\rdisciplineIds\"X\n" +
 	"\"DetachDisciplinesFromGroupResponse\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess2\xcf\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\",\n" +
+	"\x11UnlockUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"H\n" +
+	"\x12UnlockUserResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"\xc7\x01\n" +
+	"\n" +
+	"Invitation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x10\n" +
+	"\x03fio\x18\x03 \x01(\tR\x03fio\x12\x14\n" +
+	"\x05email\x18\x04 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\tR\texpiresAt\";\n" +
+	"\x16ListInvitationsRequest\x12!\n" +
+	"\fpending_only\x18\x01 \x01(\bR\vpendingOnly\"\x85\x01\n" +
+	"\x17ListInvitationsResponse\x126\n" +
+	"\vinvitations\x18\x01 \x03(\v2\x14.superacc.InvitationR\vinvitations\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\"2\n" +
+	"\x17ResendInvitationRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"N\n" +
+	"\x18ResendInvitationResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\">\n" +
+	"\x17RevokeInvitationRequest\x12#\n" +
+	"\rinvitation_id\x18\x01 \x01(\x05R\finvitationId\"N\n" +
+	"\x18RevokeInvitationResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"0\n" +
+	"\x15DeactivateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"L\n" +
+	"\x16DeactivateUserResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"0\n" +
+	"\x15ReactivateUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\"L\n" +
+	"\x16ReactivateUserResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"h\n" +
+	"\x10PurgeUserRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12#\n" +
+	"\rconfirm_email\x18\x03 \x01(\tR\fconfirmEmail\"G\n" +
+	"\x11PurgeUserResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"E\n" +
+	"\x12ImpersonateRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xe8\x01\n" +
+	"\x13ImpersonateResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\x12)\n" +
+	"\x10impersonation_id\x18\x03 \x01(\x05R\x0fimpersonationId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12\x10\n" +
+	"\x03fio\x18\x06 \x01(\tR\x03fio\x12\x18\n" +
+	"\amessage\x18\a \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\b \x01(\bR\asuccess\"D\n" +
+	"\x17EndImpersonationRequest\x12)\n" +
+	"\x10impersonation_id\x18\x01 \x01(\x05R\x0fimpersonationId\"N\n" +
+	"\x18EndImpersonationResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"-\n" +
+	"\x12ImportUsersOptions\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"x\n" +
+	"\x12ImportUsersRequest\x128\n" +
+	"\aoptions\x18\x01 \x01(\v2\x1c.superacc.ImportUsersOptionsH\x00R\aoptions\x12\x1d\n" +
+	"\tcsv_chunk\x18\x02 \x01(\fH\x00R\bcsvChunkB\t\n" +
+	"\apayload\"\x87\x01\n" +
+	"\x10ImportUserResult\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\x05R\x06userId\"\xea\x01\n" +
+	"\x13ImportUsersResponse\x124\n" +
+	"\aresults\x18\x01 \x03(\v2\x1a.superacc.ImportUserResultR\aresults\x12\x18\n" +
+	"\acreated\x18\x02 \x01(\x05R\acreated\x12\x18\n" +
+	"\askipped\x18\x03 \x01(\x05R\askipped\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\x12\x1c\n" +
+	"\tcommitted\x18\x05 \x01(\bR\tcommitted\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\a \x01(\bR\asuccess\"0\n" +
+	"\x16ExportDirectoryRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\"*\n" +
+	"\x14ExportDirectoryChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x9d\x01\n" +
+	"\x16ImportDirectoryOptions\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12'\n" +
+	"\x0fupdate_existing\x18\x03 \x01(\bR\x0eupdateExisting\x12)\n" +
+	"\x10send_invitations\x18\x04 \x01(\bR\x0fsendInvitations\"y\n" +
+	"\x16ImportDirectoryRequest\x12<\n" +
+	"\aoptions\x18\x01 \x01(\v2 .superacc.ImportDirectoryOptionsH\x00R\aoptions\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xf7\x04\n" +
+	"\x17ImportDirectoryResponse\x12#\n" +
+	"\rusers_created\x18\x01 \x01(\x05R\fusersCreated\x12#\n" +
+	"\rusers_updated\x18\x02 \x01(\x05R\fusersUpdated\x12%\n" +
+	"\x0egroups_created\x18\x03 \x01(\x05R\rgroupsCreated\x12%\n" +
+	"\x0egroups_updated\x18\x04 \x01(\x05R\rgroupsUpdated\x12/\n" +
+	"\x13memberships_created\x18\x05 \x01(\x05R\x12membershipsCreated\x12/\n" +
+	"\x13disciplines_created\x18\x06 \x01(\x05R\x12disciplinesCreated\x12/\n" +
+	"\x13disciplines_updated\x18\a \x01(\x05R\x12disciplinesUpdated\x12/\n" +
+	"\x13assignments_created\x18\b \x01(\x05R\x12assignmentsCreated\x12/\n" +
+	"\x13assignments_updated\x18\t \x01(\x05R\x12assignmentsUpdated\x12\x1a\n" +
+	"\bproblems\x18\n" +
+	" \x03(\tR\bproblems\x12\x17\n" +
+	"\adry_run\x18\v \x01(\bR\x06dryRun\x12\x1c\n" +
+	"\tcommitted\x18\f \x01(\bR\tcommitted\x12\x18\n" +
+	"\amessage\x18\r \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x0e \x01(\bR\asuccess\x12#\n" +
+	"\rterms_created\x18\x0f \x01(\x05R\ftermsCreated\x12#\n" +
+	"\rterms_updated\x18\x10 \x01(\x05R\ftermsUpdated\"v\n" +
+	"\x04Term\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tstarts_on\x18\x03 \x01(\tR\bstartsOn\x12\x17\n" +
+	"\aends_on\x18\x04 \x01(\tR\x06endsOn\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\"]\n" +
+	"\x11CreateTermRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tstarts_on\x18\x02 \x01(\tR\bstartsOn\x12\x17\n" +
+	"\aends_on\x18\x03 \x01(\tR\x06endsOn\"a\n" +
+	"\x12CreateTermResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x17\n" +
+	"\aterm_id\x18\x03 \x01(\x05R\x06termId\"\x12\n" +
+	"\x10ListTermsRequest\"m\n" +
+	"\x11ListTermsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12$\n" +
+	"\x05terms\x18\x03 \x03(\v2\x0e.superacc.TermR\x05terms\"D\n" +
+	"\x13SetTermStateRequest\x12\x17\n" +
+	"\aterm_id\x18\x01 \x01(\x05R\x06termId\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"J\n" +
+	"\x14SetTermStateResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"\xcb\x01\n" +
+	"\vStaffMember\x12#\n" +
+	"\rdiscipline_id\x18\x01 \x01(\x05R\fdisciplineId\x12'\n" +
+	"\x0fdiscipline_name\x18\x02 \x01(\tR\x0edisciplineName\x12\x19\n" +
+	"\bgroup_id\x18\x03 \x01(\x05R\agroupId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x05R\x06userId\x12\x10\n" +
+	"\x03fio\x18\x05 \x01(\tR\x03fio\x12\x14\n" +
+	"\x05email\x18\x06 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\a \x01(\tR\x04role\"\\\n" +
+	"\x1aListDisciplineStaffRequest\x12\x19\n" +
+	"\bgroup_id\x18\x01 \x01(\x05R\agroupId\x12#\n" +
+	"\rdiscipline_id\x18\x02 \x01(\x05R\fdisciplineId\"~\n" +
+	"\x1bListDisciplineStaffResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12+\n" +
+	"\x05staff\x18\x03 \x03(\v2\x15.superacc.StaffMemberR\x05staff\"\x8b\x01\n" +
+	"\x1fManageDisciplineLecturerRequest\x12#\n" +
+	"\rdiscipline_id\x18\x01 \x01(\x05R\fdisciplineId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"V\n" +
+	" ManageDisciplineLecturerResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"i\n" +
+	"\x12DisciplineLecturer\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x10\n" +
+	"\x03fio\x18\x02 \x01(\tR\x03fio\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x12\n" +
+	"\x04role\x18\x04 \x01(\tR\x04role\"E\n" +
+	"\x1eListDisciplineLecturersRequest\x12#\n" +
+	"\rdiscipline_id\x18\x01 \x01(\x05R\fdisciplineId\"\x91\x01\n" +
+	"\x1fListDisciplineLecturersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
+	"\tlecturers\x18\x03 \x03(\v2\x1c.superacc.DisciplineLecturerR\tlecturers\"\xe4\x01\n" +
+	"\x14QueryAuditLogRequest\x12\x19\n" +
+	"\bactor_id\x18\x01 \x01(\x05R\aactorId\x12\x16\n" +
+	"\x06method\x18\x02 \x01(\tR\x06method\x12\x16\n" +
+	"\x06entity\x18\x03 \x01(\tR\x06entity\x12\x1b\n" +
+	"\ttarget_id\x18\x04 \x01(\tR\btargetId\x12\x14\n" +
+	"\x05since\x18\x05 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x06 \x01(\tR\x05until\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1b\n" +
+	"\tbefore_id\x18\b \x01(\x03R\bbeforeId\"\x8d\x03\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\x05R\aactorId\x12\x1f\n" +
+	"\vactor_email\x18\x03 \x01(\tR\n" +
+	"actorEmail\x12\x1d\n" +
+	"\n" +
+	"actor_role\x18\x04 \x01(\tR\tactorRole\x12'\n" +
+	"\x0fimpersonator_id\x18\x05 \x01(\x05R\x0eimpersonatorId\x12\x16\n" +
+	"\x06method\x18\x06 \x01(\tR\x06method\x12\x16\n" +
+	"\x06entity\x18\a \x01(\tR\x06entity\x12\x1d\n" +
+	"\n" +
+	"target_ids\x18\b \x03(\tR\ttargetIds\x12\x1f\n" +
+	"\vbefore_json\x18\t \x01(\tR\n" +
+	"beforeJson\x12\x1d\n" +
+	"\n" +
+	"after_json\x18\n" +
+	" \x01(\tR\tafterJson\x12\x1c\n" +
+	"\tsucceeded\x18\v \x01(\bR\tsucceeded\x12\x1f\n" +
+	"\vstatus_code\x18\f \x01(\tR\n" +
+	"statusCode\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\r \x01(\tR\tcreatedAt\"\xa1\x01\n" +
+	"\x15QueryAuditLogResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12.\n" +
+	"\aentries\x18\x03 \x03(\v2\x14.superacc.AuditEntryR\aentries\x12$\n" +
+	"\x0enext_before_id\x18\x04 \x01(\x03R\fnextBeforeId\"D\n" +
+	"\vRosterGroup\x12\x1d\n" +
+	"\n" +
+	"group_name\x18\x01 \x01(\tR\tgroupName\x12\x16\n" +
+	"\x06emails\x18\x02 \x03(\tR\x06emails\"`\n" +
+	"\x16ReconcileRosterRequest\x12-\n" +
+	"\x06groups\x18\x01 \x03(\v2\x15.superacc.RosterGroupR\x06groups\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\x8f\x01\n" +
+	"\fRosterChange\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1d\n" +
 	"\n" +
+	"from_group\x18\x04 \x01(\tR\tfromGroup\x12\x19\n" +
+	"\bto_group\x18\x05 \x01(\tR\atoGroup\"\xb5\x01\n" +
+	"\x17ReconcileRosterResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x120\n" +
+	"\achanges\x18\x03 \x03(\v2\x16.superacc.RosterChangeR\achanges\x12\x1a\n" +
+	"\bproblems\x18\x04 \x03(\tR\bproblems\x12\x18\n" +
+	"\aapplied\x18\x05 \x01(\bR\aapplied\"\xc0\x01\n" +
+	"\x11MergeUsersRequest\x12$\n" +
+	"\x0esource_user_id\x18\x01 \x01(\x05R\fsourceUserId\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\x05R\ftargetUserId\x12F\n" +
+	"\x0fconflict_policy\x18\x03 \x01(\x0e2\x1d.superacc.MergeConflictPolicyR\x0econflictPolicy\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"\x99\x01\n" +
+	"\rMergeConflict\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\x12\x1d\n" +
+	"\n" +
+	"task_title\x18\x02 \x01(\tR\ttaskTitle\x12\"\n" +
+	"\rkept_work_ids\x18\x03 \x03(\x05R\vkeptWorkIds\x12,\n" +
+	"\x12discarded_work_ids\x18\x04 \x03(\x05R\x10discardedWorkIds\"\x90\x03\n" +
+	"\x12MergeUsersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x125\n" +
+	"\tconflicts\x18\x03 \x03(\v2\x17.superacc.MergeConflictR\tconflicts\x12\x1f\n" +
+	"\vworks_moved\x18\x04 \x01(\x05R\n" +
+	"worksMoved\x12\x1f\n" +
+	"\vmarks_moved\x18\x05 \x01(\x05R\n" +
+	"marksMoved\x12'\n" +
+	"\x0fworks_discarded\x18\x06 \x01(\x05R\x0eworksDiscarded\x12!\n" +
+	"\fgroups_moved\x18\a \x01(\x05R\vgroupsMoved\x12/\n" +
+	"\x13notifications_moved\x18\b \x01(\x05R\x12notificationsMoved\x126\n" +
+	"\x17staff_assignments_moved\x18\t \x01(\x05R\x15staffAssignmentsMoved\x12\x18\n" +
+	"\aapplied\x18\n" +
+	" \x01(\bR\aapplied*\xa3\x01\n" +
+	"\bUserRole\x12\x19\n" +
+	"\x15USER_ROLE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11USER_ROLE_STUDENT\x10\x01\x12\x17\n" +
+	"\x13USER_ROLE_ASSISTANT\x10\x02\x12\x18\n" +
+	"\x14USER_ROLE_SEMINARIST\x10\x03\x12\x16\n" +
+	"\x12USER_ROLE_LECTURER\x10\x04\x12\x1a\n" +
+	"\x16USER_ROLE_SUPERACCOUNT\x10\x05*\xb1\x01\n" +
+	"\x13MergeConflictPolicy\x12%\n" +
+	"!MERGE_CONFLICT_POLICY_UNSPECIFIED\x10\x00\x12%\n" +
+	"!MERGE_CONFLICT_POLICY_KEEP_TARGET\x10\x01\x12%\n" +
+	"!MERGE_CONFLICT_POLICY_KEEP_SOURCE\x10\x02\x12%\n" +
+	"!MERGE_CONFLICT_POLICY_KEEP_LATEST\x10\x032\xfe\x18\n" +
 	"\x0fSuperAccService\x12M\n" +
 	"\x0eUpdateUserRole\x12\x1b.superacc.UpdateRoleRequest\x1a\x1c.superacc.UpdateRoleResponse\"\x00\x12L\n" +
 	"\vManageGroup\x12\x1c.superacc.ManageGroupRequest\x1a\x1d.superacc.ManageGroupResponse\"\x00\x12[\n" +
@@ -1906,7 +5990,31 @@ const file_proto_superacc_superacc_proto_rawDesc = "" +
 	"\x10CreateDiscipline\x12'.superacc.ManageDisciplineEntityRequest\x1a(.superacc.ManageDisciplineEntityResponse\"\x00\x12[\n" +
 	"\x10DeleteDiscipline\x12!.superacc.DeleteDisciplineRequest\x1a\".superacc.DeleteDisciplineResponse\"\x00\x12R\n" +
 	"\rGetGroupStaff\x12\x1e.superacc.GetGroupStaffRequest\x1a\x1f.superacc.GetGroupStaffResponse\"\x00\x12y\n" +
-	"\x1aDetachDisciplinesFromGroup\x12+.superacc.DetachDisciplinesFromGroupRequest\x1a,.superacc.DetachDisciplinesFromGroupResponse\"\x00B\x1bZ\x19./proto/superacc;superaccb\x06proto3"
+	"\x1aDetachDisciplinesFromGroup\x12+.superacc.DetachDisciplinesFromGroupRequest\x1a,.superacc.DetachDisciplinesFromGroupResponse\"\x00\x12I\n" +
+	"\n" +
+	"UnlockUser\x12\x1b.superacc.UnlockUserRequest\x1a\x1c.superacc.UnlockUserResponse\"\x00\x12X\n" +
+	"\x0fListInvitations\x12 .superacc.ListInvitationsRequest\x1a!.superacc.ListInvitationsResponse\"\x00\x12[\n" +
+	"\x10ResendInvitation\x12!.superacc.ResendInvitationRequest\x1a\".superacc.ResendInvitationResponse\"\x00\x12[\n" +
+	"\x10RevokeInvitation\x12!.superacc.RevokeInvitationRequest\x1a\".superacc.RevokeInvitationResponse\"\x00\x12U\n" +
+	"\x0eDeactivateUser\x12\x1f.superacc.DeactivateUserRequest\x1a .superacc.DeactivateUserResponse\"\x00\x12U\n" +
+	"\x0eReactivateUser\x12\x1f.superacc.ReactivateUserRequest\x1a .superacc.ReactivateUserResponse\"\x00\x12F\n" +
+	"\tPurgeUser\x12\x1a.superacc.PurgeUserRequest\x1a\x1b.superacc.PurgeUserResponse\"\x00\x12L\n" +
+	"\vImpersonate\x12\x1c.superacc.ImpersonateRequest\x1a\x1d.superacc.ImpersonateResponse\"\x00\x12[\n" +
+	"\x10EndImpersonation\x12!.superacc.EndImpersonationRequest\x1a\".superacc.EndImpersonationResponse\"\x00\x12N\n" +
+	"\vImportUsers\x12\x1c.superacc.ImportUsersRequest\x1a\x1d.superacc.ImportUsersResponse\"\x00(\x01\x12W\n" +
+	"\x0fExportDirectory\x12 .superacc.ExportDirectoryRequest\x1a\x1e.superacc.ExportDirectoryChunk\"\x000\x01\x12Z\n" +
+	"\x0fImportDirectory\x12 .superacc.ImportDirectoryRequest\x1a!.superacc.ImportDirectoryResponse\"\x00(\x01\x12I\n" +
+	"\n" +
+	"CreateTerm\x12\x1b.superacc.CreateTermRequest\x1a\x1c.superacc.CreateTermResponse\"\x00\x12F\n" +
+	"\tListTerms\x12\x1a.superacc.ListTermsRequest\x1a\x1b.superacc.ListTermsResponse\"\x00\x12O\n" +
+	"\fSetTermState\x12\x1d.superacc.SetTermStateRequest\x1a\x1e.superacc.SetTermStateResponse\"\x00\x12d\n" +
+	"\x13ListDisciplineStaff\x12$.superacc.ListDisciplineStaffRequest\x1a%.superacc.ListDisciplineStaffResponse\"\x00\x12s\n" +
+	"\x18ManageDisciplineLecturer\x12).superacc.ManageDisciplineLecturerRequest\x1a*.superacc.ManageDisciplineLecturerResponse\"\x00\x12p\n" +
+	"\x17ListDisciplineLecturers\x12(.superacc.ListDisciplineLecturersRequest\x1a).superacc.ListDisciplineLecturersResponse\"\x00\x12R\n" +
+	"\rQueryAuditLog\x12\x1e.superacc.QueryAuditLogRequest\x1a\x1f.superacc.QueryAuditLogResponse\"\x00\x12X\n" +
+	"\x0fReconcileRoster\x12 .superacc.ReconcileRosterRequest\x1a!.superacc.ReconcileRosterResponse\"\x00\x12I\n" +
+	"\n" +
+	"MergeUsers\x12\x1b.superacc.MergeUsersRequest\x1a\x1c.superacc.MergeUsersResponse\"\x00B\x1bZ\x19./proto/superacc;superaccb\x06proto3"
 
 var (
 	file_proto_superacc_superacc_proto_rawDescOnce sync.Once
@@ -1920,80 +6028,193 @@ func file_proto_superacc_superacc_proto_rawDescGZIP() []byte {
 	return file_proto_superacc_superacc_proto_rawDescData
 }
 
-var file_proto_superacc_superacc_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_proto_superacc_superacc_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_superacc_superacc_proto_msgTypes = make([]protoimpl.MessageInfo, 84)
 var file_proto_superacc_superacc_proto_goTypes = []any{
-	(*UpdateRoleRequest)(nil),                  // 0: superacc.UpdateRoleRequest
-	(*UpdateRoleResponse)(nil),                 // 1: superacc.UpdateRoleResponse
-	(*ManageGroupRequest)(nil),                 // 2: superacc.ManageGroupRequest
-	(*ManageGroupResponse)(nil),                // 3: superacc.ManageGroupResponse
-	(*ManageDisciplineRequest)(nil),            // 4: superacc.ManageDisciplineRequest
-	(*ManageDisciplineResponse)(nil),           // 5: superacc.ManageDisciplineResponse
-	(*Group)(nil),                              // 6: superacc.Group
-	(*ListGroupsRequest)(nil),                  // 7: superacc.ListGroupsRequest
-	(*ListGroupsResponse)(nil),                 // 8: superacc.ListGroupsResponse
-	(*ManageGroupEntityRequest)(nil),           // 9: superacc.ManageGroupEntityRequest
-	(*ManageGroupEntityResponse)(nil),          // 10: superacc.ManageGroupEntityResponse
-	(*ListAllUsersRequest)(nil),                // 11: superacc.ListAllUsersRequest
-	(*User)(nil),                               // 12: superacc.User
-	(*ListAllUsersResponse)(nil),               // 13: superacc.ListAllUsersResponse
-	(*ListUsersByGroupRequest)(nil),            // 14: superacc.ListUsersByGroupRequest
-	(*ListUsersByGroupResponse)(nil),           // 15: superacc.ListUsersByGroupResponse
-	(*RemoveUserRequest)(nil),                  // 16: superacc.RemoveUserRequest
-	(*RemoveUserResponse)(nil),                 // 17: superacc.RemoveUserResponse
-	(*AddUserRequest)(nil),                     // 18: superacc.AddUserRequest
-	(*AddUserResponse)(nil),                    // 19: superacc.AddUserResponse
-	(*Discipline)(nil),                         // 20: superacc.Discipline
-	(*ListDisciplinesRequest)(nil),             // 21: superacc.ListDisciplinesRequest
-	(*ListDisciplinesResponse)(nil),            // 22: superacc.ListDisciplinesResponse
-	(*ManageDisciplineEntityRequest)(nil),      // 23: superacc.ManageDisciplineEntityRequest
-	(*ManageDisciplineEntityResponse)(nil),     // 24: superacc.ManageDisciplineEntityResponse
-	(*DeleteDisciplineRequest)(nil),            // 25: superacc.DeleteDisciplineRequest
-	(*DeleteDisciplineResponse)(nil),           // 26: superacc.DeleteDisciplineResponse
-	(*GetGroupStaffRequest)(nil),               // 27: superacc.GetGroupStaffRequest
-	(*GetGroupStaffResponse)(nil),              // 28: superacc.GetGroupStaffResponse
-	(*DetachDisciplinesFromGroupRequest)(nil),  // 29: superacc.DetachDisciplinesFromGroupRequest
-	(*DetachDisciplinesFromGroupResponse)(nil), // 30: superacc.DetachDisciplinesFromGroupResponse
+	(UserRole)(0),                              // 0: superacc.UserRole
+	(MergeConflictPolicy)(0),                   // 1: superacc.MergeConflictPolicy
+	(*UpdateRoleRequest)(nil),                  // 2: superacc.UpdateRoleRequest
+	(*UpdateRoleResponse)(nil),                 // 3: superacc.UpdateRoleResponse
+	(*ManageGroupRequest)(nil),                 // 4: superacc.ManageGroupRequest
+	(*ManageGroupResponse)(nil),                // 5: superacc.ManageGroupResponse
+	(*ManageDisciplineRequest)(nil),            // 6: superacc.ManageDisciplineRequest
+	(*ManageDisciplineResponse)(nil),           // 7: superacc.ManageDisciplineResponse
+	(*Group)(nil),                              // 8: superacc.Group
+	(*ListGroupsRequest)(nil),                  // 9: superacc.ListGroupsRequest
+	(*ListGroupsResponse)(nil),                 // 10: superacc.ListGroupsResponse
+	(*ManageGroupEntityRequest)(nil),           // 11: superacc.ManageGroupEntityRequest
+	(*ManageGroupEntityResponse)(nil),          // 12: superacc.ManageGroupEntityResponse
+	(*ListAllUsersRequest)(nil),                // 13: superacc.ListAllUsersRequest
+	(*User)(nil),                               // 14: superacc.User
+	(*ListAllUsersResponse)(nil),               // 15: superacc.ListAllUsersResponse
+	(*ListUsersByGroupRequest)(nil),            // 16: superacc.ListUsersByGroupRequest
+	(*ListUsersByGroupResponse)(nil),           // 17: superacc.ListUsersByGroupResponse
+	(*RemoveUserRequest)(nil),                  // 18: superacc.RemoveUserRequest
+	(*RemoveUserResponse)(nil),                 // 19: superacc.RemoveUserResponse
+	(*AddUserRequest)(nil),                     // 20: superacc.AddUserRequest
+	(*AddUserResponse)(nil),                    // 21: superacc.AddUserResponse
+	(*Discipline)(nil),                         // 22: superacc.Discipline
+	(*ListDisciplinesRequest)(nil),             // 23: superacc.ListDisciplinesRequest
+	(*ListDisciplinesResponse)(nil),            // 24: superacc.ListDisciplinesResponse
+	(*ManageDisciplineEntityRequest)(nil),      // 25: superacc.ManageDisciplineEntityRequest
+	(*ManageDisciplineEntityResponse)(nil),     // 26: superacc.ManageDisciplineEntityResponse
+	(*DeleteDisciplineRequest)(nil),            // 27: superacc.DeleteDisciplineRequest
+	(*DeleteDisciplineResponse)(nil),           // 28: superacc.DeleteDisciplineResponse
+	(*GetGroupStaffRequest)(nil),               // 29: superacc.GetGroupStaffRequest
+	(*GetGroupStaffResponse)(nil),              // 30: superacc.GetGroupStaffResponse
+	(*DetachDisciplinesFromGroupRequest)(nil),  // 31: superacc.DetachDisciplinesFromGroupRequest
+	(*DetachDisciplinesFromGroupResponse)(nil), // 32: superacc.DetachDisciplinesFromGroupResponse
+	(*UnlockUserRequest)(nil),                  // 33: superacc.UnlockUserRequest
+	(*UnlockUserResponse)(nil),                 // 34: superacc.UnlockUserResponse
+	(*Invitation)(nil),                         // 35: superacc.Invitation
+	(*ListInvitationsRequest)(nil),             // 36: superacc.ListInvitationsRequest
+	(*ListInvitationsResponse)(nil),            // 37: superacc.ListInvitationsResponse
+	(*ResendInvitationRequest)(nil),            // 38: superacc.ResendInvitationRequest
+	(*ResendInvitationResponse)(nil),           // 39: superacc.ResendInvitationResponse
+	(*RevokeInvitationRequest)(nil),            // 40: superacc.RevokeInvitationRequest
+	(*RevokeInvitationResponse)(nil),           // 41: superacc.RevokeInvitationResponse
+	(*DeactivateUserRequest)(nil),              // 42: superacc.DeactivateUserRequest
+	(*DeactivateUserResponse)(nil),             // 43: superacc.DeactivateUserResponse
+	(*ReactivateUserRequest)(nil),              // 44: superacc.ReactivateUserRequest
+	(*ReactivateUserResponse)(nil),             // 45: superacc.ReactivateUserResponse
+	(*PurgeUserRequest)(nil),                   // 46: superacc.PurgeUserRequest
+	(*PurgeUserResponse)(nil),                  // 47: superacc.PurgeUserResponse
+	(*ImpersonateRequest)(nil),                 // 48: superacc.ImpersonateRequest
+	(*ImpersonateResponse)(nil),                // 49: superacc.ImpersonateResponse
+	(*EndImpersonationRequest)(nil),            // 50: superacc.EndImpersonationRequest
+	(*EndImpersonationResponse)(nil),           // 51: superacc.EndImpersonationResponse
+	(*ImportUsersOptions)(nil),                 // 52: superacc.ImportUsersOptions
+	(*ImportUsersRequest)(nil),                 // 53: superacc.ImportUsersRequest
+	(*ImportUserResult)(nil),                   // 54: superacc.ImportUserResult
+	(*ImportUsersResponse)(nil),                // 55: superacc.ImportUsersResponse
+	(*ExportDirectoryRequest)(nil),             // 56: superacc.ExportDirectoryRequest
+	(*ExportDirectoryChunk)(nil),               // 57: superacc.ExportDirectoryChunk
+	(*ImportDirectoryOptions)(nil),             // 58: superacc.ImportDirectoryOptions
+	(*ImportDirectoryRequest)(nil),             // 59: superacc.ImportDirectoryRequest
+	(*ImportDirectoryResponse)(nil),            // 60: superacc.ImportDirectoryResponse
+	(*Term)(nil),                               // 61: superacc.Term
+	(*CreateTermRequest)(nil),                  // 62: superacc.CreateTermRequest
+	(*CreateTermResponse)(nil),                 // 63: superacc.CreateTermResponse
+	(*ListTermsRequest)(nil),                   // 64: superacc.ListTermsRequest
+	(*ListTermsResponse)(nil),                  // 65: superacc.ListTermsResponse
+	(*SetTermStateRequest)(nil),                // 66: superacc.SetTermStateRequest
+	(*SetTermStateResponse)(nil),               // 67: superacc.SetTermStateResponse
+	(*StaffMember)(nil),                        // 68: superacc.StaffMember
+	(*ListDisciplineStaffRequest)(nil),         // 69: superacc.ListDisciplineStaffRequest
+	(*ListDisciplineStaffResponse)(nil),        // 70: superacc.ListDisciplineStaffResponse
+	(*ManageDisciplineLecturerRequest)(nil),    // 71: superacc.ManageDisciplineLecturerRequest
+	(*ManageDisciplineLecturerResponse)(nil),   // 72: superacc.ManageDisciplineLecturerResponse
+	(*DisciplineLecturer)(nil),                 // 73: superacc.DisciplineLecturer
+	(*ListDisciplineLecturersRequest)(nil),     // 74: superacc.ListDisciplineLecturersRequest
+	(*ListDisciplineLecturersResponse)(nil),    // 75: superacc.ListDisciplineLecturersResponse
+	(*QueryAuditLogRequest)(nil),               // 76: superacc.QueryAuditLogRequest
+	(*AuditEntry)(nil),                         // 77: superacc.AuditEntry
+	(*QueryAuditLogResponse)(nil),              // 78: superacc.QueryAuditLogResponse
+	(*RosterGroup)(nil),                        // 79: superacc.RosterGroup
+	(*ReconcileRosterRequest)(nil),             // 80: superacc.ReconcileRosterRequest
+	(*RosterChange)(nil),                       // 81: superacc.RosterChange
+	(*ReconcileRosterResponse)(nil),            // 82: superacc.ReconcileRosterResponse
+	(*MergeUsersRequest)(nil),                  // 83: superacc.MergeUsersRequest
+	(*MergeConflict)(nil),                      // 84: superacc.MergeConflict
+	(*MergeUsersResponse)(nil),                 // 85: superacc.MergeUsersResponse
 }
 var file_proto_superacc_superacc_proto_depIdxs = []int32{
-	6,  // 0: superacc.ListGroupsResponse.groups:type_name -> superacc.Group
-	12, // 1: superacc.ListAllUsersResponse.users:type_name -> superacc.User
-	12, // 2: superacc.ListUsersByGroupResponse.users:type_name -> superacc.User
-	20, // 3: superacc.ListDisciplinesResponse.disciplines:type_name -> superacc.Discipline
-	0,  // 4: superacc.SuperAccService.UpdateUserRole:input_type -> superacc.UpdateRoleRequest
-	2,  // 5: superacc.SuperAccService.ManageGroup:input_type -> superacc.ManageGroupRequest
-	4,  // 6: superacc.SuperAccService.ManageDiscipline:input_type -> superacc.ManageDisciplineRequest
-	7,  // 7: superacc.SuperAccService.ListGroups:input_type -> superacc.ListGroupsRequest
-	9,  // 8: superacc.SuperAccService.ManageGroupEntity:input_type -> superacc.ManageGroupEntityRequest
-	11, // 9: superacc.SuperAccService.ListAllUsers:input_type -> superacc.ListAllUsersRequest
-	14, // 10: superacc.SuperAccService.ListUsersByGroup:input_type -> superacc.ListUsersByGroupRequest
-	16, // 11: superacc.SuperAccService.RemoveUser:input_type -> superacc.RemoveUserRequest
-	18, // 12: superacc.SuperAccService.AddUser:input_type -> superacc.AddUserRequest
-	23, // 13: superacc.SuperAccService.ManageDisciplineEntity:input_type -> superacc.ManageDisciplineEntityRequest
-	21, // 14: superacc.SuperAccService.ListDisciplines:input_type -> superacc.ListDisciplinesRequest
-	23, // 15: superacc.SuperAccService.CreateDiscipline:input_type -> superacc.ManageDisciplineEntityRequest
-	25, // 16: superacc.SuperAccService.DeleteDiscipline:input_type -> superacc.DeleteDisciplineRequest
-	27, // 17: superacc.SuperAccService.GetGroupStaff:input_type -> superacc.GetGroupStaffRequest
-	29, // 18: superacc.SuperAccService.DetachDisciplinesFromGroup:input_type -> superacc.DetachDisciplinesFromGroupRequest
-	1,  // 19: superacc.SuperAccService.UpdateUserRole:output_type -> superacc.UpdateRoleResponse
-	3,  // 20: superacc.SuperAccService.ManageGroup:output_type -> superacc.ManageGroupResponse
-	5,  // 21: superacc.SuperAccService.ManageDiscipline:output_type -> superacc.ManageDisciplineResponse
-	8,  // 22: superacc.SuperAccService.ListGroups:output_type -> superacc.ListGroupsResponse
-	10, // 23: superacc.SuperAccService.ManageGroupEntity:output_type -> superacc.ManageGroupEntityResponse
-	13, // 24: superacc.SuperAccService.ListAllUsers:output_type -> superacc.ListAllUsersResponse
-	15, // 25: superacc.SuperAccService.ListUsersByGroup:output_type -> superacc.ListUsersByGroupResponse
-	17, // 26: superacc.SuperAccService.RemoveUser:output_type -> superacc.RemoveUserResponse
-	19, // 27: superacc.SuperAccService.AddUser:output_type -> superacc.AddUserResponse
-	24, // 28: superacc.SuperAccService.ManageDisciplineEntity:output_type -> superacc.ManageDisciplineEntityResponse
-	22, // 29: superacc.SuperAccService.ListDisciplines:output_type -> superacc.ListDisciplinesResponse
-	24, // 30: superacc.SuperAccService.CreateDiscipline:output_type -> superacc.ManageDisciplineEntityResponse
-	26, // 31: superacc.SuperAccService.DeleteDiscipline:output_type -> superacc.DeleteDisciplineResponse
-	28, // 32: superacc.SuperAccService.GetGroupStaff:output_type -> superacc.GetGroupStaffResponse
-	30, // 33: superacc.SuperAccService.DetachDisciplinesFromGroup:output_type -> superacc.DetachDisciplinesFromGroupResponse
-	19, // [19:34] is the sub-list for method output_type
-	4,  // [4:19] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	0,  // 0: superacc.UpdateRoleRequest.role:type_name -> superacc.UserRole
+	0,  // 1: superacc.ManageGroupRequest.role:type_name -> superacc.UserRole
+	8,  // 2: superacc.ListGroupsResponse.groups:type_name -> superacc.Group
+	14, // 3: superacc.ListAllUsersResponse.users:type_name -> superacc.User
+	14, // 4: superacc.ListUsersByGroupResponse.users:type_name -> superacc.User
+	0,  // 5: superacc.AddUserRequest.status:type_name -> superacc.UserRole
+	22, // 6: superacc.ListDisciplinesResponse.disciplines:type_name -> superacc.Discipline
+	35, // 7: superacc.ListInvitationsResponse.invitations:type_name -> superacc.Invitation
+	52, // 8: superacc.ImportUsersRequest.options:type_name -> superacc.ImportUsersOptions
+	54, // 9: superacc.ImportUsersResponse.results:type_name -> superacc.ImportUserResult
+	58, // 10: superacc.ImportDirectoryRequest.options:type_name -> superacc.ImportDirectoryOptions
+	61, // 11: superacc.ListTermsResponse.terms:type_name -> superacc.Term
+	68, // 12: superacc.ListDisciplineStaffResponse.staff:type_name -> superacc.StaffMember
+	73, // 13: superacc.ListDisciplineLecturersResponse.lecturers:type_name -> superacc.DisciplineLecturer
+	77, // 14: superacc.QueryAuditLogResponse.entries:type_name -> superacc.AuditEntry
+	79, // 15: superacc.ReconcileRosterRequest.groups:type_name -> superacc.RosterGroup
+	81, // 16: superacc.ReconcileRosterResponse.changes:type_name -> superacc.RosterChange
+	1,  // 17: superacc.MergeUsersRequest.conflict_policy:type_name -> superacc.MergeConflictPolicy
+	84, // 18: superacc.MergeUsersResponse.conflicts:type_name -> superacc.MergeConflict
+	2,  // 19: superacc.SuperAccService.UpdateUserRole:input_type -> superacc.UpdateRoleRequest
+	4,  // 20: superacc.SuperAccService.ManageGroup:input_type -> superacc.ManageGroupRequest
+	6,  // 21: superacc.SuperAccService.ManageDiscipline:input_type -> superacc.ManageDisciplineRequest
+	9,  // 22: superacc.SuperAccService.ListGroups:input_type -> superacc.ListGroupsRequest
+	11, // 23: superacc.SuperAccService.ManageGroupEntity:input_type -> superacc.ManageGroupEntityRequest
+	13, // 24: superacc.SuperAccService.ListAllUsers:input_type -> superacc.ListAllUsersRequest
+	16, // 25: superacc.SuperAccService.ListUsersByGroup:input_type -> superacc.ListUsersByGroupRequest
+	18, // 26: superacc.SuperAccService.RemoveUser:input_type -> superacc.RemoveUserRequest
+	20, // 27: superacc.SuperAccService.AddUser:input_type -> superacc.AddUserRequest
+	25, // 28: superacc.SuperAccService.ManageDisciplineEntity:input_type -> superacc.ManageDisciplineEntityRequest
+	23, // 29: superacc.SuperAccService.ListDisciplines:input_type -> superacc.ListDisciplinesRequest
+	25, // 30: superacc.SuperAccService.CreateDiscipline:input_type -> superacc.ManageDisciplineEntityRequest
+	27, // 31: superacc.SuperAccService.DeleteDiscipline:input_type -> superacc.DeleteDisciplineRequest
+	29, // 32: superacc.SuperAccService.GetGroupStaff:input_type -> superacc.GetGroupStaffRequest
+	31, // 33: superacc.SuperAccService.DetachDisciplinesFromGroup:input_type -> superacc.DetachDisciplinesFromGroupRequest
+	33, // 34: superacc.SuperAccService.UnlockUser:input_type -> superacc.UnlockUserRequest
+	36, // 35: superacc.SuperAccService.ListInvitations:input_type -> superacc.ListInvitationsRequest
+	38, // 36: superacc.SuperAccService.ResendInvitation:input_type -> superacc.ResendInvitationRequest
+	40, // 37: superacc.SuperAccService.RevokeInvitation:input_type -> superacc.RevokeInvitationRequest
+	42, // 38: superacc.SuperAccService.DeactivateUser:input_type -> superacc.DeactivateUserRequest
+	44, // 39: superacc.SuperAccService.ReactivateUser:input_type -> superacc.ReactivateUserRequest
+	46, // 40: superacc.SuperAccService.PurgeUser:input_type -> superacc.PurgeUserRequest
+	48, // 41: superacc.SuperAccService.Impersonate:input_type -> superacc.ImpersonateRequest
+	50, // 42: superacc.SuperAccService.EndImpersonation:input_type -> superacc.EndImpersonationRequest
+	53, // 43: superacc.SuperAccService.ImportUsers:input_type -> superacc.ImportUsersRequest
+	56, // 44: superacc.SuperAccService.ExportDirectory:input_type -> superacc.ExportDirectoryRequest
+	59, // 45: superacc.SuperAccService.ImportDirectory:input_type -> superacc.ImportDirectoryRequest
+	62, // 46: superacc.SuperAccService.CreateTerm:input_type -> superacc.CreateTermRequest
+	64, // 47: superacc.SuperAccService.ListTerms:input_type -> superacc.ListTermsRequest
+	66, // 48: superacc.SuperAccService.SetTermState:input_type -> superacc.SetTermStateRequest
+	69, // 49: superacc.SuperAccService.ListDisciplineStaff:input_type -> superacc.ListDisciplineStaffRequest
+	71, // 50: superacc.SuperAccService.ManageDisciplineLecturer:input_type -> superacc.ManageDisciplineLecturerRequest
+	74, // 51: superacc.SuperAccService.ListDisciplineLecturers:input_type -> superacc.ListDisciplineLecturersRequest
+	76, // 52: superacc.SuperAccService.QueryAuditLog:input_type -> superacc.QueryAuditLogRequest
+	80, // 53: superacc.SuperAccService.ReconcileRoster:input_type -> superacc.ReconcileRosterRequest
+	83, // 54: superacc.SuperAccService.MergeUsers:input_type -> superacc.MergeUsersRequest
+	3,  // 55: superacc.SuperAccService.UpdateUserRole:output_type -> superacc.UpdateRoleResponse
+	5,  // 56: superacc.SuperAccService.ManageGroup:output_type -> superacc.ManageGroupResponse
+	7,  // 57: superacc.SuperAccService.ManageDiscipline:output_type -> superacc.ManageDisciplineResponse
+	10, // 58: superacc.SuperAccService.ListGroups:output_type -> superacc.ListGroupsResponse
+	12, // 59: superacc.SuperAccService.ManageGroupEntity:output_type -> superacc.ManageGroupEntityResponse
+	15, // 60: superacc.SuperAccService.ListAllUsers:output_type -> superacc.ListAllUsersResponse
+	17, // 61: superacc.SuperAccService.ListUsersByGroup:output_type -> superacc.ListUsersByGroupResponse
+	19, // 62: superacc.SuperAccService.RemoveUser:output_type -> superacc.RemoveUserResponse
+	21, // 63: superacc.SuperAccService.AddUser:output_type -> superacc.AddUserResponse
+	26, // 64: superacc.SuperAccService.ManageDisciplineEntity:output_type -> superacc.ManageDisciplineEntityResponse
+	24, // 65: superacc.SuperAccService.ListDisciplines:output_type -> superacc.ListDisciplinesResponse
+	26, // 66: superacc.SuperAccService.CreateDiscipline:output_type -> superacc.ManageDisciplineEntityResponse
+	28, // 67: superacc.SuperAccService.DeleteDiscipline:output_type -> superacc.DeleteDisciplineResponse
+	30, // 68: superacc.SuperAccService.GetGroupStaff:output_type -> superacc.GetGroupStaffResponse
+	32, // 69: superacc.SuperAccService.DetachDisciplinesFromGroup:output_type -> superacc.DetachDisciplinesFromGroupResponse
+	34, // 70: superacc.SuperAccService.UnlockUser:output_type -> superacc.UnlockUserResponse
+	37, // 71: superacc.SuperAccService.ListInvitations:output_type -> superacc.ListInvitationsResponse
+	39, // 72: superacc.SuperAccService.ResendInvitation:output_type -> superacc.ResendInvitationResponse
+	41, // 73: superacc.SuperAccService.RevokeInvitation:output_type -> superacc.RevokeInvitationResponse
+	43, // 74: superacc.SuperAccService.DeactivateUser:output_type -> superacc.DeactivateUserResponse
+	45, // 75: superacc.SuperAccService.ReactivateUser:output_type -> superacc.ReactivateUserResponse
+	47, // 76: superacc.SuperAccService.PurgeUser:output_type -> superacc.PurgeUserResponse
+	49, // 77: superacc.SuperAccService.Impersonate:output_type -> superacc.ImpersonateResponse
+	51, // 78: superacc.SuperAccService.EndImpersonation:output_type -> superacc.EndImpersonationResponse
+	55, // 79: superacc.SuperAccService.ImportUsers:output_type -> superacc.ImportUsersResponse
+	57, // 80: superacc.SuperAccService.ExportDirectory:output_type -> superacc.ExportDirectoryChunk
+	60, // 81: superacc.SuperAccService.ImportDirectory:output_type -> superacc.ImportDirectoryResponse
+	63, // 82: superacc.SuperAccService.CreateTerm:output_type -> superacc.CreateTermResponse
+	65, // 83: superacc.SuperAccService.ListTerms:output_type -> superacc.ListTermsResponse
+	67, // 84: superacc.SuperAccService.SetTermState:output_type -> superacc.SetTermStateResponse
+	70, // 85: superacc.SuperAccService.ListDisciplineStaff:output_type -> superacc.ListDisciplineStaffResponse
+	72, // 86: superacc.SuperAccService.ManageDisciplineLecturer:output_type -> superacc.ManageDisciplineLecturerResponse
+	75, // 87: superacc.SuperAccService.ListDisciplineLecturers:output_type -> superacc.ListDisciplineLecturersResponse
+	78, // 88: superacc.SuperAccService.QueryAuditLog:output_type -> superacc.QueryAuditLogResponse
+	82, // 89: superacc.SuperAccService.ReconcileRoster:output_type -> superacc.ReconcileRosterResponse
+	85, // 90: superacc.SuperAccService.MergeUsers:output_type -> superacc.MergeUsersResponse
+	55, // [55:91] is the sub-list for method output_type
+	19, // [19:55] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_proto_superacc_superacc_proto_init() }
@@ -2001,18 +6222,27 @@ func file_proto_superacc_superacc_proto_init() {
 	if File_proto_superacc_superacc_proto != nil {
 		return
 	}
+	file_proto_superacc_superacc_proto_msgTypes[51].OneofWrappers = []any{
+		(*ImportUsersRequest_Options)(nil),
+		(*ImportUsersRequest_CsvChunk)(nil),
+	}
+	file_proto_superacc_superacc_proto_msgTypes[57].OneofWrappers = []any{
+		(*ImportDirectoryRequest_Options)(nil),
+		(*ImportDirectoryRequest_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_superacc_superacc_proto_rawDesc), len(file_proto_superacc_superacc_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   31,
+			NumEnums:      2,
+			NumMessages:   84,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_superacc_superacc_proto_goTypes,
 		DependencyIndexes: file_proto_superacc_superacc_proto_depIdxs,
+		EnumInfos:         file_proto_superacc_superacc_proto_enumTypes,
 		MessageInfos:      file_proto_superacc_superacc_proto_msgTypes,
 	}.Build()
 	File_proto_superacc_superacc_proto = out.File
@@ -36,6 +36,27 @@ const (
 	SuperAccService_DeleteDiscipline_FullMethodName           = "/superacc.SuperAccService/DeleteDiscipline"
 	SuperAccService_GetGroupStaff_FullMethodName              = "/superacc.SuperAccService/GetGroupStaff"
 	SuperAccService_DetachDisciplinesFromGroup_FullMethodName = "/superacc.SuperAccService/DetachDisciplinesFromGroup"
+	SuperAccService_UnlockUser_FullMethodName                 = "/superacc.SuperAccService/UnlockUser"
+	SuperAccService_ListInvitations_FullMethodName            = "/superacc.SuperAccService/ListInvitations"
+	SuperAccService_ResendInvitation_FullMethodName           = "/superacc.SuperAccService/ResendInvitation"
+	SuperAccService_RevokeInvitation_FullMethodName           = "/superacc.SuperAccService/RevokeInvitation"
+	SuperAccService_DeactivateUser_FullMethodName             = "/superacc.SuperAccService/DeactivateUser"
+	SuperAccService_ReactivateUser_FullMethodName             = "/superacc.SuperAccService/ReactivateUser"
+	SuperAccService_PurgeUser_FullMethodName                  = "/superacc.SuperAccService/PurgeUser"
+	SuperAccService_Impersonate_FullMethodName                = "/superacc.SuperAccService/Impersonate"
+	SuperAccService_EndImpersonation_FullMethodName           = "/superacc.SuperAccService/EndImpersonation"
+	SuperAccService_ImportUsers_FullMethodName                = "/superacc.SuperAccService/ImportUsers"
+	SuperAccService_ExportDirectory_FullMethodName            = "/superacc.SuperAccService/ExportDirectory"
+	SuperAccService_ImportDirectory_FullMethodName            = "/superacc.SuperAccService/ImportDirectory"
+	SuperAccService_CreateTerm_FullMethodName                 = "/superacc.SuperAccService/CreateTerm"
+	SuperAccService_ListTerms_FullMethodName                  = "/superacc.SuperAccService/ListTerms"
+	SuperAccService_SetTermState_FullMethodName               = "/superacc.SuperAccService/SetTermState"
+	SuperAccService_ListDisciplineStaff_FullMethodName        = "/superacc.SuperAccService/ListDisciplineStaff"
+	SuperAccService_ManageDisciplineLecturer_FullMethodName   = "/superacc.SuperAccService/ManageDisciplineLecturer"
+	SuperAccService_ListDisciplineLecturers_FullMethodName    = "/superacc.SuperAccService/ListDisciplineLecturers"
+	SuperAccService_QueryAuditLog_FullMethodName              = "/superacc.SuperAccService/QueryAuditLog"
+	SuperAccService_ReconcileRoster_FullMethodName            = "/superacc.SuperAccService/ReconcileRoster"
+	SuperAccService_MergeUsers_FullMethodName                 = "/superacc.SuperAccService/MergeUsers"
 )
 
 // SuperAccServiceClient is the client API for SuperAccService service.
@@ -57,6 +78,27 @@ type SuperAccServiceClient interface {
 	DeleteDiscipline(ctx context.Context, in *DeleteDisciplineRequest, opts ...grpc.CallOption) (*DeleteDisciplineResponse, error)
 	GetGroupStaff(ctx context.Context, in *GetGroupStaffRequest, opts ...grpc.CallOption) (*GetGroupStaffResponse, error)
 	DetachDisciplinesFromGroup(ctx context.Context, in *DetachDisciplinesFromGroupRequest, opts ...grpc.CallOption) (*DetachDisciplinesFromGroupResponse, error)
+	UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error)
+	ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error)
+	ResendInvitation(ctx context.Context, in *ResendInvitationRequest, opts ...grpc.CallOption) (*ResendInvitationResponse, error)
+	RevokeInvitation(ctx context.Context, in *RevokeInvitationRequest, opts ...grpc.CallOption) (*RevokeInvitationResponse, error)
+	DeactivateUser(ctx context.Context, in *DeactivateUserRequest, opts ...grpc.CallOption) (*DeactivateUserResponse, error)
+	ReactivateUser(ctx context.Context, in *ReactivateUserRequest, opts ...grpc.CallOption) (*ReactivateUserResponse, error)
+	PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserResponse, error)
+	Impersonate(ctx context.Context, in *ImpersonateRequest, opts ...grpc.CallOption) (*ImpersonateResponse, error)
+	EndImpersonation(ctx context.Context, in *EndImpersonationRequest, opts ...grpc.CallOption) (*EndImpersonationResponse, error)
+	ImportUsers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportUsersRequest, ImportUsersResponse], error)
+	ExportDirectory(ctx context.Context, in *ExportDirectoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportDirectoryChunk], error)
+	ImportDirectory(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportDirectoryRequest, ImportDirectoryResponse], error)
+	CreateTerm(ctx context.Context, in *CreateTermRequest, opts ...grpc.CallOption) (*CreateTermResponse, error)
+	ListTerms(ctx context.Context, in *ListTermsRequest, opts ...grpc.CallOption) (*ListTermsResponse, error)
+	SetTermState(ctx context.Context, in *SetTermStateRequest, opts ...grpc.CallOption) (*SetTermStateResponse, error)
+	ListDisciplineStaff(ctx context.Context, in *ListDisciplineStaffRequest, opts ...grpc.CallOption) (*ListDisciplineStaffResponse, error)
+	ManageDisciplineLecturer(ctx context.Context, in *ManageDisciplineLecturerRequest, opts ...grpc.CallOption) (*ManageDisciplineLecturerResponse, error)
+	ListDisciplineLecturers(ctx context.Context, in *ListDisciplineLecturersRequest, opts ...grpc.CallOption) (*ListDisciplineLecturersResponse, error)
+	QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error)
+	ReconcileRoster(ctx context.Context, in *ReconcileRosterRequest, opts ...grpc.CallOption) (*ReconcileRosterResponse, error)
+	MergeUsers(ctx context.Context, in *MergeUsersRequest, opts ...grpc.CallOption) (*MergeUsersResponse, error)
 }
 
 type superAccServiceClient struct {
@@ -217,6 +259,231 @@ func (c *superAccServiceClient) DetachDisciplinesFromGroup(ctx context.Context,
 	return out, nil
 }
 
+func (c *superAccServiceClient) UnlockUser(ctx context.Context, in *UnlockUserRequest, opts ...grpc.CallOption) (*UnlockUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockUserResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_UnlockUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) ListInvitations(ctx context.Context, in *ListInvitationsRequest, opts ...grpc.CallOption) (*ListInvitationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInvitationsResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_ListInvitations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) ResendInvitation(ctx context.Context, in *ResendInvitationRequest, opts ...grpc.CallOption) (*ResendInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResendInvitationResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_ResendInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) RevokeInvitation(ctx context.Context, in *RevokeInvitationRequest, opts ...grpc.CallOption) (*RevokeInvitationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeInvitationResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_RevokeInvitation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) DeactivateUser(ctx context.Context, in *DeactivateUserRequest, opts ...grpc.CallOption) (*DeactivateUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeactivateUserResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_DeactivateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) ReactivateUser(ctx context.Context, in *ReactivateUserRequest, opts ...grpc.CallOption) (*ReactivateUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReactivateUserResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_ReactivateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) PurgeUser(ctx context.Context, in *PurgeUserRequest, opts ...grpc.CallOption) (*PurgeUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeUserResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_PurgeUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) Impersonate(ctx context.Context, in *ImpersonateRequest, opts ...grpc.CallOption) (*ImpersonateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImpersonateResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_Impersonate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) EndImpersonation(ctx context.Context, in *EndImpersonationRequest, opts ...grpc.CallOption) (*EndImpersonationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EndImpersonationResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_EndImpersonation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) ImportUsers(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportUsersRequest, ImportUsersResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SuperAccService_ServiceDesc.Streams[0], SuperAccService_ImportUsers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportUsersRequest, ImportUsersResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperAccService_ImportUsersClient = grpc.ClientStreamingClient[ImportUsersRequest, ImportUsersResponse]
+
+func (c *superAccServiceClient) ExportDirectory(ctx context.Context, in *ExportDirectoryRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportDirectoryChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SuperAccService_ServiceDesc.Streams[1], SuperAccService_ExportDirectory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportDirectoryRequest, ExportDirectoryChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperAccService_ExportDirectoryClient = grpc.ServerStreamingClient[ExportDirectoryChunk]
+
+func (c *superAccServiceClient) ImportDirectory(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportDirectoryRequest, ImportDirectoryResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SuperAccService_ServiceDesc.Streams[2], SuperAccService_ImportDirectory_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportDirectoryRequest, ImportDirectoryResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperAccService_ImportDirectoryClient = grpc.ClientStreamingClient[ImportDirectoryRequest, ImportDirectoryResponse]
+
+func (c *superAccServiceClient) CreateTerm(ctx context.Context, in *CreateTermRequest, opts ...grpc.CallOption) (*CreateTermResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTermResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_CreateTerm_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) ListTerms(ctx context.Context, in *ListTermsRequest, opts ...grpc.CallOption) (*ListTermsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTermsResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_ListTerms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) SetTermState(ctx context.Context, in *SetTermStateRequest, opts ...grpc.CallOption) (*SetTermStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetTermStateResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_SetTermState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) ListDisciplineStaff(ctx context.Context, in *ListDisciplineStaffRequest, opts ...grpc.CallOption) (*ListDisciplineStaffResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDisciplineStaffResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_ListDisciplineStaff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) ManageDisciplineLecturer(ctx context.Context, in *ManageDisciplineLecturerRequest, opts ...grpc.CallOption) (*ManageDisciplineLecturerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ManageDisciplineLecturerResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_ManageDisciplineLecturer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) ListDisciplineLecturers(ctx context.Context, in *ListDisciplineLecturersRequest, opts ...grpc.CallOption) (*ListDisciplineLecturersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDisciplineLecturersResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_ListDisciplineLecturers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) QueryAuditLog(ctx context.Context, in *QueryAuditLogRequest, opts ...grpc.CallOption) (*QueryAuditLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAuditLogResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_QueryAuditLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) ReconcileRoster(ctx context.Context, in *ReconcileRosterRequest, opts ...grpc.CallOption) (*ReconcileRosterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileRosterResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_ReconcileRoster_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *superAccServiceClient) MergeUsers(ctx context.Context, in *MergeUsersRequest, opts ...grpc.CallOption) (*MergeUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeUsersResponse)
+	err := c.cc.Invoke(ctx, SuperAccService_MergeUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SuperAccServiceServer is the server API for SuperAccService service.
 // All implementations must embed UnimplementedSuperAccServiceServer
 // for forward compatibility.
@@ -236,6 +503,27 @@ type SuperAccServiceServer interface {
 	DeleteDiscipline(context.Context, *DeleteDisciplineRequest) (*DeleteDisciplineResponse, error)
 	GetGroupStaff(context.Context, *GetGroupStaffRequest) (*GetGroupStaffResponse, error)
 	DetachDisciplinesFromGroup(context.Context, *DetachDisciplinesFromGroupRequest) (*DetachDisciplinesFromGroupResponse, error)
+	UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error)
+	ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error)
+	ResendInvitation(context.Context, *ResendInvitationRequest) (*ResendInvitationResponse, error)
+	RevokeInvitation(context.Context, *RevokeInvitationRequest) (*RevokeInvitationResponse, error)
+	DeactivateUser(context.Context, *DeactivateUserRequest) (*DeactivateUserResponse, error)
+	ReactivateUser(context.Context, *ReactivateUserRequest) (*ReactivateUserResponse, error)
+	PurgeUser(context.Context, *PurgeUserRequest) (*PurgeUserResponse, error)
+	Impersonate(context.Context, *ImpersonateRequest) (*ImpersonateResponse, error)
+	EndImpersonation(context.Context, *EndImpersonationRequest) (*EndImpersonationResponse, error)
+	ImportUsers(grpc.ClientStreamingServer[ImportUsersRequest, ImportUsersResponse]) error
+	ExportDirectory(*ExportDirectoryRequest, grpc.ServerStreamingServer[ExportDirectoryChunk]) error
+	ImportDirectory(grpc.ClientStreamingServer[ImportDirectoryRequest, ImportDirectoryResponse]) error
+	CreateTerm(context.Context, *CreateTermRequest) (*CreateTermResponse, error)
+	ListTerms(context.Context, *ListTermsRequest) (*ListTermsResponse, error)
+	SetTermState(context.Context, *SetTermStateRequest) (*SetTermStateResponse, error)
+	ListDisciplineStaff(context.Context, *ListDisciplineStaffRequest) (*ListDisciplineStaffResponse, error)
+	ManageDisciplineLecturer(context.Context, *ManageDisciplineLecturerRequest) (*ManageDisciplineLecturerResponse, error)
+	ListDisciplineLecturers(context.Context, *ListDisciplineLecturersRequest) (*ListDisciplineLecturersResponse, error)
+	QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error)
+	ReconcileRoster(context.Context, *ReconcileRosterRequest) (*ReconcileRosterResponse, error)
+	MergeUsers(context.Context, *MergeUsersRequest) (*MergeUsersResponse, error)
 	mustEmbedUnimplementedSuperAccServiceServer()
 }
 
@@ -291,6 +579,69 @@ func (UnimplementedSuperAccServiceServer) GetGroupStaff(context.Context, *GetGro
 func (UnimplementedSuperAccServiceServer) DetachDisciplinesFromGroup(context.Context, *DetachDisciplinesFromGroupRequest) (*DetachDisciplinesFromGroupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DetachDisciplinesFromGroup not implemented")
 }
+func (UnimplementedSuperAccServiceServer) UnlockUser(context.Context, *UnlockUserRequest) (*UnlockUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUser not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ListInvitations(context.Context, *ListInvitationsRequest) (*ListInvitationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInvitations not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ResendInvitation(context.Context, *ResendInvitationRequest) (*ResendInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendInvitation not implemented")
+}
+func (UnimplementedSuperAccServiceServer) RevokeInvitation(context.Context, *RevokeInvitationRequest) (*RevokeInvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeInvitation not implemented")
+}
+func (UnimplementedSuperAccServiceServer) DeactivateUser(context.Context, *DeactivateUserRequest) (*DeactivateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeactivateUser not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ReactivateUser(context.Context, *ReactivateUserRequest) (*ReactivateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReactivateUser not implemented")
+}
+func (UnimplementedSuperAccServiceServer) PurgeUser(context.Context, *PurgeUserRequest) (*PurgeUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeUser not implemented")
+}
+func (UnimplementedSuperAccServiceServer) Impersonate(context.Context, *ImpersonateRequest) (*ImpersonateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Impersonate not implemented")
+}
+func (UnimplementedSuperAccServiceServer) EndImpersonation(context.Context, *EndImpersonationRequest) (*EndImpersonationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndImpersonation not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ImportUsers(grpc.ClientStreamingServer[ImportUsersRequest, ImportUsersResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ExportDirectory(*ExportDirectoryRequest, grpc.ServerStreamingServer[ExportDirectoryChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportDirectory not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ImportDirectory(grpc.ClientStreamingServer[ImportDirectoryRequest, ImportDirectoryResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportDirectory not implemented")
+}
+func (UnimplementedSuperAccServiceServer) CreateTerm(context.Context, *CreateTermRequest) (*CreateTermResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTerm not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ListTerms(context.Context, *ListTermsRequest) (*ListTermsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTerms not implemented")
+}
+func (UnimplementedSuperAccServiceServer) SetTermState(context.Context, *SetTermStateRequest) (*SetTermStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetTermState not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ListDisciplineStaff(context.Context, *ListDisciplineStaffRequest) (*ListDisciplineStaffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDisciplineStaff not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ManageDisciplineLecturer(context.Context, *ManageDisciplineLecturerRequest) (*ManageDisciplineLecturerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ManageDisciplineLecturer not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ListDisciplineLecturers(context.Context, *ListDisciplineLecturersRequest) (*ListDisciplineLecturersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDisciplineLecturers not implemented")
+}
+func (UnimplementedSuperAccServiceServer) QueryAuditLog(context.Context, *QueryAuditLogRequest) (*QueryAuditLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAuditLog not implemented")
+}
+func (UnimplementedSuperAccServiceServer) ReconcileRoster(context.Context, *ReconcileRosterRequest) (*ReconcileRosterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileRoster not implemented")
+}
+func (UnimplementedSuperAccServiceServer) MergeUsers(context.Context, *MergeUsersRequest) (*MergeUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeUsers not implemented")
+}
 func (UnimplementedSuperAccServiceServer) mustEmbedUnimplementedSuperAccServiceServer() {}
 func (UnimplementedSuperAccServiceServer) testEmbeddedByValue()                         {}
 
@@ -582,6 +933,355 @@ func _SuperAccService_DetachDisciplinesFromGroup_Handler(srv interface{}, ctx co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SuperAccService_UnlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).UnlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_UnlockUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).UnlockUser(ctx, req.(*UnlockUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_ListInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInvitationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).ListInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_ListInvitations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).ListInvitations(ctx, req.(*ListInvitationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_ResendInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).ResendInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_ResendInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).ResendInvitation(ctx, req.(*ResendInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_RevokeInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeInvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).RevokeInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_RevokeInvitation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).RevokeInvitation(ctx, req.(*RevokeInvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_DeactivateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeactivateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).DeactivateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_DeactivateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).DeactivateUser(ctx, req.(*DeactivateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_ReactivateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactivateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).ReactivateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_ReactivateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).ReactivateUser(ctx, req.(*ReactivateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_PurgeUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).PurgeUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_PurgeUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).PurgeUser(ctx, req.(*PurgeUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_Impersonate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpersonateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).Impersonate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_Impersonate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).Impersonate(ctx, req.(*ImpersonateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_EndImpersonation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndImpersonationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).EndImpersonation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_EndImpersonation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).EndImpersonation(ctx, req.(*EndImpersonationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_ImportUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SuperAccServiceServer).ImportUsers(&grpc.GenericServerStream[ImportUsersRequest, ImportUsersResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperAccService_ImportUsersServer = grpc.ClientStreamingServer[ImportUsersRequest, ImportUsersResponse]
+
+func _SuperAccService_ExportDirectory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportDirectoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SuperAccServiceServer).ExportDirectory(m, &grpc.GenericServerStream[ExportDirectoryRequest, ExportDirectoryChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperAccService_ExportDirectoryServer = grpc.ServerStreamingServer[ExportDirectoryChunk]
+
+func _SuperAccService_ImportDirectory_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SuperAccServiceServer).ImportDirectory(&grpc.GenericServerStream[ImportDirectoryRequest, ImportDirectoryResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SuperAccService_ImportDirectoryServer = grpc.ClientStreamingServer[ImportDirectoryRequest, ImportDirectoryResponse]
+
+func _SuperAccService_CreateTerm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTermRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).CreateTerm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_CreateTerm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).CreateTerm(ctx, req.(*CreateTermRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_ListTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTermsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).ListTerms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_ListTerms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).ListTerms(ctx, req.(*ListTermsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_SetTermState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTermStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).SetTermState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_SetTermState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).SetTermState(ctx, req.(*SetTermStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_ListDisciplineStaff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDisciplineStaffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).ListDisciplineStaff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_ListDisciplineStaff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).ListDisciplineStaff(ctx, req.(*ListDisciplineStaffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_ManageDisciplineLecturer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ManageDisciplineLecturerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).ManageDisciplineLecturer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_ManageDisciplineLecturer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).ManageDisciplineLecturer(ctx, req.(*ManageDisciplineLecturerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_ListDisciplineLecturers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDisciplineLecturersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).ListDisciplineLecturers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_ListDisciplineLecturers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).ListDisciplineLecturers(ctx, req.(*ListDisciplineLecturersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_QueryAuditLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAuditLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).QueryAuditLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_QueryAuditLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).QueryAuditLog(ctx, req.(*QueryAuditLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_ReconcileRoster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRosterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).ReconcileRoster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_ReconcileRoster_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).ReconcileRoster(ctx, req.(*ReconcileRosterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SuperAccService_MergeUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuperAccServiceServer).MergeUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SuperAccService_MergeUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuperAccServiceServer).MergeUsers(ctx, req.(*MergeUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SuperAccService_ServiceDesc is the grpc.ServiceDesc for SuperAccService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -649,7 +1349,95 @@ var SuperAccService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DetachDisciplinesFromGroup",
 			Handler:    _SuperAccService_DetachDisciplinesFromGroup_Handler,
 		},
+		{
+			MethodName: "UnlockUser",
+			Handler:    _SuperAccService_UnlockUser_Handler,
+		},
+		{
+			MethodName: "ListInvitations",
+			Handler:    _SuperAccService_ListInvitations_Handler,
+		},
+		{
+			MethodName: "ResendInvitation",
+			Handler:    _SuperAccService_ResendInvitation_Handler,
+		},
+		{
+			MethodName: "RevokeInvitation",
+			Handler:    _SuperAccService_RevokeInvitation_Handler,
+		},
+		{
+			MethodName: "DeactivateUser",
+			Handler:    _SuperAccService_DeactivateUser_Handler,
+		},
+		{
+			MethodName: "ReactivateUser",
+			Handler:    _SuperAccService_ReactivateUser_Handler,
+		},
+		{
+			MethodName: "PurgeUser",
+			Handler:    _SuperAccService_PurgeUser_Handler,
+		},
+		{
+			MethodName: "Impersonate",
+			Handler:    _SuperAccService_Impersonate_Handler,
+		},
+		{
+			MethodName: "EndImpersonation",
+			Handler:    _SuperAccService_EndImpersonation_Handler,
+		},
+		{
+			MethodName: "CreateTerm",
+			Handler:    _SuperAccService_CreateTerm_Handler,
+		},
+		{
+			MethodName: "ListTerms",
+			Handler:    _SuperAccService_ListTerms_Handler,
+		},
+		{
+			MethodName: "SetTermState",
+			Handler:    _SuperAccService_SetTermState_Handler,
+		},
+		{
+			MethodName: "ListDisciplineStaff",
+			Handler:    _SuperAccService_ListDisciplineStaff_Handler,
+		},
+		{
+			MethodName: "ManageDisciplineLecturer",
+			Handler:    _SuperAccService_ManageDisciplineLecturer_Handler,
+		},
+		{
+			MethodName: "ListDisciplineLecturers",
+			Handler:    _SuperAccService_ListDisciplineLecturers_Handler,
+		},
+		{
+			MethodName: "QueryAuditLog",
+			Handler:    _SuperAccService_QueryAuditLog_Handler,
+		},
+		{
+			MethodName: "ReconcileRoster",
+			Handler:    _SuperAccService_ReconcileRoster_Handler,
+		},
+		{
+			MethodName: "MergeUsers",
+			Handler:    _SuperAccService_MergeUsers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ImportUsers",
+			Handler:       _SuperAccService_ImportUsers_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportDirectory",
+			Handler:       _SuperAccService_ExportDirectory_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportDirectory",
+			Handler:       _SuperAccService_ImportDirectory_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/superacc/superacc.proto",
 }
@@ -21,6 +21,63 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// WorkStatus — статус работы студента; совпадает с CHECK на student_works.status.
+// Общий для WorkService и GradingService
+type WorkStatus int32
+
+const (
+	WorkStatus_WORK_STATUS_UNSPECIFIED          WorkStatus = 0
+	WorkStatus_WORK_STATUS_PENDING              WorkStatus = 1
+	WorkStatus_WORK_STATUS_SUBMITTED            WorkStatus = 2
+	WorkStatus_WORK_STATUS_GRADED_BY_ASSISTANT  WorkStatus = 3
+	WorkStatus_WORK_STATUS_GRADED_BY_SEMINARIST WorkStatus = 4
+)
+
+// Enum value maps for WorkStatus.
+var (
+	WorkStatus_name = map[int32]string{
+		0: "WORK_STATUS_UNSPECIFIED",
+		1: "WORK_STATUS_PENDING",
+		2: "WORK_STATUS_SUBMITTED",
+		3: "WORK_STATUS_GRADED_BY_ASSISTANT",
+		4: "WORK_STATUS_GRADED_BY_SEMINARIST",
+	}
+	WorkStatus_value = map[string]int32{
+		"WORK_STATUS_UNSPECIFIED":          0,
+		"WORK_STATUS_PENDING":              1,
+		"WORK_STATUS_SUBMITTED":            2,
+		"WORK_STATUS_GRADED_BY_ASSISTANT":  3,
+		"WORK_STATUS_GRADED_BY_SEMINARIST": 4,
+	}
+)
+
+func (x WorkStatus) Enum() *WorkStatus {
+	p := new(WorkStatus)
+	*p = x
+	return p
+}
+
+func (x WorkStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_work_work_proto_enumTypes[0].Descriptor()
+}
+
+func (WorkStatus) Type() protoreflect.EnumType {
+	return &file_proto_work_work_proto_enumTypes[0]
+}
+
+func (x WorkStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkStatus.Descriptor instead.
+func (WorkStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_work_work_proto_rawDescGZIP(), []int{0}
+}
+
 type GetStudentsByGroupAndDisciplineRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GroupId       int32                  `protobuf:"varint,1,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
@@ -128,7 +185,7 @@ func (x *GetStudentsByGroupAndDisciplineResponse) GetError() string {
 type UpdateWorkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	WorkId        int32                  `protobuf:"varint,1,opt,name=work_id,json=workId,proto3" json:"work_id,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Status        WorkStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=work.WorkStatus" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -170,11 +227,11 @@ func (x *UpdateWorkRequest) GetWorkId() int32 {
 	return 0
 }
 
-func (x *UpdateWorkRequest) GetStatus() string {
+func (x *UpdateWorkRequest) GetStatus() WorkStatus {
 	if x != nil {
 		return x.Status
 	}
-	return ""
+	return WorkStatus_WORK_STATUS_UNSPECIFIED
 }
 
 type UpdateWorkResponse struct {
@@ -320,6 +377,7 @@ func (x *GetStudentWorksByTaskResponse) GetError() string {
 type GetAssistantsByDisciplineRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DisciplineId  int32                  `protobuf:"varint,1,opt,name=discipline_id,json=disciplineId,proto3" json:"discipline_id,omitempty"`
+	GroupId       int32                  `protobuf:"varint,2,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"` // 0 — ассистенты всех групп дисциплины
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -361,6 +419,13 @@ func (x *GetAssistantsByDisciplineRequest) GetDisciplineId() int32 {
 	return 0
 }
 
+func (x *GetAssistantsByDisciplineRequest) GetGroupId() int32 {
+	if x != nil {
+		return x.GroupId
+	}
+	return 0
+}
+
 type GetAssistantsByDisciplineResponse struct {
 	state         protoimpl.MessageState                         `protogen:"open.v1"`
 	Assistants    []*GetAssistantsByDisciplineResponse_Assistant `protobuf:"bytes,1,rep,name=assistants,proto3" json:"assistants,omitempty"`
@@ -512,6 +577,7 @@ func (x *AssignAssistantsToWorksResponse) GetError() string {
 type GetTasksForSeminaristRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SeminaristId  string                 `protobuf:"bytes,1,opt,name=seminarist_id,json=seminaristId,proto3" json:"seminarist_id,omitempty"`
+	TermId        int32                  `protobuf:"varint,2,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"` // 0 — активный семестр
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -553,6 +619,13 @@ func (x *GetTasksForSeminaristRequest) GetSeminaristId() string {
 	return ""
 }
 
+func (x *GetTasksForSeminaristRequest) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
 type GetTasksForSeminaristResponse struct {
 	state         protoimpl.MessageState                `protogen:"open.v1"`
 	Tasks         []*GetTasksForSeminaristResponse_Task `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
@@ -984,6 +1057,7 @@ func (x *UpdateTaskGroupAndDisciplineResponse) GetError() string {
 type GetTasksForLectorRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LectorId      int32                  `protobuf:"varint,1,opt,name=lector_id,json=lectorId,proto3" json:"lector_id,omitempty"`
+	TermId        int32                  `protobuf:"varint,2,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"` // 0 — активный семестр
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1025,6 +1099,13 @@ func (x *GetTasksForLectorRequest) GetLectorId() int32 {
 	return 0
 }
 
+func (x *GetTasksForLectorRequest) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
 type Task struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1980,6 +2061,7 @@ func (x *LoadTaskDeadlineResponse) GetError() string {
 type GetGroupsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LectorId      int32                  `protobuf:"varint,1,opt,name=lector_id,json=lectorId,proto3" json:"lector_id,omitempty"`
+	TermId        int32                  `protobuf:"varint,2,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"` // 0 — активный семестр
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2021,6 +2103,13 @@ func (x *GetGroupsRequest) GetLectorId() int32 {
 	return 0
 }
 
+func (x *GetGroupsRequest) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
 type GetGroupsResponse struct {
 	state         protoimpl.MessageState     `protogen:"open.v1"`
 	Groups        []*GetGroupsResponse_Group `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
@@ -2076,6 +2165,7 @@ func (x *GetGroupsResponse) GetError() string {
 type GetDisciplinesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	LectorId      int32                  `protobuf:"varint,1,opt,name=lector_id,json=lectorId,proto3" json:"lector_id,omitempty"`
+	TermId        int32                  `protobuf:"varint,2,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"` // 0 — активный семестр
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2117,6 +2207,13 @@ func (x *GetDisciplinesRequest) GetLectorId() int32 {
 	return 0
 }
 
+func (x *GetDisciplinesRequest) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
 type GetDisciplinesResponse struct {
 	state         protoimpl.MessageState               `protogen:"open.v1"`
 	Disciplines   []*GetDisciplinesResponse_Discipline `protobuf:"bytes,1,rep,name=disciplines,proto3" json:"disciplines,omitempty"`
@@ -2172,6 +2269,7 @@ func (x *GetDisciplinesResponse) GetError() string {
 type ListTasksForStudentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StudentId     int32                  `protobuf:"varint,1,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	TermId        int32                  `protobuf:"varint,2,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"` // 0 — активный семестр
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2213,6 +2311,13 @@ func (x *ListTasksForStudentRequest) GetStudentId() int32 {
 	return 0
 }
 
+func (x *ListTasksForStudentRequest) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
 type ListTasksForStudentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tasks         []*Tasks               `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
@@ -2500,6 +2605,7 @@ func (x *ListWorksForStudentResponse) GetError() string {
 type GetStudentDisciplinesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StudentId     int32                  `protobuf:"varint,1,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	TermId        int32                  `protobuf:"varint,2,opt,name=term_id,json=termId,proto3" json:"term_id,omitempty"` // 0 — активный семестр
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2541,6 +2647,13 @@ func (x *GetStudentDisciplinesRequest) GetStudentId() int32 {
 	return 0
 }
 
+func (x *GetStudentDisciplinesRequest) GetTermId() int32 {
+	if x != nil {
+		return x.TermId
+	}
+	return 0
+}
+
 type GetStudentDisciplinesResponse struct {
 	state         protoimpl.MessageState                      `protogen:"open.v1"`
 	Disciplines   []*GetStudentDisciplinesResponse_Discipline `protobuf:"bytes,1,rep,name=disciplines,proto3" json:"disciplines,omitempty"`
@@ -2697,6 +2810,259 @@ func (x *GetStudentWorksByDisciplineResponse) GetError() string {
 	return ""
 }
 
+type GetTermsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTermsRequest) Reset() {
+	*x = GetTermsRequest{}
+	mi := &file_proto_work_work_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTermsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTermsRequest) ProtoMessage() {}
+
+func (x *GetTermsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_work_work_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTermsRequest.ProtoReflect.Descriptor instead.
+func (*GetTermsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_work_work_proto_rawDescGZIP(), []int{51}
+}
+
+type GetTermsResponse struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Terms         []*GetTermsResponse_Term `protobuf:"bytes,1,rep,name=terms,proto3" json:"terms,omitempty"`
+	Error         string                   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTermsResponse) Reset() {
+	*x = GetTermsResponse{}
+	mi := &file_proto_work_work_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTermsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTermsResponse) ProtoMessage() {}
+
+func (x *GetTermsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_work_work_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTermsResponse.ProtoReflect.Descriptor instead.
+func (*GetTermsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_work_work_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetTermsResponse) GetTerms() []*GetTermsResponse_Term {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+func (x *GetTermsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// CloneDiscipline создаёт в целевом семестре копию дисциплины с заданиями, группами
+// критериев и критериями. Работы студентов и оценки не копируются.
+type CloneDisciplineRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	DisciplineId       int32                  `protobuf:"varint,1,opt,name=discipline_id,json=disciplineId,proto3" json:"discipline_id,omitempty"`
+	TargetTermId       int32                  `protobuf:"varint,2,opt,name=target_term_id,json=targetTermId,proto3" json:"target_term_id,omitempty"`
+	Name               string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                                                                                                 // название копии; по умолчанию как у исходной дисциплины
+	DeadlineOffsetDays int32                  `protobuf:"varint,4,opt,name=deadline_offset_days,json=deadlineOffsetDays,proto3" json:"deadline_offset_days,omitempty"`                                                        // на сколько дней сдвинуть дедлайны заданий
+	GroupMapping       map[int32]int32        `protobuf:"bytes,5,rep,name=group_mapping,json=groupMapping,proto3" json:"group_mapping,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // старая группа -> новая; группы без пары остаются прежними
+	// перенести назначения групп с семинаристами и ассистентами; без этого группы
+	// заданий прикрепляются к копии без преподавателей
+	CopyGroupAssignments bool `protobuf:"varint,6,opt,name=copy_group_assignments,json=copyGroupAssignments,proto3" json:"copy_group_assignments,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CloneDisciplineRequest) Reset() {
+	*x = CloneDisciplineRequest{}
+	mi := &file_proto_work_work_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneDisciplineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneDisciplineRequest) ProtoMessage() {}
+
+func (x *CloneDisciplineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_work_work_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneDisciplineRequest.ProtoReflect.Descriptor instead.
+func (*CloneDisciplineRequest) Descriptor() ([]byte, []int) {
+	return file_proto_work_work_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CloneDisciplineRequest) GetDisciplineId() int32 {
+	if x != nil {
+		return x.DisciplineId
+	}
+	return 0
+}
+
+func (x *CloneDisciplineRequest) GetTargetTermId() int32 {
+	if x != nil {
+		return x.TargetTermId
+	}
+	return 0
+}
+
+func (x *CloneDisciplineRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CloneDisciplineRequest) GetDeadlineOffsetDays() int32 {
+	if x != nil {
+		return x.DeadlineOffsetDays
+	}
+	return 0
+}
+
+func (x *CloneDisciplineRequest) GetGroupMapping() map[int32]int32 {
+	if x != nil {
+		return x.GroupMapping
+	}
+	return nil
+}
+
+func (x *CloneDisciplineRequest) GetCopyGroupAssignments() bool {
+	if x != nil {
+		return x.CopyGroupAssignments
+	}
+	return false
+}
+
+// Соответствие старых id новым
+type CloneDisciplineResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DisciplineId     int32                  `protobuf:"varint,1,opt,name=discipline_id,json=disciplineId,proto3" json:"discipline_id,omitempty"`
+	TaskIds          map[int32]int32        `protobuf:"bytes,2,rep,name=task_ids,json=taskIds,proto3" json:"task_ids,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	CriteriaGroupIds map[int32]int32        `protobuf:"bytes,3,rep,name=criteria_group_ids,json=criteriaGroupIds,proto3" json:"criteria_group_ids,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	CriteriaIds      map[int32]int32        `protobuf:"bytes,4,rep,name=criteria_ids,json=criteriaIds,proto3" json:"criteria_ids,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Error            string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CloneDisciplineResponse) Reset() {
+	*x = CloneDisciplineResponse{}
+	mi := &file_proto_work_work_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloneDisciplineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneDisciplineResponse) ProtoMessage() {}
+
+func (x *CloneDisciplineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_work_work_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneDisciplineResponse.ProtoReflect.Descriptor instead.
+func (*CloneDisciplineResponse) Descriptor() ([]byte, []int) {
+	return file_proto_work_work_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CloneDisciplineResponse) GetDisciplineId() int32 {
+	if x != nil {
+		return x.DisciplineId
+	}
+	return 0
+}
+
+func (x *CloneDisciplineResponse) GetTaskIds() map[int32]int32 {
+	if x != nil {
+		return x.TaskIds
+	}
+	return nil
+}
+
+func (x *CloneDisciplineResponse) GetCriteriaGroupIds() map[int32]int32 {
+	if x != nil {
+		return x.CriteriaGroupIds
+	}
+	return nil
+}
+
+func (x *CloneDisciplineResponse) GetCriteriaIds() map[int32]int32 {
+	if x != nil {
+		return x.CriteriaIds
+	}
+	return nil
+}
+
+func (x *CloneDisciplineResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type GetStudentsByGroupAndDisciplineResponse_Student struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -2710,7 +3076,7 @@ type GetStudentsByGroupAndDisciplineResponse_Student struct {
 
 func (x *GetStudentsByGroupAndDisciplineResponse_Student) Reset() {
 	*x = GetStudentsByGroupAndDisciplineResponse_Student{}
-	mi := &file_proto_work_work_proto_msgTypes[51]
+	mi := &file_proto_work_work_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2722,7 +3088,7 @@ func (x *GetStudentsByGroupAndDisciplineResponse_Student) String() string {
 func (*GetStudentsByGroupAndDisciplineResponse_Student) ProtoMessage() {}
 
 func (x *GetStudentsByGroupAndDisciplineResponse_Student) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_work_work_proto_msgTypes[51]
+	mi := &file_proto_work_work_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2792,7 +3158,7 @@ type GetStudentWorksByTaskResponse_StudentWork struct {
 
 func (x *GetStudentWorksByTaskResponse_StudentWork) Reset() {
 	*x = GetStudentWorksByTaskResponse_StudentWork{}
-	mi := &file_proto_work_work_proto_msgTypes[52]
+	mi := &file_proto_work_work_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2804,7 +3170,7 @@ func (x *GetStudentWorksByTaskResponse_StudentWork) String() string {
 func (*GetStudentWorksByTaskResponse_StudentWork) ProtoMessage() {}
 
 func (x *GetStudentWorksByTaskResponse_StudentWork) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_work_work_proto_msgTypes[52]
+	mi := &file_proto_work_work_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2909,7 +3275,7 @@ type GetAssistantsByDisciplineResponse_Assistant struct {
 
 func (x *GetAssistantsByDisciplineResponse_Assistant) Reset() {
 	*x = GetAssistantsByDisciplineResponse_Assistant{}
-	mi := &file_proto_work_work_proto_msgTypes[53]
+	mi := &file_proto_work_work_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2921,7 +3287,7 @@ func (x *GetAssistantsByDisciplineResponse_Assistant) String() string {
 func (*GetAssistantsByDisciplineResponse_Assistant) ProtoMessage() {}
 
 func (x *GetAssistantsByDisciplineResponse_Assistant) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_work_work_proto_msgTypes[53]
+	mi := &file_proto_work_work_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2975,7 +3341,7 @@ type AssignAssistantsToWorksRequest_Assignment struct {
 
 func (x *AssignAssistantsToWorksRequest_Assignment) Reset() {
 	*x = AssignAssistantsToWorksRequest_Assignment{}
-	mi := &file_proto_work_work_proto_msgTypes[54]
+	mi := &file_proto_work_work_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2987,7 +3353,7 @@ func (x *AssignAssistantsToWorksRequest_Assignment) String() string {
 func (*AssignAssistantsToWorksRequest_Assignment) ProtoMessage() {}
 
 func (x *AssignAssistantsToWorksRequest_Assignment) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_work_work_proto_msgTypes[54]
+	mi := &file_proto_work_work_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3028,7 +3394,7 @@ type GetTasksForSeminaristResponse_Task struct {
 
 func (x *GetTasksForSeminaristResponse_Task) Reset() {
 	*x = GetTasksForSeminaristResponse_Task{}
-	mi := &file_proto_work_work_proto_msgTypes[55]
+	mi := &file_proto_work_work_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3040,7 +3406,7 @@ func (x *GetTasksForSeminaristResponse_Task) String() string {
 func (*GetTasksForSeminaristResponse_Task) ProtoMessage() {}
 
 func (x *GetTasksForSeminaristResponse_Task) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_work_work_proto_msgTypes[55]
+	mi := &file_proto_work_work_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3090,7 +3456,7 @@ type GetStudentWorksForSeminaristResponse_StudentWork struct {
 
 func (x *GetStudentWorksForSeminaristResponse_StudentWork) Reset() {
 	*x = GetStudentWorksForSeminaristResponse_StudentWork{}
-	mi := &file_proto_work_work_proto_msgTypes[56]
+	mi := &file_proto_work_work_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3102,7 +3468,7 @@ func (x *GetStudentWorksForSeminaristResponse_StudentWork) String() string {
 func (*GetStudentWorksForSeminaristResponse_StudentWork) ProtoMessage() {}
 
 func (x *GetStudentWorksForSeminaristResponse_StudentWork) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_work_work_proto_msgTypes[56]
+	mi := &file_proto_work_work_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3163,7 +3529,7 @@ type GetGroupsResponse_Group struct {
 
 func (x *GetGroupsResponse_Group) Reset() {
 	*x = GetGroupsResponse_Group{}
-	mi := &file_proto_work_work_proto_msgTypes[57]
+	mi := &file_proto_work_work_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3175,7 +3541,7 @@ func (x *GetGroupsResponse_Group) String() string {
 func (*GetGroupsResponse_Group) ProtoMessage() {}
 
 func (x *GetGroupsResponse_Group) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_work_work_proto_msgTypes[57]
+	mi := &file_proto_work_work_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3209,13 +3575,14 @@ type GetDisciplinesResponse_Discipline struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"` // "owner", "co_lecturer" или "read_only"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetDisciplinesResponse_Discipline) Reset() {
 	*x = GetDisciplinesResponse_Discipline{}
-	mi := &file_proto_work_work_proto_msgTypes[58]
+	mi := &file_proto_work_work_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3227,7 +3594,7 @@ func (x *GetDisciplinesResponse_Discipline) String() string {
 func (*GetDisciplinesResponse_Discipline) ProtoMessage() {}
 
 func (x *GetDisciplinesResponse_Discipline) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_work_work_proto_msgTypes[58]
+	mi := &file_proto_work_work_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3257,6 +3624,13 @@ func (x *GetDisciplinesResponse_Discipline) GetName() string {
 	return ""
 }
 
+func (x *GetDisciplinesResponse_Discipline) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
 type GetStudentDisciplinesResponse_Discipline struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -3267,7 +3641,7 @@ type GetStudentDisciplinesResponse_Discipline struct {
 
 func (x *GetStudentDisciplinesResponse_Discipline) Reset() {
 	*x = GetStudentDisciplinesResponse_Discipline{}
-	mi := &file_proto_work_work_proto_msgTypes[59]
+	mi := &file_proto_work_work_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3279,7 +3653,7 @@ func (x *GetStudentDisciplinesResponse_Discipline) String() string {
 func (*GetStudentDisciplinesResponse_Discipline) ProtoMessage() {}
 
 func (x *GetStudentDisciplinesResponse_Discipline) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_work_work_proto_msgTypes[59]
+	mi := &file_proto_work_work_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3309,6 +3683,82 @@ func (x *GetStudentDisciplinesResponse_Discipline) GetName() string {
 	return ""
 }
 
+type GetTermsResponse_Term struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	StartsOn      string                 `protobuf:"bytes,3,opt,name=starts_on,json=startsOn,proto3" json:"starts_on,omitempty"` // YYYY-MM-DD
+	EndsOn        string                 `protobuf:"bytes,4,opt,name=ends_on,json=endsOn,proto3" json:"ends_on,omitempty"`
+	State         string                 `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"` // "upcoming", "active" или "archived"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTermsResponse_Term) Reset() {
+	*x = GetTermsResponse_Term{}
+	mi := &file_proto_work_work_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTermsResponse_Term) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTermsResponse_Term) ProtoMessage() {}
+
+func (x *GetTermsResponse_Term) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_work_work_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTermsResponse_Term.ProtoReflect.Descriptor instead.
+func (*GetTermsResponse_Term) Descriptor() ([]byte, []int) {
+	return file_proto_work_work_proto_rawDescGZIP(), []int{52, 0}
+}
+
+func (x *GetTermsResponse_Term) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetTermsResponse_Term) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetTermsResponse_Term) GetStartsOn() string {
+	if x != nil {
+		return x.StartsOn
+	}
+	return ""
+}
+
+func (x *GetTermsResponse_Term) GetEndsOn() string {
+	if x != nil {
+		return x.EndsOn
+	}
+	return ""
+}
+
+func (x *GetTermsResponse_Term) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
 var File_proto_work_work_proto protoreflect.FileDescriptor
 
 const file_proto_work_work_proto_rawDesc = "" +
@@ -3327,10 +3777,10 @@ const file_proto_work_work_proto_rawDesc = "" +
 	"\n" +
 	"patronymic\x18\x04 \x01(\tR\n" +
 	"patronymic\x12\x14\n" +
-	"\x05email\x18\x05 \x01(\tR\x05email\"D\n" +
+	"\x05email\x18\x05 \x01(\tR\x05email\"\\\n" +
 	"\x11UpdateWorkRequest\x12\x17\n" +
-	"\awork_id\x18\x01 \x01(\x05R\x06workId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"*\n" +
+	"\awork_id\x18\x01 \x01(\x05R\x06workId\x12(\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x10.work.WorkStatusR\x06statusJ\x04\b\x02\x10\x03\"*\n" +
 	"\x12UpdateWorkResponse\x12\x14\n" +
 	"\x05error\x18\x01 \x01(\tR\x05error\"7\n" +
 	"\x1cGetStudentWorksByTaskRequest\x12\x17\n" +
@@ -3351,9 +3801,10 @@ const file_proto_work_work_proto_rawDesc = "" +
 	"\x14assistant_patronymic\x18\n" +
 	" \x01(\tR\x13assistantPatronymic\x12\x1d\n" +
 	"\n" +
-	"student_id\x18\v \x01(\x05R\tstudentId\"G\n" +
+	"student_id\x18\v \x01(\x05R\tstudentId\"b\n" +
 	" GetAssistantsByDisciplineRequest\x12#\n" +
-	"\rdiscipline_id\x18\x01 \x01(\x05R\fdisciplineId\"\xf7\x01\n" +
+	"\rdiscipline_id\x18\x01 \x01(\x05R\fdisciplineId\x12\x19\n" +
+	"\bgroup_id\x18\x02 \x01(\x05R\agroupId\"\xf7\x01\n" +
 	"!GetAssistantsByDisciplineResponse\x12Q\n" +
 	"\n" +
 	"assistants\x18\x01 \x03(\v21.work.GetAssistantsByDisciplineResponse.AssistantR\n" +
@@ -3374,9 +3825,10 @@ const file_proto_work_work_proto_rawDesc = "" +
 	"\fassistant_id\x18\x02 \x01(\x05R\vassistantId\"Q\n" +
 	"\x1fAssignAssistantsToWorksResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"C\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\\\n" +
 	"\x1cGetTasksForSeminaristRequest\x12#\n" +
-	"\rseminarist_id\x18\x01 \x01(\tR\fseminaristId\"\xbf\x01\n" +
+	"\rseminarist_id\x18\x01 \x01(\tR\fseminaristId\x12\x17\n" +
+	"\aterm_id\x18\x02 \x01(\x05R\x06termId\"\xbf\x01\n" +
 	"\x1dGetTasksForSeminaristResponse\x12>\n" +
 	"\x05tasks\x18\x01 \x03(\v2(.work.GetTasksForSeminaristResponse.TaskR\x05tasks\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x1aH\n" +
@@ -3419,9 +3871,10 @@ const file_proto_work_work_proto_rawDesc = "" +
 	"\rdiscipline_id\x18\x03 \x01(\x05R\fdisciplineId\"V\n" +
 	"$UpdateTaskGroupAndDisciplineResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"7\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"P\n" +
 	"\x18GetTasksForLectorRequest\x12\x1b\n" +
-	"\tlector_id\x18\x01 \x01(\x05R\blectorId\"H\n" +
+	"\tlector_id\x18\x01 \x01(\x05R\blectorId\x12\x17\n" +
+	"\aterm_id\x18\x02 \x01(\x05R\x06termId\"H\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1a\n" +
@@ -3479,27 +3932,31 @@ const file_proto_work_work_proto_rawDesc = "" +
 	"\atask_id\x18\x01 \x01(\x05R\x06taskId\"L\n" +
 	"\x18LoadTaskDeadlineResponse\x12\x1a\n" +
 	"\bdeadline\x18\x01 \x01(\tR\bdeadline\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"/\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"H\n" +
 	"\x10GetGroupsRequest\x12\x1b\n" +
-	"\tlector_id\x18\x01 \x01(\x05R\blectorId\"\x8d\x01\n" +
+	"\tlector_id\x18\x01 \x01(\x05R\blectorId\x12\x17\n" +
+	"\aterm_id\x18\x02 \x01(\x05R\x06termId\"\x8d\x01\n" +
 	"\x11GetGroupsResponse\x125\n" +
 	"\x06groups\x18\x01 \x03(\v2\x1d.work.GetGroupsResponse.GroupR\x06groups\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x1a+\n" +
 	"\x05Group\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"4\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"M\n" +
 	"\x15GetDisciplinesRequest\x12\x1b\n" +
-	"\tlector_id\x18\x01 \x01(\x05R\blectorId\"\xab\x01\n" +
+	"\tlector_id\x18\x01 \x01(\x05R\blectorId\x12\x17\n" +
+	"\aterm_id\x18\x02 \x01(\x05R\x06termId\"\xbf\x01\n" +
 	"\x16GetDisciplinesResponse\x12I\n" +
 	"\vdisciplines\x18\x01 \x03(\v2'.work.GetDisciplinesResponse.DisciplineR\vdisciplines\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\x1a0\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x1aD\n" +
 	"\n" +
 	"Discipline\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\";\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\"T\n" +
 	"\x1aListTasksForStudentRequest\x12\x1d\n" +
 	"\n" +
-	"student_id\x18\x01 \x01(\x05R\tstudentId\"V\n" +
+	"student_id\x18\x01 \x01(\x05R\tstudentId\x12\x17\n" +
+	"\aterm_id\x18\x02 \x01(\x05R\x06termId\"V\n" +
 	"\x1bListTasksForStudentResponse\x12!\n" +
 	"\x05tasks\x18\x01 \x03(\v2\v.work.TasksR\x05tasks\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\"a\n" +
@@ -3519,10 +3976,11 @@ const file_proto_work_work_proto_rawDesc = "" +
 	"\x1bListWorksForStudentResponse\x12 \n" +
 	"\x05works\x18\x01 \x03(\v2\n" +
 	".work.WorkR\x05works\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"=\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"V\n" +
 	"\x1cGetStudentDisciplinesRequest\x12\x1d\n" +
 	"\n" +
-	"student_id\x18\x01 \x01(\x05R\tstudentId\"\xb9\x01\n" +
+	"student_id\x18\x01 \x01(\x05R\tstudentId\x12\x17\n" +
+	"\aterm_id\x18\x02 \x01(\x05R\x06termId\"\xb9\x01\n" +
 	"\x1dGetStudentDisciplinesResponse\x12P\n" +
 	"\vdisciplines\x18\x01 \x03(\v2..work.GetStudentDisciplinesResponse.DisciplineR\vdisciplines\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x1a0\n" +
@@ -3537,7 +3995,49 @@ const file_proto_work_work_proto_rawDesc = "" +
 	"#GetStudentWorksByDisciplineResponse\x12 \n" +
 	"\x05works\x18\x01 \x03(\v2\n" +
 	".work.WorkR\x05works\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error2\x9a\x11\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\x11\n" +
+	"\x0fGetTermsRequest\"\xd3\x01\n" +
+	"\x10GetTermsResponse\x121\n" +
+	"\x05terms\x18\x01 \x03(\v2\x1b.work.GetTermsResponse.TermR\x05terms\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x1av\n" +
+	"\x04Term\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tstarts_on\x18\x03 \x01(\tR\bstartsOn\x12\x17\n" +
+	"\aends_on\x18\x04 \x01(\tR\x06endsOn\x12\x14\n" +
+	"\x05state\x18\x05 \x01(\tR\x05state\"\xf5\x02\n" +
+	"\x16CloneDisciplineRequest\x12#\n" +
+	"\rdiscipline_id\x18\x01 \x01(\x05R\fdisciplineId\x12$\n" +
+	"\x0etarget_term_id\x18\x02 \x01(\x05R\ftargetTermId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x120\n" +
+	"\x14deadline_offset_days\x18\x04 \x01(\x05R\x12deadlineOffsetDays\x12S\n" +
+	"\rgroup_mapping\x18\x05 \x03(\v2..work.CloneDisciplineRequest.GroupMappingEntryR\fgroupMapping\x124\n" +
+	"\x16copy_group_assignments\x18\x06 \x01(\bR\x14copyGroupAssignments\x1a?\n" +
+	"\x11GroupMappingEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x92\x04\n" +
+	"\x17CloneDisciplineResponse\x12#\n" +
+	"\rdiscipline_id\x18\x01 \x01(\x05R\fdisciplineId\x12E\n" +
+	"\btask_ids\x18\x02 \x03(\v2*.work.CloneDisciplineResponse.TaskIdsEntryR\ataskIds\x12a\n" +
+	"\x12criteria_group_ids\x18\x03 \x03(\v23.work.CloneDisciplineResponse.CriteriaGroupIdsEntryR\x10criteriaGroupIds\x12Q\n" +
+	"\fcriteria_ids\x18\x04 \x03(\v2..work.CloneDisciplineResponse.CriteriaIdsEntryR\vcriteriaIds\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x1a:\n" +
+	"\fTaskIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1aC\n" +
+	"\x15CriteriaGroupIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a>\n" +
+	"\x10CriteriaIdsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\x05R\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01*\xa8\x01\n" +
+	"\n" +
+	"WorkStatus\x12\x1b\n" +
+	"\x17WORK_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13WORK_STATUS_PENDING\x10\x01\x12\x19\n" +
+	"\x15WORK_STATUS_SUBMITTED\x10\x02\x12#\n" +
+	"\x1fWORK_STATUS_GRADED_BY_ASSISTANT\x10\x03\x12$\n" +
+	" WORK_STATUS_GRADED_BY_SEMINARIST\x10\x042\xa9\x12\n" +
 	"\vWorkService\x12V\n" +
 	"\x11GetTasksForLector\x12\x1e.work.GetTasksForLectorRequest\x1a\x1f.work.GetTasksForLectorResponse\"\x00\x12A\n" +
 	"\n" +
@@ -3565,7 +4065,9 @@ const file_proto_work_work_proto_rawDesc = "" +
 	"\x1bGetStudentWorksByDiscipline\x12(.work.GetStudentWorksByDisciplineRequest\x1a).work.GetStudentWorksByDisciplineResponse\x12?\n" +
 	"\n" +
 	"UpdateWork\x12\x17.work.UpdateWorkRequest\x1a\x18.work.UpdateWorkResponse\x12~\n" +
-	"\x1fGetStudentsByGroupAndDiscipline\x12,.work.GetStudentsByGroupAndDisciplineRequest\x1a-.work.GetStudentsByGroupAndDisciplineResponseB\x13Z\x11./proto/work;workb\x06proto3"
+	"\x1fGetStudentsByGroupAndDiscipline\x12,.work.GetStudentsByGroupAndDisciplineRequest\x1a-.work.GetStudentsByGroupAndDisciplineResponse\x12;\n" +
+	"\bGetTerms\x12\x15.work.GetTermsRequest\x1a\x16.work.GetTermsResponse\"\x00\x12P\n" +
+	"\x0fCloneDiscipline\x12\x1c.work.CloneDisciplineRequest\x1a\x1d.work.CloneDisciplineResponse\"\x00B\x16Z\x14rubr/proto/work;workb\x06proto3"
 
 var (
 	file_proto_work_work_proto_rawDescOnce sync.Once
@@ -3579,136 +4081,157 @@ func file_proto_work_work_proto_rawDescGZIP() []byte {
 	return file_proto_work_work_proto_rawDescData
 }
 
-var file_proto_work_work_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
+var file_proto_work_work_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_work_work_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
 var file_proto_work_work_proto_goTypes = []any{
-	(*GetStudentsByGroupAndDisciplineRequest)(nil),           // 0: work.GetStudentsByGroupAndDisciplineRequest
-	(*GetStudentsByGroupAndDisciplineResponse)(nil),          // 1: work.GetStudentsByGroupAndDisciplineResponse
-	(*UpdateWorkRequest)(nil),                                // 2: work.UpdateWorkRequest
-	(*UpdateWorkResponse)(nil),                               // 3: work.UpdateWorkResponse
-	(*GetStudentWorksByTaskRequest)(nil),                     // 4: work.GetStudentWorksByTaskRequest
-	(*GetStudentWorksByTaskResponse)(nil),                    // 5: work.GetStudentWorksByTaskResponse
-	(*GetAssistantsByDisciplineRequest)(nil),                 // 6: work.GetAssistantsByDisciplineRequest
-	(*GetAssistantsByDisciplineResponse)(nil),                // 7: work.GetAssistantsByDisciplineResponse
-	(*AssignAssistantsToWorksRequest)(nil),                   // 8: work.AssignAssistantsToWorksRequest
-	(*AssignAssistantsToWorksResponse)(nil),                  // 9: work.AssignAssistantsToWorksResponse
-	(*GetTasksForSeminaristRequest)(nil),                     // 10: work.GetTasksForSeminaristRequest
-	(*GetTasksForSeminaristResponse)(nil),                    // 11: work.GetTasksForSeminaristResponse
-	(*GetStudentWorksForSeminaristRequest)(nil),              // 12: work.GetStudentWorksForSeminaristRequest
-	(*GetStudentWorksForSeminaristResponse)(nil),             // 13: work.GetStudentWorksForSeminaristResponse
-	(*GetTaskDetailsRequest)(nil),                            // 14: work.GetTaskDetailsRequest
-	(*GetTaskDetailsResponse)(nil),                           // 15: work.GetTaskDetailsResponse
-	(*UpdateTaskGroupAndDisciplineRequest)(nil),              // 16: work.UpdateTaskGroupAndDisciplineRequest
-	(*UpdateTaskGroupAndDisciplineResponse)(nil),             // 17: work.UpdateTaskGroupAndDisciplineResponse
-	(*GetTasksForLectorRequest)(nil),                         // 18: work.GetTasksForLectorRequest
-	(*Task)(nil),                                             // 19: work.Task
-	(*GetTasksForLectorResponse)(nil),                        // 20: work.GetTasksForLectorResponse
-	(*DeleteTaskRequest)(nil),                                // 21: work.DeleteTaskRequest
-	(*DeleteTaskResponse)(nil),                               // 22: work.DeleteTaskResponse
-	(*SetTaskTitleRequest)(nil),                              // 23: work.SetTaskTitleRequest
-	(*SetTaskTitleResponse)(nil),                             // 24: work.SetTaskTitleResponse
-	(*SetTaskDescriptionRequest)(nil),                        // 25: work.SetTaskDescriptionRequest
-	(*SetTaskDescriptionResponse)(nil),                       // 26: work.SetTaskDescriptionResponse
-	(*SetTaskDeadlineRequest)(nil),                           // 27: work.SetTaskDeadlineRequest
-	(*SetTaskDeadlineResponse)(nil),                          // 28: work.SetTaskDeadlineResponse
-	(*CreateWorkRequest)(nil),                                // 29: work.CreateWorkRequest
-	(*CreateWorkResponse)(nil),                               // 30: work.CreateWorkResponse
-	(*LoadTaskNameRequest)(nil),                              // 31: work.LoadTaskNameRequest
-	(*LoadTaskNameResponse)(nil),                             // 32: work.LoadTaskNameResponse
-	(*LoadTaskDescriptionRequest)(nil),                       // 33: work.LoadTaskDescriptionRequest
-	(*LoadTaskDescriptionResponse)(nil),                      // 34: work.LoadTaskDescriptionResponse
-	(*LoadTaskDeadlineRequest)(nil),                          // 35: work.LoadTaskDeadlineRequest
-	(*LoadTaskDeadlineResponse)(nil),                         // 36: work.LoadTaskDeadlineResponse
-	(*GetGroupsRequest)(nil),                                 // 37: work.GetGroupsRequest
-	(*GetGroupsResponse)(nil),                                // 38: work.GetGroupsResponse
-	(*GetDisciplinesRequest)(nil),                            // 39: work.GetDisciplinesRequest
-	(*GetDisciplinesResponse)(nil),                           // 40: work.GetDisciplinesResponse
-	(*ListTasksForStudentRequest)(nil),                       // 41: work.ListTasksForStudentRequest
-	(*ListTasksForStudentResponse)(nil),                      // 42: work.ListTasksForStudentResponse
-	(*Tasks)(nil),                                            // 43: work.Tasks
-	(*ListWorksForStudentRequest)(nil),                       // 44: work.ListWorksForStudentRequest
-	(*Work)(nil),                                             // 45: work.Work
-	(*ListWorksForStudentResponse)(nil),                      // 46: work.ListWorksForStudentResponse
-	(*GetStudentDisciplinesRequest)(nil),                     // 47: work.GetStudentDisciplinesRequest
-	(*GetStudentDisciplinesResponse)(nil),                    // 48: work.GetStudentDisciplinesResponse
-	(*GetStudentWorksByDisciplineRequest)(nil),               // 49: work.GetStudentWorksByDisciplineRequest
-	(*GetStudentWorksByDisciplineResponse)(nil),              // 50: work.GetStudentWorksByDisciplineResponse
-	(*GetStudentsByGroupAndDisciplineResponse_Student)(nil),  // 51: work.GetStudentsByGroupAndDisciplineResponse.Student
-	(*GetStudentWorksByTaskResponse_StudentWork)(nil),        // 52: work.GetStudentWorksByTaskResponse.StudentWork
-	(*GetAssistantsByDisciplineResponse_Assistant)(nil),      // 53: work.GetAssistantsByDisciplineResponse.Assistant
-	(*AssignAssistantsToWorksRequest_Assignment)(nil),        // 54: work.AssignAssistantsToWorksRequest.Assignment
-	(*GetTasksForSeminaristResponse_Task)(nil),               // 55: work.GetTasksForSeminaristResponse.Task
-	(*GetStudentWorksForSeminaristResponse_StudentWork)(nil), // 56: work.GetStudentWorksForSeminaristResponse.StudentWork
-	(*GetGroupsResponse_Group)(nil),                          // 57: work.GetGroupsResponse.Group
-	(*GetDisciplinesResponse_Discipline)(nil),                // 58: work.GetDisciplinesResponse.Discipline
-	(*GetStudentDisciplinesResponse_Discipline)(nil),         // 59: work.GetStudentDisciplinesResponse.Discipline
+	(WorkStatus)(0), // 0: work.WorkStatus
+	(*GetStudentsByGroupAndDisciplineRequest)(nil),           // 1: work.GetStudentsByGroupAndDisciplineRequest
+	(*GetStudentsByGroupAndDisciplineResponse)(nil),          // 2: work.GetStudentsByGroupAndDisciplineResponse
+	(*UpdateWorkRequest)(nil),                                // 3: work.UpdateWorkRequest
+	(*UpdateWorkResponse)(nil),                               // 4: work.UpdateWorkResponse
+	(*GetStudentWorksByTaskRequest)(nil),                     // 5: work.GetStudentWorksByTaskRequest
+	(*GetStudentWorksByTaskResponse)(nil),                    // 6: work.GetStudentWorksByTaskResponse
+	(*GetAssistantsByDisciplineRequest)(nil),                 // 7: work.GetAssistantsByDisciplineRequest
+	(*GetAssistantsByDisciplineResponse)(nil),                // 8: work.GetAssistantsByDisciplineResponse
+	(*AssignAssistantsToWorksRequest)(nil),                   // 9: work.AssignAssistantsToWorksRequest
+	(*AssignAssistantsToWorksResponse)(nil),                  // 10: work.AssignAssistantsToWorksResponse
+	(*GetTasksForSeminaristRequest)(nil),                     // 11: work.GetTasksForSeminaristRequest
+	(*GetTasksForSeminaristResponse)(nil),                    // 12: work.GetTasksForSeminaristResponse
+	(*GetStudentWorksForSeminaristRequest)(nil),              // 13: work.GetStudentWorksForSeminaristRequest
+	(*GetStudentWorksForSeminaristResponse)(nil),             // 14: work.GetStudentWorksForSeminaristResponse
+	(*GetTaskDetailsRequest)(nil),                            // 15: work.GetTaskDetailsRequest
+	(*GetTaskDetailsResponse)(nil),                           // 16: work.GetTaskDetailsResponse
+	(*UpdateTaskGroupAndDisciplineRequest)(nil),              // 17: work.UpdateTaskGroupAndDisciplineRequest
+	(*UpdateTaskGroupAndDisciplineResponse)(nil),             // 18: work.UpdateTaskGroupAndDisciplineResponse
+	(*GetTasksForLectorRequest)(nil),                         // 19: work.GetTasksForLectorRequest
+	(*Task)(nil),                                             // 20: work.Task
+	(*GetTasksForLectorResponse)(nil),                        // 21: work.GetTasksForLectorResponse
+	(*DeleteTaskRequest)(nil),                                // 22: work.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),                               // 23: work.DeleteTaskResponse
+	(*SetTaskTitleRequest)(nil),                              // 24: work.SetTaskTitleRequest
+	(*SetTaskTitleResponse)(nil),                             // 25: work.SetTaskTitleResponse
+	(*SetTaskDescriptionRequest)(nil),                        // 26: work.SetTaskDescriptionRequest
+	(*SetTaskDescriptionResponse)(nil),                       // 27: work.SetTaskDescriptionResponse
+	(*SetTaskDeadlineRequest)(nil),                           // 28: work.SetTaskDeadlineRequest
+	(*SetTaskDeadlineResponse)(nil),                          // 29: work.SetTaskDeadlineResponse
+	(*CreateWorkRequest)(nil),                                // 30: work.CreateWorkRequest
+	(*CreateWorkResponse)(nil),                               // 31: work.CreateWorkResponse
+	(*LoadTaskNameRequest)(nil),                              // 32: work.LoadTaskNameRequest
+	(*LoadTaskNameResponse)(nil),                             // 33: work.LoadTaskNameResponse
+	(*LoadTaskDescriptionRequest)(nil),                       // 34: work.LoadTaskDescriptionRequest
+	(*LoadTaskDescriptionResponse)(nil),                      // 35: work.LoadTaskDescriptionResponse
+	(*LoadTaskDeadlineRequest)(nil),                          // 36: work.LoadTaskDeadlineRequest
+	(*LoadTaskDeadlineResponse)(nil),                         // 37: work.LoadTaskDeadlineResponse
+	(*GetGroupsRequest)(nil),                                 // 38: work.GetGroupsRequest
+	(*GetGroupsResponse)(nil),                                // 39: work.GetGroupsResponse
+	(*GetDisciplinesRequest)(nil),                            // 40: work.GetDisciplinesRequest
+	(*GetDisciplinesResponse)(nil),                           // 41: work.GetDisciplinesResponse
+	(*ListTasksForStudentRequest)(nil),                       // 42: work.ListTasksForStudentRequest
+	(*ListTasksForStudentResponse)(nil),                      // 43: work.ListTasksForStudentResponse
+	(*Tasks)(nil),                                            // 44: work.Tasks
+	(*ListWorksForStudentRequest)(nil),                       // 45: work.ListWorksForStudentRequest
+	(*Work)(nil),                                             // 46: work.Work
+	(*ListWorksForStudentResponse)(nil),                      // 47: work.ListWorksForStudentResponse
+	(*GetStudentDisciplinesRequest)(nil),                     // 48: work.GetStudentDisciplinesRequest
+	(*GetStudentDisciplinesResponse)(nil),                    // 49: work.GetStudentDisciplinesResponse
+	(*GetStudentWorksByDisciplineRequest)(nil),               // 50: work.GetStudentWorksByDisciplineRequest
+	(*GetStudentWorksByDisciplineResponse)(nil),              // 51: work.GetStudentWorksByDisciplineResponse
+	(*GetTermsRequest)(nil),                                  // 52: work.GetTermsRequest
+	(*GetTermsResponse)(nil),                                 // 53: work.GetTermsResponse
+	(*CloneDisciplineRequest)(nil),                           // 54: work.CloneDisciplineRequest
+	(*CloneDisciplineResponse)(nil),                          // 55: work.CloneDisciplineResponse
+	(*GetStudentsByGroupAndDisciplineResponse_Student)(nil),  // 56: work.GetStudentsByGroupAndDisciplineResponse.Student
+	(*GetStudentWorksByTaskResponse_StudentWork)(nil),        // 57: work.GetStudentWorksByTaskResponse.StudentWork
+	(*GetAssistantsByDisciplineResponse_Assistant)(nil),      // 58: work.GetAssistantsByDisciplineResponse.Assistant
+	(*AssignAssistantsToWorksRequest_Assignment)(nil),        // 59: work.AssignAssistantsToWorksRequest.Assignment
+	(*GetTasksForSeminaristResponse_Task)(nil),               // 60: work.GetTasksForSeminaristResponse.Task
+	(*GetStudentWorksForSeminaristResponse_StudentWork)(nil), // 61: work.GetStudentWorksForSeminaristResponse.StudentWork
+	(*GetGroupsResponse_Group)(nil),                          // 62: work.GetGroupsResponse.Group
+	(*GetDisciplinesResponse_Discipline)(nil),                // 63: work.GetDisciplinesResponse.Discipline
+	(*GetStudentDisciplinesResponse_Discipline)(nil),         // 64: work.GetStudentDisciplinesResponse.Discipline
+	(*GetTermsResponse_Term)(nil),                            // 65: work.GetTermsResponse.Term
+	nil,                                                      // 66: work.CloneDisciplineRequest.GroupMappingEntry
+	nil,                                                      // 67: work.CloneDisciplineResponse.TaskIdsEntry
+	nil,                                                      // 68: work.CloneDisciplineResponse.CriteriaGroupIdsEntry
+	nil,                                                      // 69: work.CloneDisciplineResponse.CriteriaIdsEntry
 }
 var file_proto_work_work_proto_depIdxs = []int32{
-	51, // 0: work.GetStudentsByGroupAndDisciplineResponse.students:type_name -> work.GetStudentsByGroupAndDisciplineResponse.Student
-	52, // 1: work.GetStudentWorksByTaskResponse.works:type_name -> work.GetStudentWorksByTaskResponse.StudentWork
-	53, // 2: work.GetAssistantsByDisciplineResponse.assistants:type_name -> work.GetAssistantsByDisciplineResponse.Assistant
-	54, // 3: work.AssignAssistantsToWorksRequest.assignments:type_name -> work.AssignAssistantsToWorksRequest.Assignment
-	55, // 4: work.GetTasksForSeminaristResponse.tasks:type_name -> work.GetTasksForSeminaristResponse.Task
-	56, // 5: work.GetStudentWorksForSeminaristResponse.works:type_name -> work.GetStudentWorksForSeminaristResponse.StudentWork
-	19, // 6: work.GetTasksForLectorResponse.tasks:type_name -> work.Task
-	57, // 7: work.GetGroupsResponse.groups:type_name -> work.GetGroupsResponse.Group
-	58, // 8: work.GetDisciplinesResponse.disciplines:type_name -> work.GetDisciplinesResponse.Discipline
-	43, // 9: work.ListTasksForStudentResponse.tasks:type_name -> work.Tasks
-	45, // 10: work.ListWorksForStudentResponse.works:type_name -> work.Work
-	59, // 11: work.GetStudentDisciplinesResponse.disciplines:type_name -> work.GetStudentDisciplinesResponse.Discipline
-	45, // 12: work.GetStudentWorksByDisciplineResponse.works:type_name -> work.Work
-	18, // 13: work.WorkService.GetTasksForLector:input_type -> work.GetTasksForLectorRequest
-	21, // 14: work.WorkService.DeleteTask:input_type -> work.DeleteTaskRequest
-	23, // 15: work.WorkService.SetTaskTitle:input_type -> work.SetTaskTitleRequest
-	25, // 16: work.WorkService.SetTaskDescription:input_type -> work.SetTaskDescriptionRequest
-	27, // 17: work.WorkService.SetTaskDeadline:input_type -> work.SetTaskDeadlineRequest
-	29, // 18: work.WorkService.CreateWork:input_type -> work.CreateWorkRequest
-	31, // 19: work.WorkService.LoadTaskName:input_type -> work.LoadTaskNameRequest
-	33, // 20: work.WorkService.LoadTaskDescription:input_type -> work.LoadTaskDescriptionRequest
-	35, // 21: work.WorkService.LoadTaskDeadline:input_type -> work.LoadTaskDeadlineRequest
-	37, // 22: work.WorkService.GetGroups:input_type -> work.GetGroupsRequest
-	39, // 23: work.WorkService.GetDisciplines:input_type -> work.GetDisciplinesRequest
-	14, // 24: work.WorkService.GetTaskDetails:input_type -> work.GetTaskDetailsRequest
-	16, // 25: work.WorkService.UpdateTaskGroupAndDiscipline:input_type -> work.UpdateTaskGroupAndDisciplineRequest
-	41, // 26: work.WorkService.ListTasksForStudent:input_type -> work.ListTasksForStudentRequest
-	44, // 27: work.WorkService.ListWorksForStudent:input_type -> work.ListWorksForStudentRequest
-	12, // 28: work.WorkService.GetStudentWorksForSeminarist:input_type -> work.GetStudentWorksForSeminaristRequest
-	10, // 29: work.WorkService.GetTasksForSeminarist:input_type -> work.GetTasksForSeminaristRequest
-	4,  // 30: work.WorkService.GetStudentWorksByTask:input_type -> work.GetStudentWorksByTaskRequest
-	6,  // 31: work.WorkService.GetAssistantsByDiscipline:input_type -> work.GetAssistantsByDisciplineRequest
-	8,  // 32: work.WorkService.AssignAssistantsToWorks:input_type -> work.AssignAssistantsToWorksRequest
-	47, // 33: work.WorkService.GetStudentDisciplines:input_type -> work.GetStudentDisciplinesRequest
-	49, // 34: work.WorkService.GetStudentWorksByDiscipline:input_type -> work.GetStudentWorksByDisciplineRequest
-	2,  // 35: work.WorkService.UpdateWork:input_type -> work.UpdateWorkRequest
-	0,  // 36: work.WorkService.GetStudentsByGroupAndDiscipline:input_type -> work.GetStudentsByGroupAndDisciplineRequest
-	20, // 37: work.WorkService.GetTasksForLector:output_type -> work.GetTasksForLectorResponse
-	22, // 38: work.WorkService.DeleteTask:output_type -> work.DeleteTaskResponse
-	24, // 39: work.WorkService.SetTaskTitle:output_type -> work.SetTaskTitleResponse
-	26, // 40: work.WorkService.SetTaskDescription:output_type -> work.SetTaskDescriptionResponse
-	28, // 41: work.WorkService.SetTaskDeadline:output_type -> work.SetTaskDeadlineResponse
-	30, // 42: work.WorkService.CreateWork:output_type -> work.CreateWorkResponse
-	32, // 43: work.WorkService.LoadTaskName:output_type -> work.LoadTaskNameResponse
-	34, // 44: work.WorkService.LoadTaskDescription:output_type -> work.LoadTaskDescriptionResponse
-	36, // 45: work.WorkService.LoadTaskDeadline:output_type -> work.LoadTaskDeadlineResponse
-	38, // 46: work.WorkService.GetGroups:output_type -> work.GetGroupsResponse
-	40, // 47: work.WorkService.GetDisciplines:output_type -> work.GetDisciplinesResponse
-	15, // 48: work.WorkService.GetTaskDetails:output_type -> work.GetTaskDetailsResponse
-	17, // 49: work.WorkService.UpdateTaskGroupAndDiscipline:output_type -> work.UpdateTaskGroupAndDisciplineResponse
-	42, // 50: work.WorkService.ListTasksForStudent:output_type -> work.ListTasksForStudentResponse
-	46, // 51: work.WorkService.ListWorksForStudent:output_type -> work.ListWorksForStudentResponse
-	13, // 52: work.WorkService.GetStudentWorksForSeminarist:output_type -> work.GetStudentWorksForSeminaristResponse
-	11, // 53: work.WorkService.GetTasksForSeminarist:output_type -> work.GetTasksForSeminaristResponse
-	5,  // 54: work.WorkService.GetStudentWorksByTask:output_type -> work.GetStudentWorksByTaskResponse
-	7,  // 55: work.WorkService.GetAssistantsByDiscipline:output_type -> work.GetAssistantsByDisciplineResponse
-	9,  // 56: work.WorkService.AssignAssistantsToWorks:output_type -> work.AssignAssistantsToWorksResponse
-	48, // 57: work.WorkService.GetStudentDisciplines:output_type -> work.GetStudentDisciplinesResponse
-	50, // 58: work.WorkService.GetStudentWorksByDiscipline:output_type -> work.GetStudentWorksByDisciplineResponse
-	3,  // 59: work.WorkService.UpdateWork:output_type -> work.UpdateWorkResponse
-	1,  // 60: work.WorkService.GetStudentsByGroupAndDiscipline:output_type -> work.GetStudentsByGroupAndDisciplineResponse
-	37, // [37:61] is the sub-list for method output_type
-	13, // [13:37] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	56, // 0: work.GetStudentsByGroupAndDisciplineResponse.students:type_name -> work.GetStudentsByGroupAndDisciplineResponse.Student
+	0,  // 1: work.UpdateWorkRequest.status:type_name -> work.WorkStatus
+	57, // 2: work.GetStudentWorksByTaskResponse.works:type_name -> work.GetStudentWorksByTaskResponse.StudentWork
+	58, // 3: work.GetAssistantsByDisciplineResponse.assistants:type_name -> work.GetAssistantsByDisciplineResponse.Assistant
+	59, // 4: work.AssignAssistantsToWorksRequest.assignments:type_name -> work.AssignAssistantsToWorksRequest.Assignment
+	60, // 5: work.GetTasksForSeminaristResponse.tasks:type_name -> work.GetTasksForSeminaristResponse.Task
+	61, // 6: work.GetStudentWorksForSeminaristResponse.works:type_name -> work.GetStudentWorksForSeminaristResponse.StudentWork
+	20, // 7: work.GetTasksForLectorResponse.tasks:type_name -> work.Task
+	62, // 8: work.GetGroupsResponse.groups:type_name -> work.GetGroupsResponse.Group
+	63, // 9: work.GetDisciplinesResponse.disciplines:type_name -> work.GetDisciplinesResponse.Discipline
+	44, // 10: work.ListTasksForStudentResponse.tasks:type_name -> work.Tasks
+	46, // 11: work.ListWorksForStudentResponse.works:type_name -> work.Work
+	64, // 12: work.GetStudentDisciplinesResponse.disciplines:type_name -> work.GetStudentDisciplinesResponse.Discipline
+	46, // 13: work.GetStudentWorksByDisciplineResponse.works:type_name -> work.Work
+	65, // 14: work.GetTermsResponse.terms:type_name -> work.GetTermsResponse.Term
+	66, // 15: work.CloneDisciplineRequest.group_mapping:type_name -> work.CloneDisciplineRequest.GroupMappingEntry
+	67, // 16: work.CloneDisciplineResponse.task_ids:type_name -> work.CloneDisciplineResponse.TaskIdsEntry
+	68, // 17: work.CloneDisciplineResponse.criteria_group_ids:type_name -> work.CloneDisciplineResponse.CriteriaGroupIdsEntry
+	69, // 18: work.CloneDisciplineResponse.criteria_ids:type_name -> work.CloneDisciplineResponse.CriteriaIdsEntry
+	19, // 19: work.WorkService.GetTasksForLector:input_type -> work.GetTasksForLectorRequest
+	22, // 20: work.WorkService.DeleteTask:input_type -> work.DeleteTaskRequest
+	24, // 21: work.WorkService.SetTaskTitle:input_type -> work.SetTaskTitleRequest
+	26, // 22: work.WorkService.SetTaskDescription:input_type -> work.SetTaskDescriptionRequest
+	28, // 23: work.WorkService.SetTaskDeadline:input_type -> work.SetTaskDeadlineRequest
+	30, // 24: work.WorkService.CreateWork:input_type -> work.CreateWorkRequest
+	32, // 25: work.WorkService.LoadTaskName:input_type -> work.LoadTaskNameRequest
+	34, // 26: work.WorkService.LoadTaskDescription:input_type -> work.LoadTaskDescriptionRequest
+	36, // 27: work.WorkService.LoadTaskDeadline:input_type -> work.LoadTaskDeadlineRequest
+	38, // 28: work.WorkService.GetGroups:input_type -> work.GetGroupsRequest
+	40, // 29: work.WorkService.GetDisciplines:input_type -> work.GetDisciplinesRequest
+	15, // 30: work.WorkService.GetTaskDetails:input_type -> work.GetTaskDetailsRequest
+	17, // 31: work.WorkService.UpdateTaskGroupAndDiscipline:input_type -> work.UpdateTaskGroupAndDisciplineRequest
+	42, // 32: work.WorkService.ListTasksForStudent:input_type -> work.ListTasksForStudentRequest
+	45, // 33: work.WorkService.ListWorksForStudent:input_type -> work.ListWorksForStudentRequest
+	13, // 34: work.WorkService.GetStudentWorksForSeminarist:input_type -> work.GetStudentWorksForSeminaristRequest
+	11, // 35: work.WorkService.GetTasksForSeminarist:input_type -> work.GetTasksForSeminaristRequest
+	5,  // 36: work.WorkService.GetStudentWorksByTask:input_type -> work.GetStudentWorksByTaskRequest
+	7,  // 37: work.WorkService.GetAssistantsByDiscipline:input_type -> work.GetAssistantsByDisciplineRequest
+	9,  // 38: work.WorkService.AssignAssistantsToWorks:input_type -> work.AssignAssistantsToWorksRequest
+	48, // 39: work.WorkService.GetStudentDisciplines:input_type -> work.GetStudentDisciplinesRequest
+	50, // 40: work.WorkService.GetStudentWorksByDiscipline:input_type -> work.GetStudentWorksByDisciplineRequest
+	3,  // 41: work.WorkService.UpdateWork:input_type -> work.UpdateWorkRequest
+	1,  // 42: work.WorkService.GetStudentsByGroupAndDiscipline:input_type -> work.GetStudentsByGroupAndDisciplineRequest
+	52, // 43: work.WorkService.GetTerms:input_type -> work.GetTermsRequest
+	54, // 44: work.WorkService.CloneDiscipline:input_type -> work.CloneDisciplineRequest
+	21, // 45: work.WorkService.GetTasksForLector:output_type -> work.GetTasksForLectorResponse
+	23, // 46: work.WorkService.DeleteTask:output_type -> work.DeleteTaskResponse
+	25, // 47: work.WorkService.SetTaskTitle:output_type -> work.SetTaskTitleResponse
+	27, // 48: work.WorkService.SetTaskDescription:output_type -> work.SetTaskDescriptionResponse
+	29, // 49: work.WorkService.SetTaskDeadline:output_type -> work.SetTaskDeadlineResponse
+	31, // 50: work.WorkService.CreateWork:output_type -> work.CreateWorkResponse
+	33, // 51: work.WorkService.LoadTaskName:output_type -> work.LoadTaskNameResponse
+	35, // 52: work.WorkService.LoadTaskDescription:output_type -> work.LoadTaskDescriptionResponse
+	37, // 53: work.WorkService.LoadTaskDeadline:output_type -> work.LoadTaskDeadlineResponse
+	39, // 54: work.WorkService.GetGroups:output_type -> work.GetGroupsResponse
+	41, // 55: work.WorkService.GetDisciplines:output_type -> work.GetDisciplinesResponse
+	16, // 56: work.WorkService.GetTaskDetails:output_type -> work.GetTaskDetailsResponse
+	18, // 57: work.WorkService.UpdateTaskGroupAndDiscipline:output_type -> work.UpdateTaskGroupAndDisciplineResponse
+	43, // 58: work.WorkService.ListTasksForStudent:output_type -> work.ListTasksForStudentResponse
+	47, // 59: work.WorkService.ListWorksForStudent:output_type -> work.ListWorksForStudentResponse
+	14, // 60: work.WorkService.GetStudentWorksForSeminarist:output_type -> work.GetStudentWorksForSeminaristResponse
+	12, // 61: work.WorkService.GetTasksForSeminarist:output_type -> work.GetTasksForSeminaristResponse
+	6,  // 62: work.WorkService.GetStudentWorksByTask:output_type -> work.GetStudentWorksByTaskResponse
+	8,  // 63: work.WorkService.GetAssistantsByDiscipline:output_type -> work.GetAssistantsByDisciplineResponse
+	10, // 64: work.WorkService.AssignAssistantsToWorks:output_type -> work.AssignAssistantsToWorksResponse
+	49, // 65: work.WorkService.GetStudentDisciplines:output_type -> work.GetStudentDisciplinesResponse
+	51, // 66: work.WorkService.GetStudentWorksByDiscipline:output_type -> work.GetStudentWorksByDisciplineResponse
+	4,  // 67: work.WorkService.UpdateWork:output_type -> work.UpdateWorkResponse
+	2,  // 68: work.WorkService.GetStudentsByGroupAndDiscipline:output_type -> work.GetStudentsByGroupAndDisciplineResponse
+	53, // 69: work.WorkService.GetTerms:output_type -> work.GetTermsResponse
+	55, // 70: work.WorkService.CloneDiscipline:output_type -> work.CloneDisciplineResponse
+	45, // [45:71] is the sub-list for method output_type
+	19, // [19:45] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_proto_work_work_proto_init() }
@@ -3721,13 +4244,14 @@ func file_proto_work_work_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_work_work_proto_rawDesc), len(file_proto_work_work_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   60,
+			NumEnums:      1,
+			NumMessages:   69,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_work_work_proto_goTypes,
 		DependencyIndexes: file_proto_work_work_proto_depIdxs,
+		EnumInfos:         file_proto_work_work_proto_enumTypes,
 		MessageInfos:      file_proto_work_work_proto_msgTypes,
 	}.Build()
 	File_proto_work_work_proto = out.File
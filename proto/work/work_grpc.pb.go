@@ -43,6 +43,8 @@ const (
 	WorkService_GetStudentWorksByDiscipline_FullMethodName     = "/work.WorkService/GetStudentWorksByDiscipline"
 	WorkService_UpdateWork_FullMethodName                      = "/work.WorkService/UpdateWork"
 	WorkService_GetStudentsByGroupAndDiscipline_FullMethodName = "/work.WorkService/GetStudentsByGroupAndDiscipline"
+	WorkService_GetTerms_FullMethodName                        = "/work.WorkService/GetTerms"
+	WorkService_CloneDiscipline_FullMethodName                 = "/work.WorkService/CloneDiscipline"
 )
 
 // WorkServiceClient is the client API for WorkService service.
@@ -73,6 +75,8 @@ type WorkServiceClient interface {
 	GetStudentWorksByDiscipline(ctx context.Context, in *GetStudentWorksByDisciplineRequest, opts ...grpc.CallOption) (*GetStudentWorksByDisciplineResponse, error)
 	UpdateWork(ctx context.Context, in *UpdateWorkRequest, opts ...grpc.CallOption) (*UpdateWorkResponse, error)
 	GetStudentsByGroupAndDiscipline(ctx context.Context, in *GetStudentsByGroupAndDisciplineRequest, opts ...grpc.CallOption) (*GetStudentsByGroupAndDisciplineResponse, error)
+	GetTerms(ctx context.Context, in *GetTermsRequest, opts ...grpc.CallOption) (*GetTermsResponse, error)
+	CloneDiscipline(ctx context.Context, in *CloneDisciplineRequest, opts ...grpc.CallOption) (*CloneDisciplineResponse, error)
 }
 
 type workServiceClient struct {
@@ -323,6 +327,26 @@ func (c *workServiceClient) GetStudentsByGroupAndDiscipline(ctx context.Context,
 	return out, nil
 }
 
+func (c *workServiceClient) GetTerms(ctx context.Context, in *GetTermsRequest, opts ...grpc.CallOption) (*GetTermsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTermsResponse)
+	err := c.cc.Invoke(ctx, WorkService_GetTerms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workServiceClient) CloneDiscipline(ctx context.Context, in *CloneDisciplineRequest, opts ...grpc.CallOption) (*CloneDisciplineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CloneDisciplineResponse)
+	err := c.cc.Invoke(ctx, WorkService_CloneDiscipline_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // WorkServiceServer is the server API for WorkService service.
 // All implementations must embed UnimplementedWorkServiceServer
 // for forward compatibility.
@@ -351,6 +375,8 @@ type WorkServiceServer interface {
 	GetStudentWorksByDiscipline(context.Context, *GetStudentWorksByDisciplineRequest) (*GetStudentWorksByDisciplineResponse, error)
 	UpdateWork(context.Context, *UpdateWorkRequest) (*UpdateWorkResponse, error)
 	GetStudentsByGroupAndDiscipline(context.Context, *GetStudentsByGroupAndDisciplineRequest) (*GetStudentsByGroupAndDisciplineResponse, error)
+	GetTerms(context.Context, *GetTermsRequest) (*GetTermsResponse, error)
+	CloneDiscipline(context.Context, *CloneDisciplineRequest) (*CloneDisciplineResponse, error)
 	mustEmbedUnimplementedWorkServiceServer()
 }
 
@@ -433,6 +459,12 @@ func (UnimplementedWorkServiceServer) UpdateWork(context.Context, *UpdateWorkReq
 func (UnimplementedWorkServiceServer) GetStudentsByGroupAndDiscipline(context.Context, *GetStudentsByGroupAndDisciplineRequest) (*GetStudentsByGroupAndDisciplineResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStudentsByGroupAndDiscipline not implemented")
 }
+func (UnimplementedWorkServiceServer) GetTerms(context.Context, *GetTermsRequest) (*GetTermsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTerms not implemented")
+}
+func (UnimplementedWorkServiceServer) CloneDiscipline(context.Context, *CloneDisciplineRequest) (*CloneDisciplineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneDiscipline not implemented")
+}
 func (UnimplementedWorkServiceServer) mustEmbedUnimplementedWorkServiceServer() {}
 func (UnimplementedWorkServiceServer) testEmbeddedByValue()                     {}
 
@@ -886,6 +918,42 @@ func _WorkService_GetStudentsByGroupAndDiscipline_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkService_GetTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTermsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkServiceServer).GetTerms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkService_GetTerms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkServiceServer).GetTerms(ctx, req.(*GetTermsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkService_CloneDiscipline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneDisciplineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkServiceServer).CloneDiscipline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkService_CloneDiscipline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkServiceServer).CloneDiscipline(ctx, req.(*CloneDisciplineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // WorkService_ServiceDesc is the grpc.ServiceDesc for WorkService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -989,6 +1057,14 @@ var WorkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStudentsByGroupAndDiscipline",
 			Handler:    _WorkService_GetStudentsByGroupAndDiscipline_Handler,
 		},
+		{
+			MethodName: "GetTerms",
+			Handler:    _WorkService_GetTerms_Handler,
+		},
+		{
+			MethodName: "CloneDiscipline",
+			Handler:    _WorkService_CloneDiscipline_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/work/work.proto",